@@ -4,6 +4,7 @@
 	"context"
 
 	"github.com/docker/docker/api/types/checkpoint"
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/client"
 )
 
@@ -12,6 +13,7 @@ type fakeClient struct {
 	checkpointCreateFunc func(container string, options checkpoint.CreateOptions) error
 	checkpointDeleteFunc func(container string, options checkpoint.DeleteOptions) error
 	checkpointListFunc   func(container string, options checkpoint.ListOptions) ([]checkpoint.Summary, error)
+	containerInspectFunc func(containerID string) (container.InspectResponse, error)
 }
 
 func (cli *fakeClient) CheckpointCreate(_ context.Context, container string, options checkpoint.CreateOptions) error {
@@ -34,3 +36,10 @@ func (cli *fakeClient) CheckpointList(_ context.Context, container string, optio
 	}
 	return []checkpoint.Summary{}, nil
 }
+
+func (cli *fakeClient) ContainerInspect(_ context.Context, containerID string) (container.InspectResponse, error) {
+	if cli.containerInspectFunc != nil {
+		return cli.containerInspectFunc(containerID)
+	}
+	return container.InspectResponse{}, nil
+}
@@ -3,6 +3,7 @@
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
@@ -41,13 +42,23 @@ func newCreateCommand(dockerCli command.Cli) *cobra.Command {
 }
 
 func runCreate(ctx context.Context, dockerCli command.Cli, opts createOptions) error {
+	// Only print progress if a terminal is attached, as the checkpoint name
+	// printed on stdout is commonly consumed by scripts.
+	progress := dockerCli.Err().IsTerminal()
+	start := time.Now()
+	if progress {
+		fmt.Fprintf(dockerCli.Err(), "Creating checkpoint %s of container %s...\n", opts.checkpoint, opts.container)
+	}
 	err := dockerCli.Client().CheckpointCreate(ctx, opts.container, checkpoint.CreateOptions{
 		CheckpointID:  opts.checkpoint,
 		CheckpointDir: opts.checkpointDir,
 		Exit:          !opts.leaveRunning,
 	})
 	if err != nil {
-		return err
+		return command.WithCheckpointHint(err)
+	}
+	if progress {
+		fmt.Fprintf(dockerCli.Err(), "Created checkpoint %s in %s\n", opts.checkpoint, time.Since(start).Round(time.Millisecond))
 	}
 
 	fmt.Fprintf(dockerCli.Out(), "%s\n", opts.checkpoint)
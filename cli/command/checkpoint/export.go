@@ -0,0 +1,101 @@
+package checkpoint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type exportOptions struct {
+	container     string
+	checkpoint    string
+	checkpointDir string
+	output        string
+}
+
+func newExportCommand(dockerCli command.Cli) *cobra.Command {
+	var opts exportOptions
+
+	cmd := &cobra.Command{
+		Use:   "export [OPTIONS] CONTAINER CHECKPOINT",
+		Short: "Export a checkpoint to a tar archive",
+		Long: `Export a checkpoint to a tar archive.
+
+The Engine API doesn't support exporting checkpoints, so the checkpoint is read
+from the checkpoint storage directory set with --checkpoint-dir, which must be
+accessible from the client.`,
+		Args: cli.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.container = args[0]
+			opts.checkpoint = args[1]
+			return runExport(cmd.Context(), dockerCli, opts)
+		},
+		ValidArgsFunction: completion.ContainerNames(dockerCli, true),
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.checkpointDir, "checkpoint-dir", "", "Checkpoint storage directory the checkpoint was created in (required)")
+	flags.StringVarP(&opts.output, "output", "o", "", "Write to a file, instead of STDOUT")
+	_ = cmd.MarkFlagRequired("checkpoint-dir")
+
+	return cmd
+}
+
+func runExport(ctx context.Context, dockerCli command.Cli, opts exportOptions) error {
+	if opts.output == "" && dockerCli.Out().IsTerminal() {
+		return errors.New("cowardly refusing to save to a terminal. Use the -o flag or redirect")
+	}
+	if err := command.ValidateOutputPath(opts.output); err != nil {
+		return errors.Wrap(err, "failed to export checkpoint")
+	}
+
+	dir, err := checkpointPath(ctx, dockerCli, opts.container, opts.checkpointDir, opts.checkpoint)
+	if err != nil {
+		return err
+	}
+	if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+		return errors.Errorf("checkpoint %s not found in %s", opts.checkpoint, opts.checkpointDir)
+	}
+
+	content, err := archive.TarWithOptions(dir, &archive.TarOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to export checkpoint")
+	}
+	defer content.Close()
+
+	if opts.output == "" {
+		_, err := io.Copy(dockerCli.Out(), content)
+		return err
+	}
+	if err := command.CopyToFile(opts.output, content); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(dockerCli.Err(), "Successfully exported checkpoint %s to %s\n", opts.checkpoint, opts.output)
+	return nil
+}
+
+// checkpointPath returns the path of the given checkpoint of the container in
+// the checkpoint storage directory. The daemon stores the checkpoints created
+// with a custom storage directory in a sub-directory named after the
+// checkpoint.
+func checkpointPath(ctx context.Context, dockerCli command.Cli, container, checkpointDir, checkpoint string) (string, error) {
+	if checkpoint == "" || checkpoint == "." || checkpoint == ".." || strings.ContainsAny(checkpoint, `/\`) {
+		return "", errors.Errorf("invalid checkpoint name: %s", checkpoint)
+	}
+	// Make sure that the container exists, so that the checkpoint can be
+	// restored with "docker start --checkpoint".
+	if _, err := dockerCli.Client().ContainerInspect(ctx, container); err != nil {
+		return "", err
+	}
+	return filepath.Join(checkpointDir, checkpoint), nil
+}
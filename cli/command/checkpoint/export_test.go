@@ -0,0 +1,88 @@
+package checkpoint
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/container"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/fs"
+)
+
+func TestCheckpointExportImport(t *testing.T) {
+	src := fs.NewDir(t, "checkpoints",
+		fs.WithDir("checkpoint-foo",
+			fs.WithFile("core-1.img", "core"),
+			fs.WithFile("inventory.img", "inventory"),
+		),
+	)
+	dst := fs.NewDir(t, "checkpoints")
+	archive := filepath.Join(t.TempDir(), "checkpoint.tar")
+
+	cli := test.NewFakeCli(&fakeClient{})
+	cmd := newExportCommand(cli)
+	cmd.SetArgs([]string{"--checkpoint-dir", src.Path(), "-o", archive, "container-foo", "checkpoint-foo"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(cli.ErrBuffer().String(), "Successfully exported checkpoint checkpoint-foo to "+archive+"\n"))
+
+	cli = test.NewFakeCli(&fakeClient{})
+	cmd = newImportCommand(cli)
+	cmd.SetArgs([]string{"--checkpoint-dir", dst.Path(), "-i", archive, "container-bar", "checkpoint-bar"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "checkpoint-bar\n"))
+
+	content, err := os.ReadFile(filepath.Join(dst.Path(), "checkpoint-bar", "inventory.img"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(content), "inventory"))
+
+	// importing a checkpoint with the same name again fails
+	cmd = newImportCommand(test.NewFakeCli(&fakeClient{}))
+	cmd.SetArgs([]string{"--checkpoint-dir", dst.Path(), "-i", archive, "container-bar", "checkpoint-bar"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	assert.Check(t, is.Error(cmd.Execute(), "checkpoint checkpoint-bar already exists"))
+}
+
+func TestCheckpointExportErrors(t *testing.T) {
+	dir := fs.NewDir(t, "checkpoints")
+	testCases := []struct {
+		args          []string
+		expectedError string
+	}{
+		{
+			args:          []string{"container-foo", "checkpoint-foo", "--checkpoint-dir", dir.Path(), "-o", "out.tar"},
+			expectedError: "checkpoint checkpoint-foo not found in " + dir.Path(),
+		},
+		{
+			args:          []string{"container-foo", "../checkpoint-foo", "--checkpoint-dir", dir.Path(), "-o", "out.tar"},
+			expectedError: "invalid checkpoint name: ../checkpoint-foo",
+		},
+		{
+			args:          []string{"container-foo", "checkpoint-foo", "-o", "out.tar"},
+			expectedError: `required flag(s) "checkpoint-dir" not set`,
+		},
+		{
+			args:          []string{"container-missing", "checkpoint-foo", "--checkpoint-dir", dir.Path(), "-o", "out.tar"},
+			expectedError: "no such container: container-missing",
+		},
+	}
+	for _, tc := range testCases {
+		cmd := newExportCommand(test.NewFakeCli(&fakeClient{
+			containerInspectFunc: func(ctr string) (container.InspectResponse, error) {
+				if ctr == "container-missing" {
+					return container.InspectResponse{}, errors.New("no such container: " + ctr)
+				}
+				return container.InspectResponse{}, nil
+			},
+		}))
+		cmd.SetArgs(tc.args)
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		assert.Check(t, is.ErrorContains(cmd.Execute(), tc.expectedError))
+	}
+}
@@ -0,0 +1,82 @@
+package checkpoint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type importOptions struct {
+	container     string
+	checkpoint    string
+	checkpointDir string
+	input         string
+}
+
+func newImportCommand(dockerCli command.Cli) *cobra.Command {
+	var opts importOptions
+
+	cmd := &cobra.Command{
+		Use:   "import [OPTIONS] CONTAINER CHECKPOINT",
+		Short: "Import a checkpoint from a tar archive",
+		Long: `Import a checkpoint from a tar archive, created with "docker checkpoint export".
+
+The Engine API doesn't support importing checkpoints, so the checkpoint is
+written to the checkpoint storage directory set with --checkpoint-dir, which
+must be accessible from the client. Use the same directory with
+"docker start --checkpoint-dir" to restore the container from the checkpoint.`,
+		Args: cli.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.container = args[0]
+			opts.checkpoint = args[1]
+			return runImport(cmd.Context(), dockerCli, opts)
+		},
+		ValidArgsFunction: completion.ContainerNames(dockerCli, true),
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.checkpointDir, "checkpoint-dir", "", "Checkpoint storage directory to import the checkpoint in (required)")
+	flags.StringVarP(&opts.input, "input", "i", "", "Read from tar archive file, instead of STDIN")
+	_ = cmd.MarkFlagRequired("checkpoint-dir")
+
+	return cmd
+}
+
+func runImport(ctx context.Context, dockerCli command.Cli, opts importOptions) error {
+	var input io.Reader = dockerCli.In()
+	if opts.input != "" {
+		f, err := os.Open(opts.input)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		input = f
+	} else if dockerCli.In().IsTerminal() {
+		return errors.New("requested import from STDIN, but STDIN is a terminal. Use the -i flag or redirect")
+	}
+
+	dir, err := checkpointPath(ctx, dockerCli, opts.container, opts.checkpointDir, opts.checkpoint)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dir); err == nil {
+		return errors.Errorf("checkpoint %s already exists", opts.checkpoint)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return errors.Wrap(err, "failed to import checkpoint")
+	}
+	if err := archive.Untar(input, dir, &archive.TarOptions{NoLchown: os.Geteuid() != 0}); err != nil {
+		_ = os.RemoveAll(dir)
+		return errors.Wrap(err, "failed to import checkpoint")
+	}
+	_, _ = fmt.Fprintln(dockerCli.Out(), opts.checkpoint)
+	return nil
+}
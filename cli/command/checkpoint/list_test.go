@@ -66,3 +66,15 @@ func TestCheckpointListWithOptions(t *testing.T) {
 	assert.Check(t, is.Equal("/dir/foo", checkpointDir))
 	golden.Assert(t, cli.OutBuffer().String(), "checkpoint-list-with-options.golden")
 }
+
+func TestCheckpointListFormat(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		checkpointListFunc: func(container string, options checkpoint.ListOptions) ([]checkpoint.Summary, error) {
+			return []checkpoint.Summary{{Name: "checkpoint-foo"}, {Name: "checkpoint-bar"}}, nil
+		},
+	})
+	cmd := newListCommand(cli)
+	cmd.SetArgs([]string{"--format", "{{.Name}}", "container-foo"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "checkpoint-foo\ncheckpoint-bar\n"))
+}
@@ -0,0 +1,24 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WithCheckpointHint returns err with a hint on how to resolve it appended, if
+// err indicates that checkpoint and restore are not available on the daemon.
+// Other errors are returned as-is.
+func WithCheckpointHint(err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "criu") && (strings.Contains(msg, "not found") || strings.Contains(msg, "no such file") || strings.Contains(msg, "too old")):
+		return fmt.Errorf("%w\n\nCheckpoint and restore require CRIU to be installed on the daemon host.\nSee https://criu.org/Installation for installation instructions", err)
+	case strings.Contains(msg, "only supported in experimental mode"):
+		return fmt.Errorf("%w\n\nCheckpoint and restore require experimental features to be enabled on the daemon.\nSet \"experimental\": true in the daemon configuration, and restart the daemon", err)
+	default:
+		return err
+	}
+}
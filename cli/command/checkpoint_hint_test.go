@@ -0,0 +1,35 @@
+package command_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/docker/cli/cli/command"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestWithCheckpointHint(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected string
+	}{
+		{
+			err:      errors.New(`failed to create checkpoint: exec: "criu": executable file not found in $PATH`),
+			expected: "Checkpoint and restore require CRIU to be installed on the daemon host.",
+		},
+		{
+			err:      errors.New("checkpoint is only supported in experimental mode"),
+			expected: "Checkpoint and restore require experimental features to be enabled on the daemon.",
+		},
+	}
+	for _, tc := range testCases {
+		err := command.WithCheckpointHint(tc.err)
+		assert.Check(t, is.ErrorContains(err, tc.expected))
+		assert.Check(t, errors.Is(err, tc.err))
+	}
+
+	other := errors.New("no such container: foo")
+	assert.Check(t, is.Equal(command.WithCheckpointHint(other), other))
+	assert.Check(t, command.WithCheckpointHint(nil) == nil)
+}
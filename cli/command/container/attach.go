@@ -3,12 +3,14 @@
 import (
 	"context"
 	"io"
+	"sync"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/moby/sys/signal"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -45,10 +47,20 @@ func NewAttachCommand(dockerCLI command.Cli) *cobra.Command {
 	var opts AttachOptions
 
 	cmd := &cobra.Command{
-		Use:   "attach [OPTIONS] CONTAINER",
+		Use:   "attach [OPTIONS] CONTAINER [CONTAINER...]",
 		Short: "Attach local standard input, output, and error streams to a running container",
-		Args:  cli.ExactArgs(1),
+		Args:  cli.RequiresMinArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				// The standard input is not attached and signals are not
+				// proxied when attaching to multiple containers.
+				for _, name := range []string{"detach-keys", "no-stdin", "sig-proxy"} {
+					if cmd.Flags().Changed(name) {
+						return errors.Errorf("--%s can only be used when attaching to a single container", name)
+					}
+				}
+				return runMultiAttach(cmd.Context(), dockerCLI, args)
+			}
 			containerID := args[0]
 			return RunAttach(cmd.Context(), dockerCLI, containerID, &opts)
 		},
@@ -154,6 +166,60 @@ func RunAttach(ctx context.Context, dockerCLI command.Cli, containerID string, o
 	return getExitStatus(errC, resultC)
 }
 
+// runMultiAttach attaches to the output of multiple containers, prefixing each
+// line with the name of the container it originates from. The standard input
+// is not attached, and signals are not proxied.
+func runMultiAttach(ctx context.Context, dockerCLI command.Cli, containers []string) error {
+	stdouts, stderrs := newPrefixWriters(dockerCLI, containers)
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(containers))
+	for i, c := range containers {
+		wg.Add(1)
+		go func(i int, c string) {
+			defer wg.Done()
+			errs[i] = attachOutput(ctx, dockerCLI.Client(), c, stdouts[i], stderrs[i])
+			_ = stdouts[i].Flush()
+			_ = stderrs[i].Flush()
+		}(i, c)
+	}
+	wg.Wait()
+
+	return containersError(containers, errs)
+}
+
+// attachOutput copies the output of the container to stdout and stderr until
+// the container stops, or ctx is cancelled.
+func attachOutput(ctx context.Context, apiClient client.APIClient, containerID string, stdout, stderr io.Writer) error {
+	c, err := inspectContainerAndCheckState(ctx, apiClient, containerID)
+	if err != nil {
+		return err
+	}
+	resp, err := apiClient.ContainerAttach(ctx, containerID, container.AttachOptions{
+		Stream: true,
+		Stdout: true,
+		Stderr: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	// Closing the connection unblocks the copy below when ctx is cancelled.
+	stop := context.AfterFunc(ctx, resp.Close)
+	defer stop()
+
+	if c.Config.Tty {
+		_, err = io.Copy(stdout, resp.Reader)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, resp.Reader)
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
 func getExitStatus(errC <-chan error, resultC <-chan container.WaitResponse) error {
 	select {
 	case result := <-resultC:
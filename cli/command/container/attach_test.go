@@ -1,14 +1,20 @@
 package container
 
 import (
+	"context"
 	"io"
+	"net"
+	"strings"
 	"testing"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/pkg/errors"
 	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
 )
 
 func TestNewAttachCommandErrors(t *testing.T) {
@@ -134,3 +140,54 @@ func TestGetExitStatus(t *testing.T) {
 		}
 	}
 }
+
+func TestAttachMultipleContainers(t *testing.T) {
+	fakeCLI := test.NewFakeCli(&fakeClient{
+		inspectFunc: func(containerID string) (container.InspectResponse, error) {
+			return container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					State: &container.State{Running: containerID != "stopped"},
+				},
+				Config: &container.Config{Tty: containerID == "tty"},
+			}, nil
+		},
+		containerAttachFunc: func(_ context.Context, containerID string, options container.AttachOptions) (types.HijackedResponse, error) {
+			assert.Check(t, !options.Stdin)
+			server, client := net.Pipe()
+			go func() {
+				defer server.Close()
+				if containerID == "tty" {
+					_, _ = io.WriteString(server, "hello from tty\n")
+					return
+				}
+				_, _ = io.WriteString(stdcopy.NewStdWriter(server, stdcopy.Stdout), "hello from stdout\n")
+				_, _ = io.WriteString(stdcopy.NewStdWriter(server, stdcopy.Stderr), "hello from stderr\n")
+			}()
+			return types.NewHijackedResponse(client, ""), nil
+		},
+	})
+	cmd := NewAttachCommand(fakeCLI)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"web", "tty", "stopped"})
+
+	err := cmd.Execute()
+	assert.Check(t, is.Error(err, "stopped: You cannot attach to a stopped container, start it first"))
+	assert.Check(t, is.Contains(fakeCLI.OutBuffer().String(), "web     | hello from stdout\n"))
+	assert.Check(t, is.Contains(fakeCLI.OutBuffer().String(), "tty     | hello from tty\n"))
+	assert.Check(t, is.Equal(fakeCLI.ErrBuffer().String(), "web     | hello from stderr\n"))
+}
+
+func TestAttachMultipleContainersFlags(t *testing.T) {
+	for _, flag := range []string{"--detach-keys=ctrl-x", "--no-stdin", "--sig-proxy=false"} {
+		flag := flag
+		t.Run(flag, func(t *testing.T) {
+			cmd := NewAttachCommand(test.NewFakeCli(&fakeClient{}))
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs([]string{flag, "web", "worker"})
+			name, _, _ := strings.Cut(flag, "=")
+			assert.Check(t, is.Error(cmd.Execute(), name+" can only be used when attaching to a single container"))
+		})
+	}
+}
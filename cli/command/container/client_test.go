@@ -5,10 +5,13 @@
 	"io"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/checkpoint"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/api/types/system"
 	"github.com/docker/docker/client"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
@@ -29,6 +32,7 @@ type fakeClient struct {
 	infoFunc                func() (system.Info, error)
 	containerStatPathFunc   func(containerID, path string) (container.PathStat, error)
 	containerCopyFromFunc   func(containerID, srcPath string) (io.ReadCloser, container.PathStat, error)
+	containerCopyToFunc     func(containerID, path string, content io.Reader, options container.CopyToContainerOptions) error
 	logFunc                 func(string, container.LogsOptions) (io.ReadCloser, error)
 	waitFunc                func(string) (<-chan container.WaitResponse, <-chan error)
 	containerListFunc       func(container.ListOptions) ([]container.Summary, error)
@@ -36,8 +40,19 @@ type fakeClient struct {
 	containerExecResizeFunc func(id string, options container.ResizeOptions) error
 	containerRemoveFunc     func(ctx context.Context, containerID string, options container.RemoveOptions) error
 	containerKillFunc       func(ctx context.Context, containerID, signal string) error
+	checkpointListFunc      func(container string, options checkpoint.ListOptions) ([]checkpoint.Summary, error)
+	distributionInspectFunc func(imageRef string) (registry.DistributionInspect, error)
 	containerPruneFunc      func(ctx context.Context, pruneFilters filters.Args) (container.PruneReport, error)
 	containerAttachFunc     func(ctx context.Context, containerID string, options container.AttachOptions) (types.HijackedResponse, error)
+	eventsFunc              func(options events.ListOptions) (<-chan events.Message, <-chan error)
+	containerTopFunc        func(containerID string, arguments []string) (container.ContainerTopOKBody, error)
+	containerUpdateFunc     func(containerID string, updateConfig container.UpdateConfig) (container.ContainerUpdateOKBody, error)
+	containerRestartFunc    func(containerID string, options container.StopOptions) error
+	containerDiffFunc       func(containerID string) ([]container.FilesystemChange, error)
+	containerCommitFunc     func(containerID string, options container.CommitOptions) (types.IDResponse, error)
+	inspectWithRawFunc      func(containerID string, getSize bool) (container.InspectResponse, []byte, error)
+	containerPauseFunc      func(containerID string) error
+	containerUnpauseFunc    func(containerID string) error
 	Version                 string
 }
 
@@ -122,6 +137,13 @@ func (f *fakeClient) CopyFromContainer(_ context.Context, containerID, srcPath s
 	return nil, container.PathStat{}, nil
 }
 
+func (f *fakeClient) CopyToContainer(_ context.Context, containerID, path string, content io.Reader, options container.CopyToContainerOptions) error {
+	if f.containerCopyToFunc != nil {
+		return f.containerCopyToFunc(containerID, path, content, options)
+	}
+	return nil
+}
+
 func (f *fakeClient) ContainerLogs(_ context.Context, containerID string, options container.LogsOptions) (io.ReadCloser, error) {
 	if f.logFunc != nil {
 		return f.logFunc(containerID, options)
@@ -181,3 +203,80 @@ func (f *fakeClient) ContainerAttach(ctx context.Context, containerID string, op
 	}
 	return types.HijackedResponse{}, nil
 }
+
+func (f *fakeClient) Events(_ context.Context, options events.ListOptions) (<-chan events.Message, <-chan error) {
+	if f.eventsFunc != nil {
+		return f.eventsFunc(options)
+	}
+	return nil, nil
+}
+
+func (f *fakeClient) ContainerTop(_ context.Context, containerID string, arguments []string) (container.ContainerTopOKBody, error) {
+	if f.containerTopFunc != nil {
+		return f.containerTopFunc(containerID, arguments)
+	}
+	return container.ContainerTopOKBody{}, nil
+}
+
+func (f *fakeClient) ContainerUpdate(_ context.Context, containerID string, updateConfig container.UpdateConfig) (container.ContainerUpdateOKBody, error) {
+	if f.containerUpdateFunc != nil {
+		return f.containerUpdateFunc(containerID, updateConfig)
+	}
+	return container.ContainerUpdateOKBody{}, nil
+}
+
+func (f *fakeClient) ContainerRestart(_ context.Context, containerID string, options container.StopOptions) error {
+	if f.containerRestartFunc != nil {
+		return f.containerRestartFunc(containerID, options)
+	}
+	return nil
+}
+
+func (f *fakeClient) ContainerDiff(_ context.Context, containerID string) ([]container.FilesystemChange, error) {
+	if f.containerDiffFunc != nil {
+		return f.containerDiffFunc(containerID)
+	}
+	return nil, nil
+}
+
+func (f *fakeClient) ContainerCommit(_ context.Context, containerID string, options container.CommitOptions) (types.IDResponse, error) {
+	if f.containerCommitFunc != nil {
+		return f.containerCommitFunc(containerID, options)
+	}
+	return types.IDResponse{}, nil
+}
+
+func (f *fakeClient) ContainerInspectWithRaw(_ context.Context, containerID string, getSize bool) (container.InspectResponse, []byte, error) {
+	if f.inspectWithRawFunc != nil {
+		return f.inspectWithRawFunc(containerID, getSize)
+	}
+	return container.InspectResponse{}, nil, nil
+}
+
+func (f *fakeClient) ContainerPause(_ context.Context, containerID string) error {
+	if f.containerPauseFunc != nil {
+		return f.containerPauseFunc(containerID)
+	}
+	return nil
+}
+
+func (f *fakeClient) ContainerUnpause(_ context.Context, containerID string) error {
+	if f.containerUnpauseFunc != nil {
+		return f.containerUnpauseFunc(containerID)
+	}
+	return nil
+}
+
+func (f *fakeClient) CheckpointList(_ context.Context, container string, options checkpoint.ListOptions) ([]checkpoint.Summary, error) {
+	if f.checkpointListFunc != nil {
+		return f.checkpointListFunc(container, options)
+	}
+	return nil, nil
+}
+
+func (f *fakeClient) DistributionInspect(_ context.Context, imageRef, _ string) (registry.DistributionInspect, error) {
+	if f.distributionInspectFunc != nil {
+		return f.distributionInspectFunc(imageRef)
+	}
+	return registry.DistributionInspect{}, nil
+}
@@ -0,0 +1,189 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/opts"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/go-connections/nat"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type cloneOptions struct {
+	source  string
+	name    string
+	image   string
+	env     opts.ListOpts
+	labels  opts.ListOpts
+	publish opts.ListOpts
+	start   bool
+}
+
+// NewCloneCommand creates a new cobra.Command for `docker container clone`
+func NewCloneCommand(dockerCli command.Cli) *cobra.Command {
+	options := cloneOptions{
+		env:     opts.NewListOpts(opts.ValidateEnv),
+		labels:  opts.NewListOpts(opts.ValidateLabel),
+		publish: opts.NewListOpts(nil),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "clone [OPTIONS] CONTAINER",
+		Short: "Create a new container with the configuration of an existing container",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.source = args[0]
+			return runClone(cmd.Context(), dockerCli, &options)
+		},
+		ValidArgsFunction: completion.ContainerNames(dockerCli, true),
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.name, "name", "", "Assign a name to the new container")
+	flags.StringVar(&options.image, "image", "", "Use a different image for the new container")
+	flags.VarP(&options.env, "env", "e", "Set or override environment variables")
+	flags.VarP(&options.labels, "label", "l", "Set or override metadata on the new container")
+	flags.VarP(&options.publish, "publish", "p", `Remap a port published by the container (e.g. "8081:80")`)
+	flags.BoolVar(&options.start, "start", false, "Start the new container")
+
+	_ = cmd.RegisterFlagCompletionFunc("env", completion.EnvVarNames)
+	_ = cmd.RegisterFlagCompletionFunc("image", completion.ImageNames(dockerCli))
+
+	return cmd
+}
+
+func runClone(ctx context.Context, dockerCli command.Cli, options *cloneOptions) error {
+	apiClient := dockerCli.Client()
+
+	source, err := apiClient.ContainerInspect(ctx, options.source)
+	if err != nil {
+		return err
+	}
+	config, hostConfig, networkingConfig, err := cloneConfig(source, options)
+	if err != nil {
+		return err
+	}
+
+	response, err := apiClient.ContainerCreate(ctx, config, hostConfig, networkingConfig, nil, options.name)
+	if err != nil {
+		return err
+	}
+	for _, w := range response.Warnings {
+		_, _ = fmt.Fprintf(dockerCli.Err(), "WARNING: %s\n", w)
+	}
+	if options.start {
+		if err := apiClient.ContainerStart(ctx, response.ID, container.StartOptions{}); err != nil {
+			return errors.Wrapf(err, "failed to start container %s", response.ID)
+		}
+	}
+	_, _ = fmt.Fprintln(dockerCli.Out(), response.ID)
+	return nil
+}
+
+// cloneConfig returns the configuration of a new container with the same
+// configuration as the source container, and the overrides in options applied.
+//
+// Settings that are specific to the source container, such as its hostname
+// (if it was not set explicitly), static IP and MAC addresses, are not cloned.
+func cloneConfig(source container.InspectResponse, options *cloneOptions) (*container.Config, *container.HostConfig, *network.NetworkingConfig, error) {
+	if source.ContainerJSONBase == nil || source.Config == nil || source.HostConfig == nil {
+		return nil, nil, nil, errors.Errorf("container %s has no configuration", options.source)
+	}
+
+	config := *source.Config
+	config.Image = source.Image
+	if options.image != "" {
+		config.Image = options.image
+	}
+	if config.Hostname == stringid.TruncateID(source.ID) {
+		config.Hostname = ""
+	}
+	config.MacAddress = "" //nolint:staticcheck // ignore SA1019: field is deprecated, but still used on API < v1.44.
+	config.Env = mergeEnv(config.Env, options.env.GetAll())
+	config.Labels = maps.Clone(config.Labels)
+	if labels := options.labels.GetAll(); len(labels) > 0 {
+		if config.Labels == nil {
+			config.Labels = make(map[string]string, len(labels))
+		}
+		maps.Copy(config.Labels, opts.ConvertKVStringsToMap(labels))
+	}
+
+	hostConfig := *source.HostConfig
+	if publish := options.publish.GetAll(); len(publish) > 0 {
+		exposedPorts, portBindings, err := nat.ParsePortSpecs(publish)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		config.ExposedPorts = maps.Clone(config.ExposedPorts)
+		if config.ExposedPorts == nil {
+			config.ExposedPorts = make(nat.PortSet, len(exposedPorts))
+		}
+		maps.Copy(config.ExposedPorts, exposedPorts)
+		hostConfig.PortBindings = maps.Clone(hostConfig.PortBindings)
+		if hostConfig.PortBindings == nil {
+			hostConfig.PortBindings = make(nat.PortMap, len(portBindings))
+		}
+		maps.Copy(hostConfig.PortBindings, portBindings)
+	}
+
+	networkingConfig := &network.NetworkingConfig{}
+	if mode := hostConfig.NetworkMode; source.NetworkSettings != nil && (mode.IsDefault() || mode.IsBridge() || mode.IsUserDefined()) {
+		for name, ep := range source.NetworkSettings.Networks {
+			if ep == nil {
+				continue
+			}
+			if networkingConfig.EndpointsConfig == nil {
+				networkingConfig.EndpointsConfig = make(map[string]*network.EndpointSettings)
+			}
+			networkingConfig.EndpointsConfig[name] = &network.EndpointSettings{
+				Aliases:    cloneAliases(ep.Aliases, source.ID),
+				Links:      ep.Links,
+				DriverOpts: ep.DriverOpts,
+			}
+		}
+	}
+	return &config, &hostConfig, networkingConfig, nil
+}
+
+// mergeEnv returns env with the variables in overrides added, or replacing
+// the variables with the same name.
+func mergeEnv(env []string, overrides []string) []string {
+	result := make([]string, 0, len(env)+len(overrides))
+	for _, e := range env {
+		name, _, _ := strings.Cut(e, "=")
+		if !containsEnv(overrides, name) {
+			result = append(result, e)
+		}
+	}
+	return append(result, overrides...)
+}
+
+func containsEnv(env []string, name string) bool {
+	for _, e := range env {
+		if n, _, _ := strings.Cut(e, "="); n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// cloneAliases returns the network aliases of the source container, without
+// the alias that the daemon adds for the short ID of the container.
+func cloneAliases(aliases []string, id string) []string {
+	var result []string
+	for _, a := range aliases {
+		if a != stringid.TruncateID(id) {
+			result = append(result, a)
+		}
+	}
+	return result
+}
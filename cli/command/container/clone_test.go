@@ -0,0 +1,113 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestContainerClone(t *testing.T) {
+	const sourceID = "0123456789abcdef0123456789abcdef"
+	var started string
+	cli := test.NewFakeCli(&fakeClient{
+		inspectFunc: func(string) (container.InspectResponse, error) {
+			return container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					ID:    sourceID,
+					Image: "sha256:image-id",
+					HostConfig: &container.HostConfig{
+						NetworkMode: "mynet",
+						Binds:       []string{"data:/data"},
+						PortBindings: nat.PortMap{
+							"80/tcp":  {{HostPort: "8080"}},
+							"443/tcp": {{HostPort: "8443"}},
+						},
+					},
+				},
+				Config: &container.Config{
+					Hostname: "0123456789ab",
+					Image:    "nginx:latest",
+					Env:      []string{"FOO=foo", "BAR=bar"},
+					Labels:   map[string]string{"com.example.label": "source"},
+					ExposedPorts: nat.PortSet{
+						"80/tcp":  {},
+						"443/tcp": {},
+					},
+				},
+				NetworkSettings: &container.NetworkSettings{
+					Networks: map[string]*network.EndpointSettings{
+						"mynet": {
+							Aliases:   []string{"web", "0123456789ab"},
+							IPAddress: "172.18.0.2",
+						},
+					},
+				},
+			}, nil
+		},
+		createContainerFunc: func(config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, _ *specs.Platform, name string) (container.CreateResponse, error) {
+			assert.Check(t, is.Equal(name, "web-debug"))
+			assert.Check(t, is.Equal(config.Image, "sha256:image-id"))
+			assert.Check(t, is.Equal(config.Hostname, ""))
+			assert.Check(t, is.DeepEqual(config.Env, []string{"BAR=bar", "FOO=override", "DEBUG=1"}))
+			assert.Check(t, is.DeepEqual(config.Labels, map[string]string{"com.example.label": "source", "debug": "true"}))
+			assert.Check(t, is.DeepEqual(hostConfig.Binds, []string{"data:/data"}))
+			assert.Check(t, is.DeepEqual(hostConfig.PortBindings, nat.PortMap{
+				"80/tcp":  {{HostPort: "8081"}},
+				"443/tcp": {{HostPort: "8443"}},
+			}))
+			assert.Check(t, is.DeepEqual(networkingConfig.EndpointsConfig, map[string]*network.EndpointSettings{
+				"mynet": {Aliases: []string{"web"}},
+			}))
+			return container.CreateResponse{ID: "new-id"}, nil
+		},
+		containerStartFunc: func(containerID string, _ container.StartOptions) error {
+			started = containerID
+			return nil
+		},
+	})
+	cmd := NewCloneCommand(cli)
+	cmd.SetArgs([]string{
+		"--name", "web-debug",
+		"-e", "FOO=override", "-e", "DEBUG=1",
+		"-l", "debug=true",
+		"-p", "8081:80",
+		"--start",
+		"web",
+	})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(started, "new-id"))
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "new-id\n"))
+}
+
+func TestContainerCloneHostNetwork(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		inspectFunc: func(string) (container.InspectResponse, error) {
+			return container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					ID:         "source-id",
+					Image:      "sha256:image-id",
+					HostConfig: &container.HostConfig{NetworkMode: "host"},
+				},
+				Config: &container.Config{Hostname: "myhost"},
+				NetworkSettings: &container.NetworkSettings{
+					Networks: map[string]*network.EndpointSettings{"host": {}},
+				},
+			}, nil
+		},
+		createContainerFunc: func(config *container.Config, _ *container.HostConfig, networkingConfig *network.NetworkingConfig, _ *specs.Platform, _ string) (container.CreateResponse, error) {
+			assert.Check(t, is.Equal(config.Image, "alpine"))
+			assert.Check(t, is.Equal(config.Hostname, "myhost"))
+			assert.Check(t, is.Len(networkingConfig.EndpointsConfig, 0))
+			return container.CreateResponse{ID: "new-id"}, nil
+		},
+	})
+	cmd := NewCloneCommand(cli)
+	cmd.SetArgs([]string{"--image", "alpine", "source"})
+	assert.NilError(t, cmd.Execute())
+}
@@ -16,6 +16,7 @@ func NewContainerCommand(dockerCli command.Cli) *cobra.Command {
 	}
 	cmd.AddCommand(
 		NewAttachCommand(dockerCli),
+		NewCloneCommand(dockerCli),
 		NewCommitCommand(dockerCli),
 		NewCopyCommand(dockerCli),
 		NewCreateCommand(dockerCli),
@@ -26,6 +27,7 @@ func NewContainerCommand(dockerCli command.Cli) *cobra.Command {
 		NewLogsCommand(dockerCli),
 		NewPauseCommand(dockerCli),
 		NewPortCommand(dockerCli),
+		NewPortsCommand(dockerCli),
 		NewRenameCommand(dockerCli),
 		NewRestartCommand(dockerCli),
 		NewRmCommand(dockerCli),
@@ -33,6 +35,7 @@ func NewContainerCommand(dockerCli command.Cli) *cobra.Command {
 		NewStartCommand(dockerCli),
 		NewStatsCommand(dockerCli),
 		NewStopCommand(dockerCli),
+		NewTemplateCommand(dockerCli),
 		NewTopCommand(dockerCli),
 		NewUnpauseCommand(dockerCli),
 		NewUpdateCommand(dockerCli),
@@ -1,14 +1,19 @@
 package container
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"os"
+	"strings"
+	"unicode"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
 	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -16,10 +21,11 @@ type commitOptions struct {
 	container string
 	reference string
 
-	pause   bool
-	comment string
-	author  string
-	changes opts.ListOpts
+	pause       bool
+	comment     string
+	author      string
+	changes     opts.ListOpts
+	changesFile string
 }
 
 // NewCommitCommand creates a new cobra.Command for `docker commit`
@@ -52,16 +58,29 @@ func NewCommitCommand(dockerCli command.Cli) *cobra.Command {
 
 	options.changes = opts.NewListOpts(nil)
 	flags.VarP(&options.changes, "change", "c", "Apply Dockerfile instruction to the created image")
+	flags.StringVar(&options.changesFile, "changes-file", "", "Read Dockerfile instructions to apply to the created image from a file")
 
 	return cmd
 }
 
 func runCommit(ctx context.Context, dockerCli command.Cli, options *commitOptions) error {
+	var changes []string
+	if options.changesFile != "" {
+		var err error
+		changes, err = readChangesFile(options.changesFile)
+		if err != nil {
+			return err
+		}
+	}
+	// Changes passed on the command-line are applied last, so that they
+	// take precedence over the ones of the file.
+	changes = append(changes, options.changes.GetAll()...)
+
 	response, err := dockerCli.Client().ContainerCommit(ctx, options.container, container.CommitOptions{
 		Reference: options.reference,
 		Comment:   options.comment,
 		Author:    options.author,
-		Changes:   options.changes.GetAll(),
+		Changes:   changes,
 		Pause:     options.pause,
 	})
 	if err != nil {
@@ -71,3 +90,74 @@ func runCommit(ctx context.Context, dockerCli command.Cli, options *commitOption
 	fmt.Fprintln(dockerCli.Out(), response.ID)
 	return nil
 }
+
+// readChangesFile reads the Dockerfile instructions of a changes file, one
+// instruction per line. Lines ending with a backslash are continued on the
+// next line, and empty lines and lines starting with "#" are ignored.
+//
+// Environment variables ("$VAR" or "${VAR}") are replaced by their value in
+// the environment of the CLI. "$$" is replaced by a literal "$", to reference
+// variables of the image (e.g. "ENV PATH=/app/bin:$$PATH").
+func readChangesFile(filename string) ([]string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var (
+		changes []string
+		current strings.Builder
+		lineNum int
+	)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if current.Len() > 0 {
+			// As in a Dockerfile, the leading whitespace of a continuation
+			// line is kept.
+			line = strings.TrimRightFunc(scanner.Text(), unicode.IsSpace)
+		}
+		if strings.HasSuffix(line, "\\") {
+			current.WriteString(strings.TrimSuffix(line, "\\"))
+			continue
+		}
+		current.WriteString(line)
+
+		change, err := expandChangeEnv(current.String())
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s: line %d", filename, lineNum)
+		}
+		changes = append(changes, change)
+		current.Reset()
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if current.Len() > 0 {
+		return nil, errors.Errorf("%s: line %d: unexpected end of file after line continuation", filename, lineNum)
+	}
+	return changes, nil
+}
+
+func expandChangeEnv(change string) (string, error) {
+	var missing []string
+	expanded := os.Expand(change, func(name string) string {
+		if name == "$" {
+			return "$"
+		}
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+		return v
+	})
+	if len(missing) > 0 {
+		return "", errors.Errorf("variable %s is not set", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
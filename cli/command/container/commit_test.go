@@ -0,0 +1,90 @@
+package container
+
+import (
+	"io"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/fs"
+)
+
+func TestCommitChangesFile(t *testing.T) {
+	t.Setenv("APP_VERSION", "1.2.3")
+	changesFile := fs.NewFile(t, "changes", fs.WithContent(`# metadata of the image
+LABEL org.opencontainers.image.version=${APP_VERSION} \
+      org.opencontainers.image.title=app
+
+ENV PATH=/app/bin:$$PATH
+EXPOSE 8080
+ENTRYPOINT ["/app/bin/app"]
+`))
+
+	var changes []string
+	cli := test.NewFakeCli(&fakeClient{
+		containerCommitFunc: func(_ string, options container.CommitOptions) (types.IDResponse, error) {
+			changes = options.Changes
+			return types.IDResponse{ID: "sha256:1234"}, nil
+		},
+	})
+	cmd := NewCommitCommand(cli)
+	cmd.SetArgs([]string{"--changes-file", changesFile.Path(), "--change", "EXPOSE 9090", "foo", "app:latest"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.DeepEqual(changes, []string{
+		"LABEL org.opencontainers.image.version=1.2.3       org.opencontainers.image.title=app",
+		"ENV PATH=/app/bin:$PATH",
+		"EXPOSE 8080",
+		`ENTRYPOINT ["/app/bin/app"]`,
+		"EXPOSE 9090",
+	}))
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "sha256:1234\n"))
+}
+
+func TestCommitChangesFileContinuation(t *testing.T) {
+	changesFile := fs.NewFile(t, "changes", fs.WithContent("LABEL a=b\\\n  c=d\nLABEL e=f \\\n# comment\n\n  g=h\n"))
+
+	var changes []string
+	fakeCLI := test.NewFakeCli(&fakeClient{
+		containerCommitFunc: func(_ string, options container.CommitOptions) (types.IDResponse, error) {
+			changes = options.Changes
+			return types.IDResponse{ID: "sha256:1234"}, nil
+		},
+	})
+	cmd := NewCommitCommand(fakeCLI)
+	cmd.SetArgs([]string{"--changes-file", changesFile.Path(), "foo"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.DeepEqual(changes, []string{"LABEL a=b  c=d", "LABEL e=f   g=h"}))
+}
+
+func TestCommitChangesFileErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "unset variable",
+			content:  "EXPOSE 80\nLABEL version=$NO_SUCH_VARIABLE\n",
+			expected: "line 2: variable NO_SUCH_VARIABLE is not set",
+		},
+		{
+			name:     "unterminated continuation",
+			content:  "LABEL a=b \\\n",
+			expected: "line 1: unexpected end of file after line continuation",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			changesFile := fs.NewFile(t, "changes", fs.WithContent(tc.content))
+			cmd := NewCommitCommand(test.NewFakeCli(&fakeClient{}))
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs([]string{"--changes-file", changesFile.Path(), "foo"})
+			assert.Check(t, is.ErrorContains(cmd.Execute(), tc.expected))
+		})
+	}
+}
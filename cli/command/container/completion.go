@@ -1,6 +1,8 @@
 package container
 
 import (
+	"sort"
+
 	"github.com/docker/cli/cli/command/completion"
 	"github.com/docker/docker/api/types/container"
 	"github.com/moby/sys/signal"
@@ -84,11 +86,14 @@ func completeRestartPolicies(cmd *cobra.Command, args []string, toComplete strin
 	return completion.FromList(restartPolicies...)(cmd, args, toComplete)
 }
 
+// completeSignals completes the names of the signals that can be sent to a
+// container, both with and without the "SIG" prefix (for example, "KILL" or
+// "SIGKILL").
 func completeSignals(cmd *cobra.Command, args []string, toComplete string) (names []string, _ cobra.ShellCompDirective) {
-	// TODO(thaJeztah): do we want to provide the full list here, or a subset?
-	signalNames := make([]string, 0, len(signal.SignalMap))
+	signalNames := make([]string, 0, 2*len(signal.SignalMap))
 	for k := range signal.SignalMap {
-		signalNames = append(signalNames, k)
+		signalNames = append(signalNames, k, "SIG"+k)
 	}
+	sort.Strings(signalNames)
 	return completion.FromList(signalNames...)(cmd, args, toComplete)
 }
@@ -1,7 +1,7 @@
 package container
 
 import (
-	"bytes"
+	"archive/tar"
 	"context"
 	"fmt"
 	"io"
@@ -14,12 +14,11 @@
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/internal/transfer"
 	"github.com/docker/cli/cli/streams"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/system"
-	units "github.com/docker/go-units"
-	"github.com/morikuni/aec"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -30,6 +29,7 @@ type copyOptions struct {
 	followLink  bool
 	copyUIDGID  bool
 	quiet       bool
+	compress    bool
 }
 
 type copyDirection int
@@ -44,82 +44,124 @@ type cpConfig struct {
 	followLink bool
 	copyUIDGID bool
 	quiet      bool
+	compress   bool
 	sourcePath string
 	destPath   string
 	container  string
 }
 
-// copyProgressPrinter wraps io.ReadCloser to print progress information when
-// copying files to/from a container.
-type copyProgressPrinter struct {
-	io.ReadCloser
-	total *int64
-}
-
 const (
-	copyToContainerHeader       = "Copying to container - "
-	copyFromContainerHeader     = "Copying from container - "
-	copyProgressUpdateThreshold = 75 * time.Millisecond
+	copyToContainerHeader   = "Copying to container - "
+	copyFromContainerHeader = "Copying from container - "
 )
 
-func (pt *copyProgressPrinter) Read(p []byte) (int, error) {
-	n, err := pt.ReadCloser.Read(p)
-	atomic.AddInt64(pt.total, int64(n))
-	return n, err
+// copyStats holds the progress of a copy. size and files are updated
+// atomically while the copy is in progress. The expected totals are zero if
+// they are not known in advance.
+type copyStats struct {
+	size       int64
+	files      int64
+	totalSize  int64
+	totalFiles int64
 }
 
-func copyProgress(ctx context.Context, dst io.Writer, header string, total *int64) (func(), <-chan struct{}) {
-	done := make(chan struct{})
-	if !streams.NewOut(dst).IsTerminal() {
-		close(done)
-		return func() {}, done
-	}
-
-	fmt.Fprint(dst, aec.Save)
-	fmt.Fprint(dst, "Preparing to copy...")
+// progress formats the progress of the copy, estimating the remaining time
+// from the transfer rate since the copy started.
+func (s *copyStats) progress(elapsed time.Duration) string {
+	n := atomic.LoadInt64(&s.size)
+	files := atomic.LoadInt64(&s.files)
 
-	restore := func() {
-		fmt.Fprint(dst, aec.Restore)
-		fmt.Fprint(dst, aec.EraseLine(aec.EraseModes.All))
+	var b strings.Builder
+	b.WriteString(transfer.HumanSize(n))
+	if s.totalSize > 0 {
+		fmt.Fprintf(&b, " / %s (%d%%)", transfer.HumanSize(s.totalSize), min(n*100/s.totalSize, 100))
 	}
+	if s.totalFiles > 0 {
+		fmt.Fprintf(&b, ", %d/%d files", files, s.totalFiles)
+	} else {
+		fmt.Fprintf(&b, ", %d files", files)
+	}
+	if s.totalSize > n && n > 0 && elapsed > 0 {
+		remaining := time.Duration(float64(s.totalSize-n) / float64(n) * float64(elapsed))
+		fmt.Fprintf(&b, ", ETA %s", remaining.Round(time.Second))
+	}
+	return b.String()
+}
 
+// archiveEntryCounter passes through a tar archive unmodified, while counting
+// its entries from a copy of the stream.
+type archiveEntryCounter struct {
+	io.ReadCloser
+	tee *io.PipeWriter
+}
+
+// countArchiveEntries returns a reader of the tar archive read from src,
+// incrementing files for every non-directory entry that went through. The
+// archive itself is not altered.
+func countArchiveEntries(src io.ReadCloser, files *int64) io.ReadCloser {
+	pr, pw := io.Pipe()
 	go func() {
-		defer close(done)
-		fmt.Fprint(dst, aec.Hide)
-		defer fmt.Fprint(dst, aec.Show)
+		tr := tar.NewReader(pr)
+		for {
+			hdr, err := tr.Next()
+			if err != nil {
+				break
+			}
+			if hdr.Typeflag != tar.TypeDir {
+				atomic.AddInt64(files, 1)
+			}
+		}
+		// Keep consuming the copy of the stream, so that reading the archive
+		// never blocks, even if it can't be parsed.
+		_, _ = io.Copy(io.Discard, pr)
+	}()
+	return &archiveEntryCounter{ReadCloser: src, tee: pw}
+}
 
-		fmt.Fprint(dst, aec.Restore)
-		fmt.Fprint(dst, aec.EraseLine(aec.EraseModes.All))
-		fmt.Fprint(dst, header)
+func (c *archiveEntryCounter) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	if n > 0 {
+		_, _ = c.tee.Write(p[:n])
+	}
+	if err != nil {
+		c.tee.Close()
+	}
+	return n, err
+}
 
-		var last int64
-		fmt.Fprint(dst, progressHumanSize(last))
+func (c *archiveEntryCounter) Close() error {
+	c.tee.Close()
+	return c.ReadCloser.Close()
+}
 
-		buf := bytes.NewBuffer(nil)
-		ticker := time.NewTicker(copyProgressUpdateThreshold)
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				n := atomic.LoadInt64(total)
-				if n == last {
-					// Don't write to the terminal, if we don't need to.
-					continue
-				}
+// tarBlockSize is the size of the blocks a tar archive is made of.
+const tarBlockSize = 512
 
-				// Write to the buffer first to avoid flickering and context switching
-				fmt.Fprint(buf, aec.Column(uint(len(header)+1)))
-				fmt.Fprint(buf, aec.EraseLine(aec.EraseModes.Tail))
-				fmt.Fprint(buf, progressHumanSize(n))
+// tarEntrySize returns the size of a tar archive entry, including its header,
+// for a file of the given size.
+func tarEntrySize(size int64) int64 {
+	return tarBlockSize + (size+tarBlockSize-1)/tarBlockSize*tarBlockSize
+}
 
-				buf.WriteTo(dst)
-				buf.Reset()
-				last += n
-			}
+// estimateArchiveSize walks path to estimate the size of a tar archive of
+// its content, and the number of files it contains.
+func estimateArchiveSize(path string) (size int64, files int64, err error) {
+	err = filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
 		}
-	}()
-	return restore, done
+		if info.Mode().IsRegular() {
+			size += tarEntrySize(info.Size())
+		} else {
+			size += tarEntrySize(0)
+		}
+		if !info.IsDir() {
+			files++
+		}
+		return nil
+	})
+	// An archive ends with two zero-filled blocks.
+	return size + 2*tarBlockSize, files, err
 }
 
 // NewCopyCommand creates a new `docker cp` command
@@ -162,13 +204,10 @@ func NewCopyCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVarP(&opts.followLink, "follow-link", "L", false, "Always follow symbol link in SRC_PATH")
 	flags.BoolVarP(&opts.copyUIDGID, "archive", "a", false, "Archive mode (copy all uid/gid information)")
 	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Suppress progress output during copy. Progress output is automatically suppressed if no terminal is attached")
+	flags.BoolVar(&opts.compress, "compress", false, "Compress the archive sent to the container using gzip")
 	return cmd
 }
 
-func progressHumanSize(n int64) string {
-	return units.HumanSizeWithPrecision(float64(n), 3)
-}
-
 func runCopy(ctx context.Context, dockerCli command.Cli, opts copyOptions) error {
 	srcContainer, srcPath := splitCpArg(opts.source)
 	destContainer, destPath := splitCpArg(opts.destination)
@@ -177,6 +216,7 @@ func runCopy(ctx context.Context, dockerCli command.Cli, opts copyOptions) error
 		followLink: opts.followLink,
 		copyUIDGID: opts.copyUIDGID,
 		quiet:      opts.quiet,
+		compress:   opts.compress,
 		sourcePath: srcPath,
 		destPath:   destPath,
 	}
@@ -191,6 +231,10 @@ func runCopy(ctx context.Context, dockerCli command.Cli, opts copyOptions) error
 		copyConfig.container = destContainer
 	}
 
+	if opts.compress && direction == fromContainer {
+		return errors.New("--compress is only supported when copying to a container")
+	}
+
 	switch direction {
 	case fromContainer:
 		return copyFromContainer(ctx, dockerCli, copyConfig)
@@ -267,11 +311,15 @@ func copyFromContainer(ctx context.Context, dockerCli command.Cli, copyConfig cp
 		RebaseName: rebaseName,
 	}
 
-	var copiedSize int64
+	var stats copyStats
+	if !stat.Mode.IsDir() {
+		stats.totalSize, stats.totalFiles = tarEntrySize(stat.Size)+2*tarBlockSize, 1
+	}
 	if !copyConfig.quiet {
-		content = &copyProgressPrinter{
-			ReadCloser: content,
-			total:      &copiedSize,
+		content = &transfer.CountingReader{Reader: content, N: &stats.size}
+		if streams.NewOut(dockerCli.Err()).IsTerminal() {
+			content = countArchiveEntries(content, &stats.files)
+			defer content.Close()
 		}
 	}
 
@@ -285,12 +333,12 @@ func copyFromContainer(ctx context.Context, dockerCli command.Cli, copyConfig cp
 		return archive.CopyTo(preArchive, srcInfo, dstPath)
 	}
 
-	restore, done := copyProgress(ctx, dockerCli.Err(), copyFromContainerHeader, &copiedSize)
+	restore, done := transfer.ShowProgress(ctx, dockerCli.Err(), copyFromContainerHeader, stats.progress)
 	res := archive.CopyTo(preArchive, srcInfo, dstPath)
 	cancel()
 	<-done
 	restore()
-	fmt.Fprintln(dockerCli.Err(), "Successfully copied", progressHumanSize(copiedSize), "to", dstPath)
+	fmt.Fprintln(dockerCli.Err(), "Successfully copied", transfer.HumanSize(stats.size), "to", dstPath)
 
 	return res
 }
@@ -347,7 +395,7 @@ func copyToContainer(ctx context.Context, dockerCli command.Cli, copyConfig cpCo
 	var (
 		content         io.ReadCloser
 		resolvedDstPath string
-		copiedSize      int64
+		stats           copyStats
 	)
 
 	if srcPath == "-" {
@@ -390,13 +438,24 @@ func copyToContainer(ctx context.Context, dockerCli command.Cli, copyConfig cpCo
 		resolvedDstPath = dstDir
 		content = preparedArchive
 		if !copyConfig.quiet {
-			content = &copyProgressPrinter{
-				ReadCloser: content,
-				total:      &copiedSize,
+			content = &transfer.CountingReader{Reader: content, N: &stats.size}
+			if streams.NewOut(dockerCli.Err()).IsTerminal() {
+				// The estimate is only used to report progress, so don't fail
+				// the copy if it can't be computed.
+				if size, files, err := estimateArchiveSize(srcInfo.Path); err == nil {
+					stats.totalSize, stats.totalFiles = size, files
+				}
+				content = countArchiveEntries(content, &stats.files)
+				defer content.Close()
 			}
 		}
 	}
 
+	if copyConfig.compress {
+		content = transfer.Gzip(content)
+		defer content.Close()
+	}
+
 	options := container.CopyToContainerOptions{
 		AllowOverwriteDirWithFile: false,
 		CopyUIDGID:                copyConfig.copyUIDGID,
@@ -407,12 +466,12 @@ func copyToContainer(ctx context.Context, dockerCli command.Cli, copyConfig cpCo
 	}
 
 	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt)
-	restore, done := copyProgress(ctx, dockerCli.Err(), copyToContainerHeader, &copiedSize)
+	restore, done := transfer.ShowProgress(ctx, dockerCli.Err(), copyToContainerHeader, stats.progress)
 	res := client.CopyToContainer(ctx, copyConfig.container, resolvedDstPath, content, options)
 	cancel()
 	<-done
 	restore()
-	fmt.Fprintln(dockerCli.Err(), "Successfully copied", progressHumanSize(copiedSize), "to", copyConfig.container+":"+dstInfo.Path)
+	fmt.Fprintln(dockerCli.Err(), "Successfully copied", transfer.HumanSize(stats.size), "to", copyConfig.container+":"+dstInfo.Path)
 
 	return res
 }
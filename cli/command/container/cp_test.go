@@ -1,12 +1,17 @@
 package container
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"io"
 	"os"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/docker/cli/internal/test"
 	"github.com/docker/docker/api/types/container"
@@ -14,6 +19,7 @@
 	"gotest.tools/v3/assert"
 	is "gotest.tools/v3/assert/cmp"
 	"gotest.tools/v3/fs"
+	"gotest.tools/v3/poll"
 )
 
 func TestRunCopyWithInvalidArguments(t *testing.T) {
@@ -204,3 +210,86 @@ func TestRunCopyFromContainerToFilesystemIrregularDestination(t *testing.T) {
 	expected := `"/dev/random" must be a directory or a regular file`
 	assert.ErrorContains(t, err, expected)
 }
+
+func TestRunCopyToContainerCompressed(t *testing.T) {
+	srcDir := fs.NewDir(t, "cp-test", fs.WithFile("file1", "content\n"))
+	defer srcDir.Remove()
+
+	cli := test.NewFakeCli(&fakeClient{
+		containerStatPathFunc: func(_, _ string) (container.PathStat, error) {
+			return container.PathStat{Mode: os.ModeDir}, nil
+		},
+		containerCopyToFunc: func(_, path string, content io.Reader, _ container.CopyToContainerOptions) error {
+			assert.Check(t, is.Equal(path, "/path"))
+			gz, err := gzip.NewReader(content)
+			assert.NilError(t, err)
+			tr := tar.NewReader(gz)
+			hdr, err := tr.Next()
+			assert.NilError(t, err)
+			assert.Check(t, is.Equal(hdr.Name, "file1"))
+			return nil
+		},
+	})
+	err := runCopy(context.TODO(), cli, copyOptions{
+		source:      srcDir.Join("file1"),
+		destination: "container:/path",
+		compress:    true,
+	})
+	assert.NilError(t, err)
+	assert.Check(t, is.Contains(cli.ErrBuffer().String(), "Successfully copied 2.05kB to container:/path"))
+}
+
+func TestRunCopyFromContainerCompressed(t *testing.T) {
+	err := runCopy(context.TODO(), test.NewFakeCli(&fakeClient{}), copyOptions{
+		source:      "container:/path",
+		destination: "./dest",
+		compress:    true,
+	})
+	assert.Error(t, err, "--compress is only supported when copying to a container")
+}
+
+func TestCopyStatsProgress(t *testing.T) {
+	stats := copyStats{size: 25 * 1000, files: 1, totalSize: 100 * 1000, totalFiles: 4}
+	assert.Check(t, is.Equal(stats.progress(3*time.Second), "25kB / 100kB (25%), 1/4 files, ETA 9s"))
+
+	stats = copyStats{size: 25 * 1000, files: 3}
+	assert.Check(t, is.Equal(stats.progress(3*time.Second), "25kB, 3 files"))
+}
+
+func TestEstimateArchiveSize(t *testing.T) {
+	srcDir := fs.NewDir(t, "cp-test",
+		fs.WithFile("file1", "content\n"),
+		fs.WithDir("sub", fs.WithFile("file2", strings.Repeat("a", 513))))
+	defer srcDir.Remove()
+
+	size, files, err := estimateArchiveSize(srcDir.Path())
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(files, int64(2)))
+	// 4 headers, 1 block for file1, 2 blocks for file2, and the 2 trailing blocks.
+	assert.Check(t, is.Equal(size, int64(9*512)))
+}
+
+func TestCountArchiveEntries(t *testing.T) {
+	var archived bytes.Buffer
+	tw := tar.NewWriter(&archived)
+	assert.NilError(t, tw.WriteHeader(&tar.Header{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0o755}))
+	for _, name := range []string{"dir/file1", "dir/file2"} {
+		assert.NilError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: 4, Format: tar.FormatPAX}))
+		_, err := tw.Write([]byte("data"))
+		assert.NilError(t, err)
+	}
+	assert.NilError(t, tw.Close())
+
+	var files int64
+	content := countArchiveEntries(io.NopCloser(bytes.NewReader(archived.Bytes())), &files)
+	copied, err := io.ReadAll(content)
+	assert.NilError(t, err)
+	assert.NilError(t, content.Close())
+	assert.Check(t, bytes.Equal(copied, archived.Bytes()), "archive must not be altered")
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		if n := atomic.LoadInt64(&files); n != 2 {
+			return poll.Continue("counted %d files", n)
+		}
+		return poll.Success()
+	}, poll.WithTimeout(5*time.Second))
+}
@@ -2,6 +2,7 @@
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -17,6 +18,7 @@
 	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types/container"
 	imagetypes "github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/pkg/jsonmessage"
@@ -39,6 +41,12 @@ type createOptions struct {
 	untrusted bool
 	pull      string // always, missing, never
 	quiet     bool
+	dryRun    bool
+
+	// events is used to emit lifecycle events, such as pulling the image,
+	// instead of printing human-readable progress. It is nil unless events
+	// are requested (docker run --progress json).
+	events *runEventWriter
 }
 
 // NewCreateCommand creates a new cobra.Command for `docker create`
@@ -69,6 +77,7 @@ func NewCreateCommand(dockerCli command.Cli) *cobra.Command {
 	flags.StringVar(&options.name, "name", "", "Assign a name to the container")
 	flags.StringVar(&options.pull, "pull", PullImageMissing, `Pull image before creating ("`+PullImageAlways+`", "|`+PullImageMissing+`", "`+PullImageNever+`")`)
 	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Suppress the pull output")
+	flags.BoolVar(&options.dryRun, "dry-run", false, "Validate the container configuration and print it without creating the container")
 
 	// Add an explicit help that doesn't have a `-h` to prevent the conflict
 	// with hostname
@@ -82,6 +91,7 @@ func NewCreateCommand(dockerCli command.Cli) *cobra.Command {
 	_ = cmd.RegisterFlagCompletionFunc("cap-drop", completeLinuxCapabilityNames)
 	_ = cmd.RegisterFlagCompletionFunc("env", completion.EnvVarNames)
 	_ = cmd.RegisterFlagCompletionFunc("env-file", completion.FileNames)
+	_ = cmd.RegisterFlagCompletionFunc("env-file-format", completion.FromList(opts.EnvFileFormatV1, opts.EnvFileFormatV2))
 	_ = cmd.RegisterFlagCompletionFunc("network", completion.NetworkNames(dockerCli))
 	_ = cmd.RegisterFlagCompletionFunc("pull", completion.FromList(PullImageAlways, PullImageMissing, PullImageNever))
 	_ = cmd.RegisterFlagCompletionFunc("restart", completeRestartPolicies)
@@ -120,6 +130,9 @@ func runCreate(ctx context.Context, dockerCli command.Cli, flags *pflag.FlagSet,
 			StatusCode: 125,
 		}
 	}
+	if options.dryRun {
+		return dryRunContainer(ctx, dockerCli, containerCfg, options)
+	}
 	id, err := createContainer(ctx, dockerCli, containerCfg, options)
 	if err != nil {
 		return err
@@ -145,10 +158,15 @@ func pullImage(ctx context.Context, dockerCli command.Cli, img string, options *
 	defer responseBody.Close()
 
 	out := dockerCli.Err()
-	if options.quiet {
+	if options.quiet || options.events != nil {
 		out = streams.NewOut(io.Discard)
 	}
-	return jsonmessage.DisplayJSONMessagesToStream(responseBody, out, nil)
+	options.events.emit(runEvent{Event: runEventPulling, Image: img})
+	if err := jsonmessage.DisplayJSONMessagesToStream(responseBody, out, nil); err != nil {
+		return err
+	}
+	options.events.emit(runEvent{Event: runEventPulled, Image: img})
+	return nil
 }
 
 type cidFile struct {
@@ -214,9 +232,12 @@ func createContainer(ctx context.Context, dockerCli command.Cli, containerCfg *c
 		namedRef   reference.Named
 	)
 
-	containerIDFile, err := newCIDFile(hostConfig.ContainerIDFile)
-	if err != nil {
-		return "", err
+	containerIDFile := &cidFile{}
+	if !options.dryRun {
+		containerIDFile, err = newCIDFile(hostConfig.ContainerIDFile)
+		if err != nil {
+			return "", err
+		}
 	}
 	defer containerIDFile.Close()
 
@@ -272,7 +293,7 @@ func createContainer(ctx context.Context, dockerCli command.Cli, containerCfg *c
 	if err != nil {
 		// Pull image if it does not exist locally and we have the PullImageMissing option. Default behavior.
 		if errdefs.IsNotFound(err) && namedRef != nil && options.pull == PullImageMissing {
-			if !options.quiet {
+			if !options.quiet && options.events == nil {
 				// we don't want to write to stdout anything apart from container.ID
 				fmt.Fprintf(dockerCli.Err(), "Unable to find image '%s' locally\n", reference.FamiliarString(namedRef))
 			}
@@ -298,6 +319,52 @@ func createContainer(ctx context.Context, dockerCli command.Cli, containerCfg *c
 	return response.ID, err
 }
 
+// dryRunConfig is the container configuration printed by `--dry-run`.
+type dryRunConfig struct {
+	Config           *container.Config
+	HostConfig       *container.HostConfig
+	NetworkingConfig *network.NetworkingConfig
+}
+
+// dryRunContainer validates the container configuration by creating the
+// container and removing it immediately, and prints the configuration as
+// resolved by the daemon.
+func dryRunContainer(ctx context.Context, dockerCli command.Cli, containerCfg *containerConfig, options *createOptions) error {
+	id, err := createContainer(ctx, dockerCli, containerCfg, options)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Use a context that can't be cancelled, so that the container
+		// is not left behind if the command is interrupted.
+		err := dockerCli.Client().ContainerRemove(context.WithoutCancel(ctx), id, container.RemoveOptions{
+			RemoveVolumes: true,
+			Force:         true,
+		})
+		if err != nil {
+			_, _ = fmt.Fprintf(dockerCli.Err(), "WARNING: failed to remove container %s: %v\n", id, err)
+		}
+	}()
+
+	c, err := dockerCli.Client().ContainerInspect(ctx, id)
+	if err != nil {
+		return err
+	}
+	cfg := dryRunConfig{
+		Config:     c.Config,
+		HostConfig: c.HostConfig,
+	}
+	if c.NetworkSettings != nil {
+		cfg.NetworkingConfig = &network.NetworkingConfig{EndpointsConfig: c.NetworkSettings.Networks}
+	}
+	out, err := json.MarshalIndent(cfg, "", "    ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(dockerCli.Out(), string(out))
+	return err
+}
+
 func warnOnOomKillDisable(hostConfig container.HostConfig, stderr io.Writer) {
 	if hostConfig.OomKillDisable != nil && *hostConfig.OomKillDisable && hostConfig.Memory == 0 {
 		fmt.Fprintln(stderr, "WARNING: Disabling the OOM killer on containers without setting a '-m/--memory' limit may be dangerous.")
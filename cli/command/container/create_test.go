@@ -358,3 +358,46 @@ type fakeNotFound struct{
 
 func (f fakeNotFound) NotFound()     {}
 func (f fakeNotFound) Error() string { return "error fake not found" }
+
+func TestCreateContainerDryRun(t *testing.T) {
+	cidFile := fs.NewDir(t, "create-dry-run").Join("cid")
+	var removed bool
+	fakeCLI := test.NewFakeCli(&fakeClient{
+		createContainerFunc: func(config *container.Config,
+			hostConfig *container.HostConfig,
+			_ *network.NetworkingConfig,
+			_ *specs.Platform,
+			_ string,
+		) (container.CreateResponse, error) {
+			return container.CreateResponse{ID: "abc123"}, nil
+		},
+		inspectFunc: func(containerID string) (container.InspectResponse, error) {
+			assert.Check(t, is.Equal(containerID, "abc123"))
+			return container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					ID:         containerID,
+					HostConfig: &container.HostConfig{NetworkMode: "bridge"},
+				},
+				Config: &container.Config{Image: "image:tag", Env: []string{"FOO=bar"}},
+			}, nil
+		},
+		containerRemoveFunc: func(_ context.Context, containerID string, options container.RemoveOptions) error {
+			assert.Check(t, is.Equal(containerID, "abc123"))
+			assert.Check(t, options.Force)
+			assert.Check(t, options.RemoveVolumes)
+			removed = true
+			return nil
+		},
+	})
+	cmd := NewCreateCommand(fakeCLI)
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{"--dry-run", "--cidfile", cidFile, "-e", "FOO=bar", "image:tag"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, removed)
+	assert.Check(t, is.Contains(fakeCLI.OutBuffer().String(), `"Image": "image:tag"`))
+	assert.Check(t, is.Contains(fakeCLI.OutBuffer().String(), `"NetworkMode": "bridge"`))
+	assert.Check(t, !strings.Contains(fakeCLI.OutBuffer().String(), "abc123"))
+
+	_, err := os.Stat(cidFile)
+	assert.Check(t, os.IsNotExist(err))
+}
@@ -2,25 +2,33 @@
 
 import (
 	"context"
+	"path"
+	"strings"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
 	"github.com/docker/cli/cli/command/formatter"
+	flagsHelper "github.com/docker/cli/cli/flags"
+	"github.com/docker/cli/opts"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 type diffOptions struct {
 	container string
+	format    string
+	filter    opts.FilterOpt
 }
 
 // NewDiffCommand creates a new cobra.Command for `docker diff`
 func NewDiffCommand(dockerCli command.Cli) *cobra.Command {
-	var opts diffOptions
+	opts := diffOptions{filter: opts.NewFilterOpt()}
 
-	return &cobra.Command{
-		Use:   "diff CONTAINER",
+	cmd := &cobra.Command{
+		Use:   "diff [OPTIONS] CONTAINER",
 		Short: "Inspect changes to files or directories on a container's filesystem",
 		Args:  cli.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -32,19 +40,76 @@ func NewDiffCommand(dockerCli command.Cli) *cobra.Command {
 		},
 		ValidArgsFunction: completion.ContainerNames(dockerCli, false),
 	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.format, "format", "", flagsHelper.FormatHelp)
+	flags.Var(&opts.filter, "filter", `Filter output based on conditions provided (e.g. "type=A", "path=/etc")`)
+
+	return cmd
 }
 
 func runDiff(ctx context.Context, dockerCli command.Cli, opts *diffOptions) error {
 	if opts.container == "" {
 		return errors.New("Container name cannot be empty")
 	}
+	diffFilter := opts.filter.Value()
+	if err := diffFilter.Validate(map[string]bool{"type": true, "path": true}); err != nil {
+		return err
+	}
+	for _, t := range diffFilter.Get("type") {
+		if !diffChangeTypes[t] {
+			return errors.Errorf("invalid filter 'type=%s': must be one of A, C, D", t)
+		}
+	}
+
 	changes, err := dockerCli.Client().ContainerDiff(ctx, opts.container)
 	if err != nil {
 		return err
 	}
+
+	format := opts.format
+	if format == "" {
+		format = "{{.Type}} {{.Path}}"
+	}
 	diffCtx := formatter.Context{
 		Output: dockerCli.Out(),
-		Format: NewDiffFormat("{{.Type}} {{.Path}}"),
+		Format: NewDiffFormat(format),
+	}
+	return DiffFormatWrite(diffCtx, filterChanges(changes, diffFilter))
+}
+
+var diffChangeTypes = map[string]bool{
+	container.ChangeAdd.String():    true,
+	container.ChangeModify.String(): true,
+	container.ChangeDelete.String(): true,
+}
+
+// filterChanges returns the changes matching the "type" and "path" filters.
+// A change matches the "path" filter if it is the given path, or a path below
+// it.
+func filterChanges(changes []container.FilesystemChange, diffFilter filters.Args) []container.FilesystemChange {
+	if diffFilter.Len() == 0 {
+		return changes
+	}
+	var filtered []container.FilesystemChange
+	for _, change := range changes {
+		if diffFilter.Contains("type") && !diffFilter.ExactMatch("type", change.Kind.String()) {
+			continue
+		}
+		if diffFilter.Contains("path") && !matchPathPrefix(change.Path, diffFilter.Get("path")) {
+			continue
+		}
+		filtered = append(filtered, change)
+	}
+	return filtered
+}
+
+func matchPathPrefix(p string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		prefix = path.Clean("/" + prefix)
+		if prefix == "/" || p == prefix || strings.HasPrefix(p, prefix+"/") {
+			return true
+		}
 	}
-	return DiffFormatWrite(diffCtx, changes)
+	return false
 }
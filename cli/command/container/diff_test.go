@@ -0,0 +1,79 @@
+package container
+
+import (
+	"io"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/container"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestDiffFilterAndFormat(t *testing.T) {
+	changes := []container.FilesystemChange{
+		{Kind: container.ChangeModify, Path: "/etc"},
+		{Kind: container.ChangeAdd, Path: "/etc/app.conf"},
+		{Kind: container.ChangeModify, Path: "/var/log/app.log"},
+		{Kind: container.ChangeDelete, Path: "/etcd"},
+	}
+	testCases := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{
+			name:     "default",
+			args:     []string{"foo"},
+			expected: "C /etc\nA /etc/app.conf\nC /var/log/app.log\nD /etcd\n",
+		},
+		{
+			name:     "type filter",
+			args:     []string{"--filter", "type=A", "--filter", "type=D", "foo"},
+			expected: "A /etc/app.conf\nD /etcd\n",
+		},
+		{
+			name:     "path filter",
+			args:     []string{"--filter", "path=/etc/", "foo"},
+			expected: "C /etc\nA /etc/app.conf\n",
+		},
+		{
+			name:     "type and path filter",
+			args:     []string{"--filter", "type=C", "--filter", "path=/etc", "--filter", "path=/var", "foo"},
+			expected: "C /etc\nC /var/log/app.log\n",
+		},
+		{
+			name:     "json",
+			args:     []string{"--format", "json", "--filter", "type=A", "foo"},
+			expected: `{"Path":"/etc/app.conf","Type":"A"}` + "\n",
+		},
+		{
+			name:     "table",
+			args:     []string{"--format", "table", "--filter", "type=D", "foo"},
+			expected: "CHANGE TYPE   PATH\nD             /etcd\n",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cli := test.NewFakeCli(&fakeClient{
+				containerDiffFunc: func(string) ([]container.FilesystemChange, error) {
+					return changes, nil
+				},
+			})
+			cmd := NewDiffCommand(cli)
+			cmd.SetArgs(tc.args)
+			assert.NilError(t, cmd.Execute())
+			assert.Check(t, is.Equal(cli.OutBuffer().String(), tc.expected))
+		})
+	}
+}
+
+func TestDiffInvalidFilter(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{})
+	cmd := NewDiffCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--filter", "type=X", "foo"})
+	assert.Check(t, is.Error(cmd.Execute(), "invalid filter 'type=X': must be one of A, C, D"))
+}
@@ -4,14 +4,18 @@
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/cli/command/formatter/tabwriter"
 	"github.com/docker/cli/cli/config/configfile"
 	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/stringid"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -29,6 +33,8 @@ type ExecOptions struct {
 	Workdir     string
 	Command     []string
 	EnvFile     opts.ListOpts
+	// EnvFileFormat is the format of the env-files, see [opts.ReadKVEnvStringsWithFormat].
+	EnvFileFormat string
 }
 
 // NewExecOptions creates a new ExecOptions
@@ -42,14 +48,37 @@ func NewExecOptions() ExecOptions {
 // NewExecCommand creates a new cobra.Command for `docker exec`
 func NewExecCommand(dockerCli command.Cli) *cobra.Command {
 	options := NewExecOptions()
+	var list, shell bool
 
 	cmd := &cobra.Command{
 		Use:   "exec [OPTIONS] CONTAINER COMMAND [ARG...]",
 		Short: "Execute a command in a running container",
-		Args:  cli.RequiresMinArgs(2),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if list {
+				return cli.ExactArgs(1)(cmd, args)
+			}
+			if shell {
+				return cli.RequiresMinArgs(1)(cmd, args)
+			}
+			return cli.RequiresMinArgs(2)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			containerIDorName := args[0]
+			if list {
+				return runExecList(cmd.Context(), dockerCli, containerIDorName)
+			}
 			options.Command = args[1:]
+			if shell {
+				sh, err := detectShell(cmd.Context(), dockerCli.Client(), containerIDorName)
+				if err != nil {
+					return err
+				}
+				options.Command = append(sh, options.Command...)
+				options.Interactive = true
+				if !cmd.Flags().Changed("tty") {
+					options.TTY = dockerCli.In().IsTerminal() && dockerCli.Out().IsTerminal()
+				}
+			}
 			return RunExec(cmd.Context(), dockerCli, containerIDorName, options)
 		},
 		ValidArgsFunction: completion.ContainerNames(dockerCli, false, func(ctr container.Summary) bool {
@@ -74,11 +103,15 @@ func NewExecCommand(dockerCli command.Cli) *cobra.Command {
 	flags.SetAnnotation("env", "version", []string{"1.25"})
 	flags.Var(&options.EnvFile, "env-file", "Read in a file of environment variables")
 	flags.SetAnnotation("env-file", "version", []string{"1.25"})
+	flags.StringVar(&options.EnvFileFormat, "env-file-format", opts.EnvFileFormatV1, `Format of the env-files ("v1", "v2" for compose-compatible files)`)
 	flags.StringVarP(&options.Workdir, "workdir", "w", "", "Working directory inside the container")
 	flags.SetAnnotation("workdir", "version", []string{"1.35"})
+	flags.BoolVar(&list, "list", false, "List the exec sessions of the container")
+	flags.BoolVar(&shell, "shell", false, "Run the first shell found in the container (bash, sh, or busybox sh) interactively, instead of COMMAND")
 
 	_ = cmd.RegisterFlagCompletionFunc("env", completion.EnvVarNames)
 	_ = cmd.RegisterFlagCompletionFunc("env-file", completion.FileNames)
+	_ = cmd.RegisterFlagCompletionFunc("env-file-format", completion.FromList(opts.EnvFileFormatV1, opts.EnvFileFormatV2))
 
 	return cmd
 }
@@ -127,6 +160,63 @@ func RunExec(ctx context.Context, dockerCli command.Cli, containerIDorName strin
 	return interactiveExec(ctx, dockerCli, execOptions, execID)
 }
 
+// execShells is the list of shells that are looked up in the container by
+// "docker exec --shell", in order of preference.
+var execShells = [][]string{
+	{"/bin/bash"},
+	{"/usr/bin/bash"},
+	{"/bin/sh"},
+	{"/usr/bin/sh"},
+	{"/bin/busybox", "sh"},
+	{"/busybox/sh"},
+}
+
+// detectShell returns the command to run the first shell of [execShells]
+// that exists in the container.
+func detectShell(ctx context.Context, apiClient client.ContainerAPIClient, containerIDorName string) ([]string, error) {
+	tried := make([]string, 0, len(execShells))
+	for _, sh := range execShells {
+		if _, err := apiClient.ContainerStatPath(ctx, containerIDorName, sh[0]); err != nil {
+			if errdefs.IsNotFound(err) {
+				tried = append(tried, sh[0])
+				continue
+			}
+			return nil, err
+		}
+		return sh, nil
+	}
+	return nil, errors.Errorf("no shell found in container %s (tried %s)", containerIDorName, strings.Join(tried, ", "))
+}
+
+// runExecList prints the exec sessions of a container, including the ones that
+// were detached from, and the ones that have exited but were not cleaned up yet.
+func runExecList(ctx context.Context, dockerCli command.Cli, containerIDorName string) error {
+	apiClient := dockerCli.Client()
+	c, err := apiClient.ContainerInspect(ctx, containerIDorName)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
+	fmt.Fprintln(w, "EXEC ID\tSTATUS\tPID")
+	for _, execID := range c.ExecIDs {
+		resp, err := apiClient.ContainerExecInspect(ctx, execID)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				// The exec was cleaned up in the meantime.
+				continue
+			}
+			return err
+		}
+		status := fmt.Sprintf("Exited (%d)", resp.ExitCode)
+		if resp.Running {
+			status = "Running"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\n", stringid.TruncateID(resp.ExecID), status, resp.Pid)
+	}
+	return w.Flush()
+}
+
 func fillConsoleSize(execOptions *container.ExecOptions, dockerCli command.Cli) {
 	if execOptions.Tty {
 		height, width := dockerCli.Out().GetTtySize()
@@ -229,7 +319,7 @@ func parseExec(execOpts ExecOptions, configFile *configfile.ConfigFile) (*contai
 
 	// collect all the environment variables for the container
 	var err error
-	if execOptions.Env, err = opts.ReadKVEnvStrings(execOpts.EnvFile.GetAll(), execOpts.Env.GetAll()); err != nil {
+	if execOptions.Env, err = opts.ReadKVEnvStringsWithFormat(execOpts.EnvFileFormat, execOpts.EnvFile.GetAll(), execOpts.Env.GetAll()); err != nil {
 		return nil, err
 	}
 
@@ -12,10 +12,12 @@
 	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
 	"github.com/pkg/errors"
 	"gotest.tools/v3/assert"
 	is "gotest.tools/v3/assert/cmp"
 	"gotest.tools/v3/fs"
+	"gotest.tools/v3/golden"
 )
 
 func withDefaultOpts(options ExecOptions) ExecOptions {
@@ -158,6 +160,18 @@ func TestParseExecNoSuchFile(t *testing.T) {
 	assert.Check(t, execConfig == nil)
 }
 
+func TestParseExecEnvFileV2(t *testing.T) {
+	tmpFile := fs.NewFile(t, t.Name(), fs.WithContent("export ONE=\"1\"\nTWO='multi\nline'\nTHREE=${ONE}-3\n"))
+	defer tmpFile.Remove()
+
+	execOpts := withDefaultOpts(ExecOptions{})
+	execOpts.EnvFileFormat = opts.EnvFileFormatV2
+	assert.NilError(t, execOpts.EnvFile.Set(tmpFile.Path()))
+	execConfig, err := parseExec(execOpts, &configfile.ConfigFile{})
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(execConfig.Env, []string{"ONE=1", "TWO=multi\nline", "THREE=1-3"}))
+}
+
 func TestRunExec(t *testing.T) {
 	testcases := []struct {
 		doc           string
@@ -271,3 +285,68 @@ func TestNewExecCommandErrors(t *testing.T) {
 		assert.ErrorContains(t, cmd.Execute(), tc.expectedError)
 	}
 }
+
+func TestRunExecList(t *testing.T) {
+	const (
+		runningID = "b0a1ea6e8f6c0f5d0f6cbd7e0e6b2f2ffab0c4c5cde7b8a6e1e8ec4a0b1c2d3e"
+		exitedID  = "c1b2fb7f9a7d1a6e1a7dce8f1f7c3a3aabc1d5d6def8c9b7f2f9fd5b1c2d3e4f"
+		removedID = "d2c3ac8a0b8e2b7f2b8edf9a2a8d4b4bbcd2e6e7efa9dac8a3a0ae6c2d3e4f5a"
+	)
+	cli := test.NewFakeCli(&fakeClient{
+		inspectFunc: func(string) (container.InspectResponse, error) {
+			return container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					ExecIDs: []string{runningID, exitedID, removedID},
+				},
+			}, nil
+		},
+		execInspectFunc: func(execID string) (container.ExecInspect, error) {
+			switch execID {
+			case runningID:
+				return container.ExecInspect{ExecID: execID, Running: true, Pid: 1234}, nil
+			case exitedID:
+				return container.ExecInspect{ExecID: execID, ExitCode: 130}, nil
+			default:
+				return container.ExecInspect{}, errdefs.NotFound(errors.New("no such exec"))
+			}
+		},
+	})
+	cmd := NewExecCommand(cli)
+	cmd.SetArgs([]string{"--list", "foo"})
+	assert.NilError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "container-exec-list.golden")
+}
+
+func TestExecShell(t *testing.T) {
+	var cmd []string
+	fakeCLI := test.NewFakeCli(&fakeClient{
+		containerStatPathFunc: func(_, path string) (container.PathStat, error) {
+			if path != "/bin/sh" {
+				return container.PathStat{}, errdefs.NotFound(errors.New("no such file"))
+			}
+			return container.PathStat{Name: "sh"}, nil
+		},
+		execCreateFunc: func(_ string, options container.ExecOptions) (types.IDResponse, error) {
+			cmd = options.Cmd
+			assert.Check(t, !options.Tty)
+			return types.IDResponse{ID: "execid"}, nil
+		},
+	})
+	c := NewExecCommand(fakeCLI)
+	c.SetArgs([]string{"--shell", "--detach", "foo", "-c", "echo hello"})
+	assert.NilError(t, c.Execute())
+	assert.Check(t, is.DeepEqual(cmd, []string{"/bin/sh", "-c", "echo hello"}))
+}
+
+func TestExecShellNotFound(t *testing.T) {
+	fakeCLI := test.NewFakeCli(&fakeClient{
+		containerStatPathFunc: func(string, string) (container.PathStat, error) {
+			return container.PathStat{}, errdefs.NotFound(errors.New("no such file"))
+		},
+	})
+	c := NewExecCommand(fakeCLI)
+	c.SetArgs([]string{"--shell", "foo"})
+	c.SetOut(io.Discard)
+	c.SetErr(io.Discard)
+	assert.ErrorContains(t, c.Execute(), "no shell found in container foo (tried /bin/bash, /usr/bin/bash, /bin/sh, /usr/bin/sh, /bin/busybox, /busybox/sh)")
+}
@@ -2,11 +2,15 @@
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"sync/atomic"
+	"time"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/cli/internal/transfer"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -14,8 +18,13 @@
 type exportOptions struct {
 	container string
 	output    string
+	gzip      bool
+	zstd      bool
+	quiet     bool
 }
 
+const exportHeader = "Exporting - "
+
 // NewExportCommand creates a new `docker export` command
 func NewExportCommand(dockerCli command.Cli) *cobra.Command {
 	var opts exportOptions
@@ -37,6 +46,9 @@ func NewExportCommand(dockerCli command.Cli) *cobra.Command {
 	flags := cmd.Flags()
 
 	flags.StringVarP(&opts.output, "output", "o", "", "Write to a file, instead of STDOUT")
+	flags.BoolVar(&opts.gzip, "gzip", false, "Compress the archive using gzip")
+	flags.BoolVar(&opts.zstd, "zstd", false, "Compress the archive using zstd")
+	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Suppress progress output when writing to a file. Progress output is automatically suppressed if no terminal is attached")
 
 	return cmd
 }
@@ -45,6 +57,9 @@ func runExport(ctx context.Context, dockerCli command.Cli, opts exportOptions) e
 	if opts.output == "" && dockerCli.Out().IsTerminal() {
 		return errors.New("cowardly refusing to save to a terminal. Use the -o flag or redirect")
 	}
+	if opts.gzip && opts.zstd {
+		return errors.New("conflicting options: cannot specify both --gzip and --zstd")
+	}
 
 	if err := command.ValidateOutputPath(opts.output); err != nil {
 		return errors.Wrap(err, "failed to export container")
@@ -58,10 +73,38 @@ func runExport(ctx context.Context, dockerCli command.Cli, opts exportOptions) e
 	}
 	defer responseBody.Close()
 
+	var size int64
+	content := io.ReadCloser(&transfer.CountingReader{Reader: responseBody, N: &size})
+	switch {
+	case opts.gzip:
+		content = transfer.Gzip(content)
+		defer content.Close()
+	case opts.zstd:
+		content = transfer.Zstd(content)
+		defer content.Close()
+	}
+
 	if opts.output == "" {
-		_, err := io.Copy(dockerCli.Out(), responseBody)
+		_, err := io.Copy(dockerCli.Out(), content)
 		return err
 	}
+	if opts.quiet {
+		return command.CopyToFile(opts.output, content)
+	}
 
-	return command.CopyToFile(opts.output, responseBody)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	restore, done := transfer.ShowProgress(ctx, dockerCli.Err(), exportHeader, func(time.Duration) string {
+		return transfer.HumanSize(atomic.LoadInt64(&size))
+	})
+	err = command.CopyToFile(opts.output, content)
+	cancel()
+	<-done
+	restore()
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(dockerCli.Err(), "Successfully exported", transfer.HumanSize(size), "to", opts.output)
+	return nil
 }
@@ -1,12 +1,16 @@
 package container
 
 import (
+	"bytes"
 	"io"
+	"os"
 	"strings"
 	"testing"
 
 	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/pkg/archive"
 	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
 	"gotest.tools/v3/fs"
 )
 
@@ -47,3 +51,49 @@ func TestContainerExportOutputToIrregularFile(t *testing.T) {
 	expected := `"/dev/random" must be a directory or a regular file`
 	assert.ErrorContains(t, err, expected)
 }
+
+func TestContainerExportCompressed(t *testing.T) {
+	for _, tc := range []struct {
+		flag        string
+		compression archive.Compression
+	}{
+		{flag: "--gzip", compression: archive.Gzip},
+		{flag: "--zstd", compression: archive.Zstd},
+	} {
+		t.Run(tc.flag, func(t *testing.T) {
+			dir := fs.NewDir(t, "export-test")
+			defer dir.Remove()
+
+			cli := test.NewFakeCli(&fakeClient{
+				containerExportFunc: func(container string) (io.ReadCloser, error) {
+					return io.NopCloser(strings.NewReader("bar")), nil
+				},
+			})
+			cmd := NewExportCommand(cli)
+			cmd.SetOut(io.Discard)
+			cmd.SetArgs([]string{tc.flag, "-o", dir.Join("foo.tar"), "container"})
+			assert.NilError(t, cmd.Execute())
+			assert.Check(t, is.Equal(cli.ErrBuffer().String(), "Successfully exported 3B to "+dir.Join("foo.tar")+"\n"))
+
+			compressed, err := os.ReadFile(dir.Join("foo.tar"))
+			assert.NilError(t, err)
+			assert.Check(t, is.Equal(archive.DetectCompression(compressed), tc.compression))
+
+			r, err := archive.DecompressStream(bytes.NewReader(compressed))
+			assert.NilError(t, err)
+			defer r.Close()
+			content, err := io.ReadAll(r)
+			assert.NilError(t, err)
+			assert.Check(t, is.Equal(string(content), "bar"))
+		})
+	}
+}
+
+func TestContainerExportConflictingCompression(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{})
+	cmd := NewExportCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--gzip", "--zstd", "-o", "foo.tar", "container"})
+	assert.Check(t, is.Error(cmd.Execute(), "conflicting options: cannot specify both --gzip and --zstd"))
+}
@@ -0,0 +1,78 @@
+package container
+
+import (
+	"strconv"
+
+	"github.com/docker/cli/cli/command/formatter"
+)
+
+const (
+	defaultPortsTableFormat = "table {{.Container}}\t{{.HostIP}}\t{{.HostPort}}\t{{.ContainerPort}}\t{{.Protocol}}"
+
+	hostIPHeader        = "HOST IP"
+	hostPortHeader      = "HOST PORT"
+	containerPortHeader = "CONTAINER PORT"
+	protocolHeader      = "PROTOCOL"
+)
+
+// NewPortsFormat returns a format for use with a ports Context
+func NewPortsFormat(source string) formatter.Format {
+	if source == formatter.TableFormatKey {
+		return defaultPortsTableFormat
+	}
+	return formatter.Format(source)
+}
+
+// portsFormatWrite writes formatted published ports using the Context
+func portsFormatWrite(ctx formatter.Context, ports []publishedPort) error {
+	render := func(format func(subContext formatter.SubContext) error) error {
+		for _, p := range ports {
+			if err := format(&portsContext{p: p}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return ctx.Write(newPortsContext(), render)
+}
+
+type portsContext struct {
+	formatter.HeaderContext
+	p publishedPort
+}
+
+func newPortsContext() *portsContext {
+	portsCtx := portsContext{}
+	portsCtx.Header = formatter.SubHeaderContext{
+		"Container":     containerHeader,
+		"HostIP":        hostIPHeader,
+		"HostPort":      hostPortHeader,
+		"ContainerPort": containerPortHeader,
+		"Protocol":      protocolHeader,
+	}
+	return &portsCtx
+}
+
+func (c *portsContext) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(c)
+}
+
+func (c *portsContext) Container() string {
+	return c.p.Container
+}
+
+func (c *portsContext) HostIP() string {
+	return c.p.HostIP
+}
+
+func (c *portsContext) HostPort() string {
+	return strconv.Itoa(int(c.p.HostPort))
+}
+
+func (c *portsContext) ContainerPort() string {
+	return strconv.Itoa(int(c.p.ContainerPort))
+}
+
+func (c *portsContext) Protocol() string {
+	return c.p.Protocol
+}
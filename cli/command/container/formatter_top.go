@@ -0,0 +1,104 @@
+package container
+
+import (
+	"github.com/docker/cli/cli/command/formatter"
+	"github.com/docker/docker/api/types/container"
+)
+
+const (
+	defaultTopTableFormat = "table {{.PID}}\t{{.PPID}}\t{{.User}}\t{{.CPU}}\t{{.Mem}}\t{{.Command}}"
+
+	pidHeader     = "PID"
+	ppidHeader    = "PPID"
+	userHeader    = "USER"
+	cpuHeader     = "%CPU"
+	memHeader     = "%MEM"
+	commandHeader = "COMMAND"
+)
+
+// topPsArgs are the ps options used to collect the processes of a container
+// when a format is used, so that the columns are known.
+var topPsArgs = []string{"-eo", "pid,ppid,user,pcpu,pmem,args"}
+
+// NewTopFormat returns a format for use with a top Context
+func NewTopFormat(source string) formatter.Format {
+	if source == formatter.TableFormatKey {
+		return defaultTopTableFormat
+	}
+	return formatter.Format(source)
+}
+
+// TopFormatWrite writes formatted processes using the Context
+func TopFormatWrite(ctx formatter.Context, procList container.ContainerTopOKBody) error {
+	columns := make(map[string]int, len(procList.Titles))
+	for i, title := range procList.Titles {
+		columns[title] = i
+	}
+	render := func(format func(subContext formatter.SubContext) error) error {
+		for _, proc := range procList.Processes {
+			if err := format(&topContext{columns: columns, p: proc}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return ctx.Write(newTopContext(), render)
+}
+
+type topContext struct {
+	formatter.HeaderContext
+	columns map[string]int
+	p       []string
+}
+
+func newTopContext() *topContext {
+	topCtx := topContext{}
+	topCtx.Header = formatter.SubHeaderContext{
+		"PID":     pidHeader,
+		"PPID":    ppidHeader,
+		"User":    userHeader,
+		"CPU":     cpuHeader,
+		"Mem":     memHeader,
+		"Command": commandHeader,
+	}
+	return &topCtx
+}
+
+func (t *topContext) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(t)
+}
+
+// column returns the value of the first of the given columns that is present
+// in the output of ps.
+func (t *topContext) column(titles ...string) string {
+	for _, title := range titles {
+		if i, ok := t.columns[title]; ok && i < len(t.p) {
+			return t.p[i]
+		}
+	}
+	return ""
+}
+
+func (t *topContext) PID() string {
+	return t.column(pidHeader)
+}
+
+func (t *topContext) PPID() string {
+	return t.column(ppidHeader)
+}
+
+func (t *topContext) User() string {
+	return t.column(userHeader, "UID")
+}
+
+func (t *topContext) CPU() string {
+	return t.column(cpuHeader, "C")
+}
+
+func (t *topContext) Mem() string {
+	return t.column(memHeader)
+}
+
+func (t *topContext) Command() string {
+	return t.column(commandHeader, "CMD")
+}
@@ -5,6 +5,7 @@
 
 import (
 	"context"
+	"strings"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
@@ -15,9 +16,10 @@
 )
 
 type inspectOptions struct {
-	format string
-	size   bool
-	refs   []string
+	format      string
+	size        bool
+	sizeChanged bool
+	refs        []string
 }
 
 // newInspectCommand creates a new cobra.Command for `docker container inspect`
@@ -30,6 +32,7 @@ func newInspectCommand(dockerCli command.Cli) *cobra.Command {
 		Args:  cli.RequiresMinArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.refs = args
+			opts.sizeChanged = cmd.Flags().Changed("size")
 			return runInspect(cmd.Context(), dockerCli, opts)
 		},
 		ValidArgsFunction: completion.ContainerNames(dockerCli, true),
@@ -45,6 +48,13 @@ func newInspectCommand(dockerCli command.Cli) *cobra.Command {
 func runInspect(ctx context.Context, dockerCli command.Cli, opts inspectOptions) error {
 	client := dockerCli.Client()
 
+	// Calculating the size of a container is costly, so it's only done when
+	// requested, or when the format uses the size fields (unless explicitly
+	// disabled with --size=false).
+	if !opts.size && !opts.sizeChanged {
+		opts.size = strings.Contains(opts.format, ".SizeRw") || strings.Contains(opts.format, ".SizeRootFs")
+	}
+
 	getRefFunc := func(ref string) (any, []byte, error) {
 		return client.ContainerInspectWithRaw(ctx, ref, opts.size)
 	}
@@ -0,0 +1,60 @@
+package container
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/container"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestInspectSizeFields(t *testing.T) {
+	testCases := []struct {
+		doc          string
+		args         []string
+		sizeExpected bool
+		expected     string
+	}{
+		{
+			doc:          "size fields in format",
+			args:         []string{"--format", "{{.Name}} {{humanSize .SizeRw}} {{humanSize .SizeRootFs}}", "web"},
+			sizeExpected: true,
+			expected:     "/web 2.3GB 2.4GB\n",
+		},
+		{
+			doc:          "no size fields in format",
+			args:         []string{"--format", "{{.Name}}", "web"},
+			sizeExpected: false,
+			expected:     "/web\n",
+		},
+		{
+			doc:          "size explicitly disabled",
+			args:         []string{"--size=false", "--format", "{{.Name}} {{humanSize .SizeRw}}", "web"},
+			sizeExpected: false,
+			expected:     "/web \n",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.doc, func(t *testing.T) {
+			cli := test.NewFakeCli(&fakeClient{
+				inspectWithRawFunc: func(_ string, getSize bool) (container.InspectResponse, []byte, error) {
+					assert.Check(t, is.Equal(getSize, tc.sizeExpected))
+					c := container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{Name: "/web"}}
+					if getSize {
+						sizeRw, sizeRootFs := int64(2300000000), int64(2400000000)
+						c.SizeRw, c.SizeRootFs = &sizeRw, &sizeRootFs
+					}
+					raw, err := json.Marshal(c)
+					return c, raw, err
+				},
+			})
+			cmd := newInspectCommand(cli)
+			cmd.SetArgs(tc.args)
+			assert.NilError(t, cmd.Execute())
+			assert.Check(t, is.Equal(cli.OutBuffer().String(), tc.expected))
+		})
+	}
+}
@@ -5,27 +5,31 @@
 	"fmt"
 	"strings"
 
-	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/opts"
+	"github.com/docker/docker/errdefs"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 type killOptions struct {
 	signal string
+	all    bool
+	force  bool
 
+	filter     opts.FilterOpt
 	containers []string
 }
 
 // NewKillCommand creates a new cobra.Command for `docker kill`
 func NewKillCommand(dockerCli command.Cli) *cobra.Command {
-	var opts killOptions
+	opts := killOptions{filter: opts.NewFilterOpt()}
 
 	cmd := &cobra.Command{
 		Use:   "kill [OPTIONS] CONTAINER [CONTAINER...]",
 		Short: "Kill one or more running containers",
-		Args:  cli.RequiresMinArgs(1),
+		Args:  requiresContainersFilterOrAll(&opts.filter, &opts.all),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.containers = args
 			return runKill(cmd.Context(), dockerCli, &opts)
@@ -38,6 +42,9 @@ func NewKillCommand(dockerCli command.Cli) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.StringVarP(&opts.signal, "signal", "s", "", "Signal to send to the container")
+	flags.Var(&opts.filter, "filter", `Kill all running containers matching the filter (e.g. "label=project=foo")`)
+	flags.BoolVarP(&opts.all, "all", "a", false, "Kill all running containers (matching the filter, if any)")
+	flags.BoolVarP(&opts.force, "force", "f", false, "Do not prompt for confirmation when using --all")
 
 	_ = cmd.RegisterFlagCompletionFunc("signal", completeSignals)
 
@@ -45,6 +52,29 @@ func NewKillCommand(dockerCli command.Cli) *cobra.Command {
 }
 
 func runKill(ctx context.Context, dockerCli command.Cli, opts *killOptions) error {
+	if opts.all {
+		containers, err := containersWithStatus(ctx, dockerCli.Client(), nil, opts.filter.Value(), true, "running")
+		if err != nil {
+			return err
+		}
+		if !opts.force {
+			r, err := command.PromptForConfirmation(ctx, dockerCli.In(), dockerCli.Out(), fmt.Sprintf(killAllWarning, len(containers)))
+			if err != nil {
+				return err
+			}
+			if !r {
+				return errdefs.Cancelled(errors.New("kill has been cancelled"))
+			}
+		}
+		opts.containers = containers
+	} else {
+		containers, err := containersWithFilter(ctx, dockerCli.Client(), opts.containers, opts.filter.Value(), false)
+		if err != nil {
+			return err
+		}
+		opts.containers = containers
+	}
+
 	var errs []string
 	errChan := parallelOperation(ctx, opts.containers, func(ctx context.Context, container string) error {
 		return dockerCli.Client().ContainerKill(ctx, container, opts.signal)
@@ -61,3 +91,6 @@ func runKill(ctx context.Context, dockerCli command.Cli, opts *killOptions) erro
 	}
 	return nil
 }
+
+const killAllWarning = `WARNING! This will kill %d running container(s).
+Are you sure you want to continue?`
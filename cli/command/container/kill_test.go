@@ -0,0 +1,87 @@
+package container
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/docker/cli/cli/streams"
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/container"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestKillAll(t *testing.T) {
+	newClient := func(killed *[]string) *fakeClient {
+		var mu sync.Mutex
+		return &fakeClient{
+			containerListFunc: func(options container.ListOptions) ([]container.Summary, error) {
+				assert.Check(t, is.DeepEqual(options.Filters.Get("status"), []string{"running"}))
+				assert.Check(t, is.DeepEqual(options.Filters.Get("label"), []string{"project=foo"}))
+				return []container.Summary{
+					{ID: "id-1", Names: []string{"/one"}},
+					{ID: "id-2", Names: []string{"/two"}},
+				}, nil
+			},
+			containerKillFunc: func(_ context.Context, container, signal string) error {
+				assert.Check(t, is.Equal(signal, "SIGTERM"))
+				mu.Lock()
+				defer mu.Unlock()
+				*killed = append(*killed, container)
+				return nil
+			},
+		}
+	}
+
+	t.Run("force", func(t *testing.T) {
+		var killed []string
+		cli := test.NewFakeCli(newClient(&killed))
+		cmd := NewKillCommand(cli)
+		cmd.SetArgs([]string{"--all", "--force", "--filter", "label=project=foo", "--signal", "SIGTERM"})
+		assert.NilError(t, cmd.Execute())
+		sort.Strings(killed)
+		assert.Check(t, is.DeepEqual(killed, []string{"one", "two"}))
+		assert.Check(t, is.Equal(cli.OutBuffer().String(), "one\ntwo\n"))
+	})
+
+	t.Run("confirmed", func(t *testing.T) {
+		var killed []string
+		cli := test.NewFakeCli(newClient(&killed))
+		cli.SetIn(streams.NewIn(io.NopCloser(strings.NewReader("y\n"))))
+		cmd := NewKillCommand(cli)
+		cmd.SetArgs([]string{"--all", "--filter", "label=project=foo", "--signal", "SIGTERM"})
+		assert.NilError(t, cmd.Execute())
+		assert.Check(t, is.Len(killed, 2))
+		assert.Check(t, is.Contains(cli.OutBuffer().String(), "WARNING! This will kill 2 running container(s)."))
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		var killed []string
+		cli := test.NewFakeCli(newClient(&killed))
+		cli.SetIn(streams.NewIn(io.NopCloser(strings.NewReader("n\n"))))
+		cmd := NewKillCommand(cli)
+		cmd.SetArgs([]string{"--all", "--filter", "label=project=foo"})
+		assert.Check(t, is.Error(cmd.Execute(), "kill has been cancelled"))
+		assert.Check(t, is.Len(killed, 0))
+	})
+}
+
+func TestKillAllWithContainers(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{})
+	cmd := NewKillCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--all", "foo"})
+	assert.Check(t, is.Error(cmd.Execute(), "conflicting options: cannot specify both --all and container names"))
+}
+
+func TestCompleteSignals(t *testing.T) {
+	names, _ := completeSignals(NewKillCommand(test.NewFakeCli(&fakeClient{})), nil, "")
+	assert.Check(t, is.Contains(names, "KILL"))
+	assert.Check(t, is.Contains(names, "SIGKILL"))
+	assert.Check(t, sort.StringsAreSorted(names))
+}
@@ -26,6 +26,8 @@ type psOptions struct {
 	last        int
 	format      string
 	filter      opts.FilterOpt
+	tree        bool
+	groupBy     string
 }
 
 // NewPsCommand creates a new cobra.Command for `docker ps`
@@ -57,6 +59,8 @@ func NewPsCommand(dockerCLI command.Cli) *cobra.Command {
 	flags.IntVarP(&options.last, "last", "n", -1, "Show n last created containers (includes all states)")
 	flags.StringVar(&options.format, "format", "", flagsHelper.FormatHelp)
 	flags.VarP(&options.filter, "filter", "f", "Filter output based on conditions provided")
+	flags.BoolVar(&options.tree, "tree", false, "Show containers as a tree, grouped by Compose project or by the label set with --group-by")
+	flags.StringVar(&options.groupBy, "group-by", "", `Label to group containers by in the tree view (default "`+defaultTreeGroupLabel+`")`)
 
 	return cmd
 }
@@ -115,6 +119,23 @@ func buildContainerListOptions(options *psOptions) (*container.ListOptions, erro
 }
 
 func runPs(ctx context.Context, dockerCLI command.Cli, options *psOptions) error {
+	if options.tree {
+		if options.quiet {
+			return errors.New("--quiet is not supported with --tree")
+		}
+		if options.format != "" {
+			return errors.New("--format is not supported with --tree")
+		}
+		listOptions, err := buildContainerListOptions(options)
+		if err != nil {
+			return err
+		}
+		return runPsTree(ctx, dockerCLI, options, listOptions)
+	}
+	if options.groupBy != "" {
+		return errors.New("--group-by can only be used with --tree")
+	}
+
 	if len(options.format) == 0 {
 		// load custom psFormat from CLI config (if any)
 		options.format = dockerCLI.ConfigFile().PsFormat
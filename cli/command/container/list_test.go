@@ -246,6 +246,11 @@ func TestContainerListFormatSizeSetsOption(t *testing.T) {
 			format:       `{{.Size}}`,
 			sizeExpected: true,
 		},
+		{
+			doc:          "detect with raw size fields",
+			format:       `{{humanSize .SizeRw}} {{.SizeRootFs}}`,
+			sizeExpected: true,
+		},
 		{
 			doc:          "detect no size",
 			format:       `{{.Names}}`,
@@ -329,3 +334,61 @@ func TestContainerListWithFormat(t *testing.T) {
 		golden.Assert(t, cli.OutBuffer().String(), "container-list-quiet.golden")
 	})
 }
+
+func TestContainerListTree(t *testing.T) {
+	running := func(c *container.Summary) {
+		c.State = "running"
+	}
+	exited := func(c *container.Summary) {
+		c.State = "exited"
+		c.Status = "Exited (1) 2 minutes ago"
+	}
+	cli := test.NewFakeCli(&fakeClient{
+		containerListFunc: func(_ container.ListOptions) ([]container.Summary, error) {
+			return []container.Summary{
+				*builders.Container("nginx", running),
+				*builders.Container("shop-web-1", running, builders.WithLabel("com.docker.compose.project", "shop"), builders.WithPort(80, 8080, builders.IP("0.0.0.0"), builders.TCP)),
+				*builders.Container("shop-db-1", exited, builders.WithLabel("com.docker.compose.project", "shop")),
+				*builders.Container("blog-web-1", running, builders.WithLabel("com.docker.compose.project", "blog")),
+			}, nil
+		},
+	})
+	cmd := newListCommand(cli)
+	cmd.SetArgs([]string{"--tree"})
+	assert.NilError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "container-list-tree.golden")
+}
+
+func TestGroupContainers(t *testing.T) {
+	groups := groupContainers([]container.Summary{
+		*builders.Container("web", builders.WithLabel("team", "frontend")),
+		*builders.Container("other"),
+		*builders.Container("worker", builders.WithLabel("team", "backend")),
+		*builders.Container("api", builders.WithLabel("team", "backend")),
+	}, "team")
+
+	var actual []string
+	for _, g := range groups {
+		for _, c := range g.Containers {
+			actual = append(actual, g.Name+"/"+containerName(c))
+		}
+	}
+	assert.Check(t, is.DeepEqual(actual, []string{"backend/api", "backend/worker", "frontend/web", "<none>/other"}))
+}
+
+func TestContainerListTreeErrors(t *testing.T) {
+	for _, tc := range []struct {
+		args        []string
+		expectedErr string
+	}{
+		{args: []string{"--tree", "--quiet"}, expectedErr: "--quiet is not supported with --tree"},
+		{args: []string{"--tree", "--format", "{{.ID}}"}, expectedErr: "--format is not supported with --tree"},
+		{args: []string{"--group-by", "team"}, expectedErr: "--group-by can only be used with --tree"},
+	} {
+		cmd := newListCommand(test.NewFakeCli(&fakeClient{}))
+		cmd.SetArgs(tc.args)
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		assert.Check(t, is.Error(cmd.Execute(), tc.expectedErr))
+	}
+}
@@ -0,0 +1,127 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/formatter"
+	"github.com/docker/cli/cli/command/formatter/tabwriter"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stringid"
+)
+
+// defaultTreeGroupLabel is the label used to group containers in the tree
+// view if no label is specified. It is set by Docker Compose to the name of
+// the project.
+const defaultTreeGroupLabel = "com.docker.compose.project"
+
+// ungroupedName is the name of the group of containers that don't have the
+// label that containers are grouped by.
+const ungroupedName = "<none>"
+
+// containerGroup is a group of containers with the same value for the label
+// that containers are grouped by.
+type containerGroup struct {
+	Name       string
+	Containers []container.Summary
+}
+
+// Running returns the number of running containers in the group.
+func (g containerGroup) Running() int {
+	var n int
+	for _, c := range g.Containers {
+		if c.State == "running" {
+			n++
+		}
+	}
+	return n
+}
+
+// Ports returns the ports published by all containers in the group.
+func (g containerGroup) Ports() string {
+	var ports []container.Port
+	for _, c := range g.Containers {
+		ports = append(ports, c.Ports...)
+	}
+	return formatter.DisplayablePorts(ports)
+}
+
+func runPsTree(ctx context.Context, dockerCLI command.Cli, options *psOptions, listOptions *container.ListOptions) error {
+	containers, err := dockerCLI.Client().ContainerList(ctx, *listOptions)
+	if err != nil {
+		return err
+	}
+	label := options.groupBy
+	if label == "" {
+		label = defaultTreeGroupLabel
+	}
+	return printContainerTree(dockerCLI.Out(), groupContainers(containers, label), !options.noTrunc)
+}
+
+// groupContainers groups containers by the value of the given label. Groups
+// are sorted by name, with the containers that don't have the label last.
+func groupContainers(containers []container.Summary, label string) []containerGroup {
+	byName := make(map[string]*containerGroup)
+	for _, c := range containers {
+		name, ok := c.Labels[label]
+		if !ok || name == "" {
+			name = ungroupedName
+		}
+		g, ok := byName[name]
+		if !ok {
+			g = &containerGroup{Name: name}
+			byName[name] = g
+		}
+		g.Containers = append(g.Containers, c)
+	}
+
+	groups := make([]containerGroup, 0, len(byName))
+	for _, g := range byName {
+		sort.Slice(g.Containers, func(i, j int) bool {
+			return containerName(g.Containers[i]) < containerName(g.Containers[j])
+		})
+		groups = append(groups, *g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if (groups[i].Name == ungroupedName) != (groups[j].Name == ungroupedName) {
+			return groups[j].Name == ungroupedName
+		}
+		return groups[i].Name < groups[j].Name
+	})
+	return groups
+}
+
+func containerName(c container.Summary) string {
+	if names := formatter.StripNamePrefix(c.Names); len(names) > 0 {
+		return names[0]
+	}
+	return c.ID
+}
+
+func printContainerTree(out io.Writer, groups []containerGroup, trunc bool) error {
+	w := tabwriter.NewWriter(out, 10, 1, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tCONTAINER ID\tIMAGE\tSTATUS\tPORTS")
+	for _, g := range groups {
+		_, _ = fmt.Fprintf(w, "%s\t\t\t%d/%d running\t%s\n", g.Name, g.Running(), len(g.Containers), g.Ports())
+		for i, c := range g.Containers {
+			prefix := "├─ "
+			if i == len(g.Containers)-1 {
+				prefix = "└─ "
+			}
+			id := c.ID
+			if trunc {
+				id = stringid.TruncateID(id)
+			}
+			status := c.Status
+			if status == "" {
+				status = c.State
+			}
+			_, _ = fmt.Fprintf(w, "%s%s\t%s\t%s\t%s\t%s\n", prefix, containerName(c), id, c.Image, status, strings.TrimSpace(formatter.DisplayablePorts(c.Ports)))
+		}
+	}
+	return w.Flush()
+}
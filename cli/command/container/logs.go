@@ -1,14 +1,25 @@
 package container
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
-	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/morikuni/aec"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -19,21 +30,30 @@ type logsOptions struct {
 	timestamps bool
 	details    bool
 	tail       string
+	tailBytes  opts.MemBytes
+	retry      bool
+	filter     opts.FilterOpt
 
-	container string
+	containers []string
 }
 
 // NewLogsCommand creates a new cobra.Command for `docker logs`
 func NewLogsCommand(dockerCli command.Cli) *cobra.Command {
-	var opts logsOptions
+	options := logsOptions{filter: opts.NewFilterOpt()}
 
 	cmd := &cobra.Command{
-		Use:   "logs [OPTIONS] CONTAINER",
-		Short: "Fetch the logs of a container",
-		Args:  cli.ExactArgs(1),
+		Use:   "logs [OPTIONS] CONTAINER [CONTAINER...]",
+		Short: "Fetch the logs of one or more containers",
+		Args:  requiresContainersOrFilter(&options.filter),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			opts.container = args[0]
-			return runLogs(cmd.Context(), dockerCli, &opts)
+			if options.retry && !options.follow {
+				return errors.New("--retry can only be used with --follow")
+			}
+			if options.tailBytes > 0 && cmd.Flags().Changed("tail") {
+				return errors.New("conflicting options: cannot specify both --tail and --tail-bytes")
+			}
+			options.containers = args
+			return runLogs(cmd.Context(), dockerCli, &options)
 		},
 		Annotations: map[string]string{
 			"aliases": "docker container logs, docker logs",
@@ -42,18 +62,244 @@ func NewLogsCommand(dockerCli command.Cli) *cobra.Command {
 	}
 
 	flags := cmd.Flags()
-	flags.BoolVarP(&opts.follow, "follow", "f", false, "Follow log output")
-	flags.StringVar(&opts.since, "since", "", `Show logs since timestamp (e.g. "2013-01-02T13:23:37Z") or relative (e.g. "42m" for 42 minutes)`)
-	flags.StringVar(&opts.until, "until", "", `Show logs before a timestamp (e.g. "2013-01-02T13:23:37Z") or relative (e.g. "42m" for 42 minutes)`)
+	flags.BoolVarP(&options.follow, "follow", "f", false, "Follow log output")
+	flags.StringVar(&options.since, "since", "", `Show logs since timestamp (e.g. "2013-01-02T13:23:37Z") or relative (e.g. "42m" for 42 minutes, or "2 hours ago")`)
+	flags.StringVar(&options.until, "until", "", `Show logs before a timestamp (e.g. "2013-01-02T13:23:37Z") or relative (e.g. "42m" for 42 minutes, or "2 hours ago")`)
 	flags.SetAnnotation("until", "version", []string{"1.35"})
-	flags.BoolVarP(&opts.timestamps, "timestamps", "t", false, "Show timestamps")
-	flags.BoolVar(&opts.details, "details", false, "Show extra details provided to logs")
-	flags.StringVarP(&opts.tail, "tail", "n", "all", "Number of lines to show from the end of the logs")
+	flags.BoolVarP(&options.timestamps, "timestamps", "t", false, "Show timestamps")
+	flags.BoolVar(&options.details, "details", false, "Show extra details provided to logs")
+	flags.StringVarP(&options.tail, "tail", "n", "all", "Number of lines to show from the end of the logs")
+	flags.Var(&options.tailBytes, "tail-bytes", `Maximum amount of logs to show from the end of the logs (e.g. "1mb")`)
+	flags.BoolVar(&options.retry, "retry", false, "Keep following the logs when the container or the daemon is restarted")
+	flags.Var(&options.filter, "filter", `Fetch logs of all containers matching the filter (e.g. "label=project=foo")`)
 	return cmd
 }
 
 func runLogs(ctx context.Context, dockerCli command.Cli, opts *logsOptions) error {
-	c, err := dockerCli.Client().ContainerInspect(ctx, opts.container)
+	now := time.Now()
+	for _, v := range []*string{&opts.since, &opts.until} {
+		if t, ok := parseRelativeTime(*v, now); ok {
+			*v = formatLogTimestamp(t)
+		}
+	}
+
+	containers, err := containersWithFilter(ctx, dockerCli.Client(), opts.containers, opts.filter.Value(), true)
+	if err != nil {
+		return err
+	}
+
+	// A single container keeps the historic, unprefixed output.
+	if len(containers) == 1 && opts.filter.Value().Len() == 0 {
+		if opts.retry {
+			return retryLogs(ctx, dockerCli, opts, containers[0], dockerCli.Out(), dockerCli.Err())
+		}
+		return streamLogs(ctx, dockerCli, opts, containers[0], dockerCli.Out(), dockerCli.Err())
+	}
+	return runMultiLogs(ctx, dockerCli, opts, containers)
+}
+
+// runMultiLogs streams the logs of all containers concurrently, prefixing
+// each line with the name of the container it originates from.
+func runMultiLogs(ctx context.Context, dockerCli command.Cli, opts *logsOptions, containers []string) error {
+	stdouts, stderrs := newPrefixWriters(dockerCli, containers)
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(containers))
+	for i, c := range containers {
+		wg.Add(1)
+		go func(i int, c string) {
+			defer wg.Done()
+			errs[i] = followLogs(ctx, dockerCli, opts, c, stdouts[i], stderrs[i])
+			_ = stdouts[i].Flush()
+			_ = stderrs[i].Flush()
+		}(i, c)
+	}
+	wg.Wait()
+
+	return containersError(containers, errs)
+}
+
+// newPrefixWriters returns the writers for the standard output and standard
+// error of each container, prefixing each line with the name of the container.
+func newPrefixWriters(dockerCli command.Cli, containers []string) (stdouts, stderrs []*prefixWriter) {
+	var width int
+	for _, c := range containers {
+		if len(c) > width {
+			width = len(c)
+		}
+	}
+
+	outMu, errMu := new(sync.Mutex), new(sync.Mutex)
+	for i, c := range containers {
+		prefix := fmt.Sprintf("%-*s | ", width, c)
+		if dockerCli.Out().IsTerminal() {
+			prefix = logPrefixColors[i%len(logPrefixColors)].Apply(prefix)
+		}
+		stdouts = append(stdouts, &prefixWriter{out: dockerCli.Out(), mu: outMu, prefix: prefix})
+		stderrs = append(stderrs, &prefixWriter{out: dockerCli.Err(), mu: errMu, prefix: prefix})
+	}
+	return stdouts, stderrs
+}
+
+// containersError combines the errors of an operation on multiple containers,
+// errs[i] being the error for containers[i].
+func containersError(containers []string, errs []error) error {
+	var errMsgs []string
+	for i, err := range errs {
+		if err != nil {
+			errMsgs = append(errMsgs, fmt.Sprintf("%s: %s", containers[i], err))
+		}
+	}
+	if len(errMsgs) > 0 {
+		return errors.New(strings.Join(errMsgs, "\n"))
+	}
+	return nil
+}
+
+// followLogs streams the logs of a single container. When following, it
+// reconnects to the container once it is started again by its restart policy,
+// resuming from the moment the previous stream ended.
+func followLogs(ctx context.Context, dockerCli command.Cli, opts *logsOptions, containerID string, stdout, stderr io.Writer) error {
+	if opts.retry {
+		return retryLogs(ctx, dockerCli, opts, containerID, stdout, stderr)
+	}
+	o := *opts
+	for {
+		if err := streamLogs(ctx, dockerCli, &o, containerID, stdout, stderr); err != nil || !opts.follow {
+			return err
+		}
+		ended := time.Now()
+		restarted, err := waitContainerRestart(ctx, dockerCli, containerID, ended)
+		if err != nil || !restarted {
+			return err
+		}
+		o.since = formatLogTimestamp(ended)
+		o.tail = "all"
+		o.tailBytes = 0
+	}
+}
+
+// waitContainerRestart waits for the container to be started again if it is
+// being restarted. It returns false if the container is not going to restart.
+func waitContainerRestart(ctx context.Context, dockerCli command.Cli, containerID string, since time.Time) (bool, error) {
+	c, err := dockerCli.Client().ContainerInspect(ctx, containerID)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if c.State.Running {
+		return true, nil
+	}
+	if !c.State.Restarting {
+		return false, nil
+	}
+
+	f := filters.NewArgs(
+		filters.Arg("type", string(events.ContainerEventType)),
+		filters.Arg("container", c.ID),
+		filters.Arg("event", string(events.ActionStart)),
+		filters.Arg("event", string(events.ActionDestroy)),
+	)
+	eventChan, errChan := dockerCli.Client().Events(ctx, events.ListOptions{
+		Since:   strconv.FormatInt(since.Unix(), 10),
+		Filters: f,
+	})
+	select {
+	case <-ctx.Done():
+		return false, nil
+	case e := <-eventChan:
+		return e.Action == events.ActionStart, nil
+	case err := <-errChan:
+		return false, err
+	}
+}
+
+const (
+	minRetryDelay = 500 * time.Millisecond
+	maxRetryDelay = 10 * time.Second
+)
+
+// retryLogs follows the logs of a single container until it is removed or
+// ctx is cancelled. Whenever the stream is interrupted, because the container
+// stopped or because the connection to the daemon was lost, it waits for the
+// container to be running again and resumes from the last timestamp seen.
+func retryLogs(ctx context.Context, dockerCli command.Cli, opts *logsOptions, containerID string, stdout, stderr io.Writer) error {
+	o := *opts
+	o.timestamps = true // needed to know where to resume from
+	var last time.Time
+	outW := &timestampWriter{out: stdout, last: &last, strip: !opts.timestamps}
+	errW := &timestampWriter{out: stderr, last: &last, strip: !opts.timestamps}
+
+	delay := minRetryDelay
+	for {
+		started := time.Now()
+		err := streamLogs(ctx, dockerCli, &o, containerID, outW, errW)
+		if flushErr := outW.Flush(); flushErr != nil {
+			return flushErr
+		}
+		if flushErr := errW.Flush(); flushErr != nil {
+			return flushErr
+		}
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case errdefs.IsNotFound(err):
+			return err
+		case err == nil:
+			delay = minRetryDelay
+		}
+
+		running, err := waitContainerRunning(ctx, dockerCli, containerID, &delay)
+		if err != nil || !running {
+			return err
+		}
+		since := started
+		if !last.IsZero() {
+			since = last.Add(time.Nanosecond)
+		}
+		o.since = formatLogTimestamp(since)
+		o.tail = "all"
+		o.tailBytes = 0
+	}
+}
+
+// waitContainerRunning polls the daemon until the container is running,
+// increasing delay between attempts. It returns false if the container is
+// stopped, and isn't being restarted by its restart policy. Errors other than
+// the container not existing are considered transient, as the daemon may be
+// restarting.
+func waitContainerRunning(ctx context.Context, dockerCli command.Cli, containerID string, delay *time.Duration) (bool, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(*delay):
+		}
+		*delay = min(*delay*2, maxRetryDelay)
+
+		c, err := dockerCli.Client().ContainerInspect(ctx, containerID)
+		switch {
+		case errdefs.IsNotFound(err):
+			return false, err
+		case err != nil:
+			continue
+		case c.State.Running:
+			return true, nil
+		case !c.State.Restarting:
+			return false, nil
+		}
+	}
+}
+
+// streamLogs copies the logs of a single container to stdout and stderr.
+func streamLogs(ctx context.Context, dockerCli command.Cli, opts *logsOptions, containerID string, stdout, stderr io.Writer) error {
+	if opts.tailBytes > 0 {
+		return streamLogsTailBytes(ctx, dockerCli, opts, containerID, stdout, stderr)
+	}
+
+	c, err := dockerCli.Client().ContainerInspect(ctx, containerID)
 	if err != nil {
 		return err
 	}
@@ -74,9 +320,261 @@ func runLogs(ctx context.Context, dockerCli command.Cli, opts *logsOptions) erro
 	defer responseBody.Close()
 
 	if c.Config.Tty {
-		_, err = io.Copy(dockerCli.Out(), responseBody)
+		_, err = io.Copy(stdout, responseBody)
 	} else {
-		_, err = stdcopy.StdCopy(dockerCli.Out(), dockerCli.Err(), responseBody)
+		_, err = stdcopy.StdCopy(stdout, stderr, responseBody)
+	}
+	return err
+}
+
+// streamLogsTailBytes copies the last opts.tailBytes bytes of the logs of a
+// single container to stdout and stderr, then follows the logs if requested.
+// The daemon only supports tailing a number of lines, so the logs are fetched
+// in full, and truncated client-side.
+func streamLogsTailBytes(ctx context.Context, dockerCli command.Cli, opts *logsOptions, containerID string, stdout, stderr io.Writer) error {
+	now := time.Now()
+	o := *opts
+	o.tail = "all"
+	o.tailBytes = 0
+	o.follow = false
+	if opts.follow && o.until == "" {
+		o.until = formatLogTimestamp(now)
+	}
+
+	buf := &tailBuffer{max: opts.tailBytes.Value()}
+	err := streamLogs(ctx, dockerCli, &o, containerID, buf.writer(false), buf.writer(true))
+	if flushErr := buf.flush(stdout, stderr); err == nil {
+		err = flushErr
+	}
+	if err != nil || !opts.follow {
+		return err
+	}
+
+	o = *opts
+	o.since = formatLogTimestamp(now)
+	o.tail = "all"
+	o.tailBytes = 0
+	return streamLogs(ctx, dockerCli, &o, containerID, stdout, stderr)
+}
+
+// formatLogTimestamp formats t as a timestamp accepted by the daemon for the
+// since and until options.
+func formatLogTimestamp(t time.Time) string {
+	return fmt.Sprintf("%d.%09d", t.Unix(), t.Nanosecond())
+}
+
+var relativeTimeUnits = map[string]time.Duration{
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+}
+
+// parseRelativeTime parses the human-friendly times that are not supported by
+// the daemon: "now", "today", "yesterday", and "<amount> <unit> ago" (e.g.
+// "2 hours ago", "3d ago", or "1h30m ago"). It returns false for any other
+// value, which is passed to the daemon as-is.
+func parseRelativeTime(value string, now time.Time) (time.Time, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "now":
+		return now, true
+	case "today":
+		y, m, d := now.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, now.Location()), true
+	case "yesterday":
+		y, m, d := now.Date()
+		return time.Date(y, m, d-1, 0, 0, 0, 0, now.Location()), true
+	}
+
+	value, ok := strings.CutSuffix(value, " ago")
+	if !ok {
+		return time.Time{}, false
+	}
+	if d, err := time.ParseDuration(strings.TrimSpace(value)); err == nil {
+		return now.Add(-d), true
+	}
+	amount, unit := value, ""
+	if i := strings.IndexFunc(value, func(r rune) bool { return r != '.' && (r < '0' || r > '9') }); i > 0 {
+		amount, unit = value[:i], strings.TrimSpace(value[i:])
+	}
+	n, err := strconv.ParseFloat(amount, 64)
+	if err != nil || n < 0 {
+		return time.Time{}, false
+	}
+	d, ok := relativeTimeUnits[unit]
+	if !ok {
+		return time.Time{}, false
+	}
+	return now.Add(-time.Duration(n * float64(d))), true
+}
+
+// tailBuffer keeps the last max bytes written to its writers, preserving the
+// order of the writes to stdout and stderr.
+type tailBuffer struct {
+	max       int64
+	size      int64
+	truncated bool
+	chunks    []tailChunk
+}
+
+type tailChunk struct {
+	stderr bool
+	data   []byte
+}
+
+type tailBufferWriter struct {
+	buf    *tailBuffer
+	stderr bool
+}
+
+func (w tailBufferWriter) Write(p []byte) (int, error) {
+	w.buf.add(w.stderr, p)
+	return len(p), nil
+}
+
+func (b *tailBuffer) writer(stderr bool) io.Writer {
+	return tailBufferWriter{buf: b, stderr: stderr}
+}
+
+func (b *tailBuffer) add(stderr bool, p []byte) {
+	b.chunks = append(b.chunks, tailChunk{stderr: stderr, data: bytes.Clone(p)})
+	b.size += int64(len(p))
+	for b.size > b.max {
+		b.truncated = true
+		excess := b.size - b.max
+		if first := &b.chunks[0]; int64(len(first.data)) > excess {
+			first.data = first.data[excess:]
+			b.size -= excess
+		} else {
+			b.size -= int64(len(first.data))
+			b.chunks = b.chunks[1:]
+		}
+	}
+}
+
+// flush writes the buffered logs to stdout and stderr. If the logs were
+// truncated, the output starts at the first complete line.
+func (b *tailBuffer) flush(stdout, stderr io.Writer) error {
+	if b.truncated && len(b.chunks) > 0 {
+		if i := bytes.IndexByte(b.chunks[0].data, '\n'); i >= 0 {
+			b.chunks[0].data = b.chunks[0].data[i+1:]
+		}
+	}
+	for _, c := range b.chunks {
+		w := stdout
+		if c.stderr {
+			w = stderr
+		}
+		if _, err := w.Write(c.data); err != nil {
+			return err
+		}
+	}
+	b.chunks, b.size = nil, 0
+	return nil
+}
+
+var logPrefixColors = []aec.ANSI{
+	aec.CyanF,
+	aec.YellowF,
+	aec.GreenF,
+	aec.MagentaF,
+	aec.BlueF,
+	aec.LightCyanF,
+	aec.LightYellowF,
+	aec.LightGreenF,
+	aec.LightMagentaF,
+	aec.LightBlueF,
+}
+
+// prefixWriter writes complete lines to out, each preceded by prefix. Partial
+// lines are buffered until their terminating newline is written, or until
+// Flush is called. Writers sharing the same output must share mu so that
+// lines of different containers are not interleaved.
+type prefixWriter struct {
+	out    io.Writer
+	mu     *sync.Mutex
+	prefix string
+	buf    bytes.Buffer
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+	for {
+		idx := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if idx < 0 {
+			return len(p), nil
+		}
+		if err := w.writeLine(w.buf.Next(idx + 1)); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Flush writes any buffered partial line, terminated with a newline.
+func (w *prefixWriter) Flush() error {
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	line := append(w.buf.Bytes(), '\n')
+	w.buf.Reset()
+	return w.writeLine(line)
+}
+
+func (w *prefixWriter) writeLine(line []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err := io.WriteString(w.out, w.prefix)
+	if err != nil {
+		return err
+	}
+	_, err = w.out.Write(line)
+	return err
+}
+
+// timestampWriter writes complete log lines to out, recording the timestamp
+// each line is prefixed with in last. The timestamp is removed from the line
+// if strip is set. Writers of the same stream must share last.
+type timestampWriter struct {
+	out   io.Writer
+	last  *time.Time
+	strip bool
+	buf   bytes.Buffer
+}
+
+func (w *timestampWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+	for {
+		idx := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if idx < 0 {
+			return len(p), nil
+		}
+		if err := w.writeLine(w.buf.Next(idx + 1)); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Flush writes any buffered partial line.
+func (w *timestampWriter) Flush() error {
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	line := bytes.Clone(w.buf.Bytes())
+	w.buf.Reset()
+	return w.writeLine(line)
+}
+
+func (w *timestampWriter) writeLine(line []byte) error {
+	if ts, rest, ok := bytes.Cut(line, []byte{' '}); ok {
+		if t, err := time.Parse(time.RFC3339Nano, string(ts)); err == nil {
+			*w.last = t
+			if w.strip {
+				line = rest
+			}
+		}
 	}
+	_, err := w.out.Write(line)
 	return err
 }
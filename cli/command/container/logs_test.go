@@ -1,13 +1,22 @@
 package container
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"io"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/cli/internal/test"
+	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/stdcopy"
 	"gotest.tools/v3/assert"
 	is "gotest.tools/v3/assert/cmp"
 )
@@ -22,7 +31,7 @@ func TestRunLogs(t *testing.T) {
 	inspectFn := func(containerID string) (container.InspectResponse, error) {
 		return container.InspectResponse{
 			Config:            &container.Config{Tty: true},
-			ContainerJSONBase: &container.ContainerJSONBase{State: &container.State{Running: false}},
+			ContainerJSONBase: &container.ContainerJSONBase{ID: containerID, State: &container.State{Running: false}},
 		}, nil
 	}
 
@@ -37,9 +46,52 @@ func TestRunLogs(t *testing.T) {
 		{
 			doc:         "successful logs",
 			expectedOut: "foo",
-			options:     &logsOptions{},
+			options:     &logsOptions{containers: []string{"foo"}},
 			client:      &fakeClient{logFunc: logFn("foo"), inspectFunc: inspectFn},
 		},
+		{
+			doc:           "multiple containers with error",
+			expectedOut:   "foo | foo\n",
+			expectedError: "bar: no such container",
+			options:       &logsOptions{containers: []string{"bar", "foo"}},
+			client: &fakeClient{
+				logFunc: logFn("foo"),
+				inspectFunc: func(containerID string) (container.InspectResponse, error) {
+					if containerID == "bar" {
+						return container.InspectResponse{}, errors.New("no such container")
+					}
+					return inspectFn(containerID)
+				},
+			},
+		},
+		{
+			doc:         "filter",
+			expectedOut: "foo | foo\n",
+			options: func() *logsOptions {
+				f := opts.NewFilterOpt()
+				assert.NilError(t, f.Set("label=project=foo"))
+				return &logsOptions{filter: f}
+			}(),
+			client: &fakeClient{
+				logFunc:     logFn("foo"),
+				inspectFunc: inspectFn,
+				containerListFunc: func(options container.ListOptions) ([]container.Summary, error) {
+					assert.Check(t, options.All)
+					assert.Check(t, is.Equal(options.Filters.Get("label")[0], "project=foo"))
+					return []container.Summary{{ID: "abc123", Names: []string{"/foo"}}}, nil
+				},
+			},
+		},
+		{
+			doc:           "filter without matches",
+			expectedError: "no containers match the given filter",
+			options: func() *logsOptions {
+				f := opts.NewFilterOpt()
+				assert.NilError(t, f.Set("label=project=foo"))
+				return &logsOptions{filter: f}
+			}(),
+			client: &fakeClient{},
+		},
 	}
 
 	for _, testcase := range testcases {
@@ -57,3 +109,250 @@ func TestRunLogs(t *testing.T) {
 		})
 	}
 }
+
+func TestRunLogsMultipleContainers(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		inspectFunc: func(containerID string) (container.InspectResponse, error) {
+			return container.InspectResponse{
+				Config:            &container.Config{},
+				ContainerJSONBase: &container.ContainerJSONBase{ID: containerID},
+			}, nil
+		},
+		logFunc: func(containerID string, _ container.LogsOptions) (io.ReadCloser, error) {
+			var buf bytes.Buffer
+			w := stdcopy.NewStdWriter(&buf, stdcopy.Stdout)
+			_, _ = w.Write([]byte("hello from " + containerID + "\npartial"))
+			w = stdcopy.NewStdWriter(&buf, stdcopy.Stderr)
+			_, _ = w.Write([]byte("oops\n"))
+			return io.NopCloser(&buf), nil
+		},
+	})
+
+	err := runLogs(context.TODO(), cli, &logsOptions{containers: []string{"web", "db-1"}})
+	assert.NilError(t, err)
+
+	out := strings.Split(strings.TrimSpace(cli.OutBuffer().String()), "\n")
+	sort.Strings(out)
+	assert.Check(t, is.DeepEqual(out, []string{
+		"db-1 | hello from db-1",
+		"db-1 | partial",
+		"web  | hello from web",
+		"web  | partial",
+	}))
+	errOut := strings.Split(strings.TrimSpace(cli.ErrBuffer().String()), "\n")
+	sort.Strings(errOut)
+	assert.Check(t, is.DeepEqual(errOut, []string{"db-1 | oops", "web  | oops"}))
+}
+
+func TestRunLogsMultipleContainersFollowRestart(t *testing.T) {
+	var calls int
+	cli := test.NewFakeCli(&fakeClient{
+		inspectFunc: func(containerID string) (container.InspectResponse, error) {
+			state := &container.State{}
+			if containerID == "web" && calls == 1 {
+				state.Restarting = true
+			}
+			return container.InspectResponse{
+				Config:            &container.Config{Tty: true},
+				ContainerJSONBase: &container.ContainerJSONBase{ID: containerID, State: state},
+			}, nil
+		},
+		logFunc: func(containerID string, options container.LogsOptions) (io.ReadCloser, error) {
+			assert.Check(t, options.Follow)
+			if containerID != "web" {
+				return io.NopCloser(strings.NewReader("ready\n")), nil
+			}
+			calls++
+			if calls == 2 {
+				assert.Check(t, options.Since != "")
+				assert.Check(t, is.Equal(options.Tail, "all"))
+			}
+			return io.NopCloser(strings.NewReader("run " + strconv.Itoa(calls) + "\n")), nil
+		},
+		eventsFunc: func(options events.ListOptions) (<-chan events.Message, <-chan error) {
+			assert.Check(t, is.DeepEqual(options.Filters.Get("container"), []string{"web"}))
+			eventChan := make(chan events.Message, 1)
+			eventChan <- events.Message{Action: events.ActionStart}
+			return eventChan, make(chan error)
+		},
+	})
+
+	err := runLogs(context.TODO(), cli, &logsOptions{follow: true, tail: "10", containers: []string{"web", "db"}})
+	assert.NilError(t, err)
+
+	out := strings.Split(strings.TrimSpace(cli.OutBuffer().String()), "\n")
+	sort.Strings(out)
+	assert.Check(t, is.DeepEqual(out, []string{"db  | ready", "web | run 1", "web | run 2"}))
+}
+
+func TestRunLogsRetry(t *testing.T) {
+	var calls int
+	cli := test.NewFakeCli(&fakeClient{
+		inspectFunc: func(containerID string) (container.InspectResponse, error) {
+			if calls == 4 {
+				// container removed
+				return container.InspectResponse{}, errdefs.NotFound(errors.New("no such container"))
+			}
+			return container.InspectResponse{
+				Config:            &container.Config{Tty: true},
+				ContainerJSONBase: &container.ContainerJSONBase{ID: containerID, State: &container.State{Running: true}},
+			}, nil
+		},
+		logFunc: func(_ string, options container.LogsOptions) (io.ReadCloser, error) {
+			calls++
+			assert.Check(t, options.Timestamps)
+			switch calls {
+			case 1:
+				assert.Check(t, is.Equal(options.Tail, "1"))
+				return io.NopCloser(strings.NewReader("2024-01-01T00:00:01.000000001Z first\n")), nil
+			case 2:
+				// connection to the daemon lost
+				assert.Check(t, is.Equal(options.Since, "1704067201.000000002"))
+				return nil, errors.New("connection refused")
+			case 3:
+				assert.Check(t, is.Equal(options.Since, "1704067201.000000002"))
+				assert.Check(t, is.Equal(options.Tail, "all"))
+				return io.NopCloser(strings.NewReader("2024-01-01T00:00:02Z second\n")), nil
+			default:
+				assert.Check(t, is.Equal(options.Since, "1704067202.000000001"))
+				return io.NopCloser(strings.NewReader("")), nil
+			}
+		},
+	})
+
+	err := runLogs(context.TODO(), cli, &logsOptions{follow: true, retry: true, tail: "1", containers: []string{"foo"}})
+	assert.Check(t, is.ErrorContains(err, "no such container"))
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "first\nsecond\n"))
+}
+
+func TestRunLogsRetryExited(t *testing.T) {
+	var inspects int
+	cli := test.NewFakeCli(&fakeClient{
+		inspectFunc: func(containerID string) (container.InspectResponse, error) {
+			inspects++
+			state := &container.State{Status: "exited"}
+			if inspects == 2 {
+				state = &container.State{Status: "restarting", Restarting: true}
+			}
+			return container.InspectResponse{
+				Config:            &container.Config{Tty: true},
+				ContainerJSONBase: &container.ContainerJSONBase{ID: containerID, State: state},
+			}, nil
+		},
+		logFunc: func(_ string, options container.LogsOptions) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("2024-01-01T00:00:01Z done\n")), nil
+		},
+	})
+
+	err := runLogs(context.TODO(), cli, &logsOptions{follow: true, retry: true, tail: "all", containers: []string{"foo"}})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "done\n"))
+	// logs, restarting, exited
+	assert.Check(t, is.Equal(inspects, 3))
+}
+
+func TestTimestampWriter(t *testing.T) {
+	var (
+		last time.Time
+		out  bytes.Buffer
+	)
+	w := &timestampWriter{out: &out, last: &last}
+	_, err := w.Write([]byte("2024-01-01T00:00:01Z hello\n2024-01-01T00:00:02Z wor"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(out.String(), "2024-01-01T00:00:01Z hello\n"))
+	_, err = w.Write([]byte("ld\nnot a timestamp\n"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(out.String(), "2024-01-01T00:00:01Z hello\n2024-01-01T00:00:02Z world\nnot a timestamp\n"))
+	assert.Check(t, is.Equal(last, time.Date(2024, 1, 1, 0, 0, 2, 0, time.UTC)))
+}
+
+func TestParseRelativeTime(t *testing.T) {
+	now := time.Date(2024, 3, 10, 15, 30, 0, 0, time.UTC)
+	testcases := []struct {
+		value    string
+		expected time.Time
+		ok       bool
+	}{
+		{value: "now", expected: now, ok: true},
+		{value: "today", expected: time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), ok: true},
+		{value: "Yesterday", expected: time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC), ok: true},
+		{value: "2 hours ago", expected: now.Add(-2 * time.Hour), ok: true},
+		{value: "1 minute ago", expected: now.Add(-time.Minute), ok: true},
+		{value: "3d ago", expected: now.Add(-72 * time.Hour), ok: true},
+		{value: "1.5 days ago", expected: now.Add(-36 * time.Hour), ok: true},
+		{value: "1 week ago", expected: now.Add(-7 * 24 * time.Hour), ok: true},
+		{value: "1h30m ago", expected: now.Add(-90 * time.Minute), ok: true},
+		{value: "42m"},
+		{value: "2013-01-02T13:23:37Z"},
+		{value: "2 fortnights ago"},
+		{value: "ago"},
+		{value: ""},
+	}
+	for _, tc := range testcases {
+		actual, ok := parseRelativeTime(tc.value, now)
+		assert.Check(t, is.Equal(ok, tc.ok), tc.value)
+		if tc.ok {
+			assert.Check(t, is.Equal(actual, tc.expected), tc.value)
+		}
+	}
+}
+
+func TestTailBuffer(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	b := &tailBuffer{max: 12}
+	_, _ = b.writer(false).Write([]byte("line 1\n"))
+	_, _ = b.writer(true).Write([]byte("line 2\n"))
+	_, _ = b.writer(false).Write([]byte("line 3\n"))
+	assert.NilError(t, b.flush(&stdout, &stderr))
+	// "ne 2\nline 3\n" is kept, and the partial line is dropped.
+	assert.Check(t, is.Equal(stdout.String(), "line 3\n"))
+	assert.Check(t, is.Equal(stderr.String(), ""))
+
+	stdout.Reset()
+	b = &tailBuffer{max: 100}
+	_, _ = b.writer(false).Write([]byte("line 1\n"))
+	_, _ = b.writer(true).Write([]byte("line 2\n"))
+	assert.NilError(t, b.flush(&stdout, &stderr))
+	assert.Check(t, is.Equal(stdout.String(), "line 1\n"))
+	assert.Check(t, is.Equal(stderr.String(), "line 2\n"))
+}
+
+func TestRunLogsTailBytes(t *testing.T) {
+	var requests []container.LogsOptions
+	cli := test.NewFakeCli(&fakeClient{
+		inspectFunc: func(containerID string) (container.InspectResponse, error) {
+			return container.InspectResponse{
+				Config:            &container.Config{Tty: true},
+				ContainerJSONBase: &container.ContainerJSONBase{ID: containerID, State: &container.State{}},
+			}, nil
+		},
+		logFunc: func(_ string, options container.LogsOptions) (io.ReadCloser, error) {
+			requests = append(requests, options)
+			if options.Follow {
+				return io.NopCloser(strings.NewReader("new line\n")), nil
+			}
+			return io.NopCloser(strings.NewReader(strings.Repeat("0123456789abcdef\n", 200))), nil
+		},
+	})
+	cmd := NewLogsCommand(cli)
+	cmd.SetArgs([]string{"--tail-bytes", "1kb", "--follow", "--since", "1 hour ago", "foo"})
+	assert.NilError(t, cmd.Execute())
+	// 1024 bytes are kept, minus the first partial line.
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), strings.Repeat("0123456789abcdef\n", 60)+"new line\n"))
+
+	assert.Assert(t, is.Len(requests, 2))
+	assert.Check(t, is.Equal(requests[0].Tail, "all"))
+	assert.Check(t, !requests[0].Follow)
+	assert.Check(t, requests[0].Until != "")
+	assert.Check(t, requests[0].Since != "1 hour ago")
+	assert.Check(t, requests[1].Follow)
+	assert.Check(t, is.Equal(requests[1].Since, requests[0].Until))
+}
+
+func TestRunLogsTailConflict(t *testing.T) {
+	cmd := NewLogsCommand(test.NewFakeCli(&fakeClient{}))
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--tail", "10", "--tail-bytes", "1kb", "foo"})
+	assert.Check(t, is.Error(cmd.Execute(), "conflicting options: cannot specify both --tail and --tail-bytes"))
+}
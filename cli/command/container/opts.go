@@ -73,6 +73,7 @@ type containerOptions struct {
 	extraHosts          opts.ListOpts
 	volumesFrom         opts.ListOpts
 	envFile             opts.ListOpts
+	envFileFormat       string
 	capAdd              opts.ListOpts
 	capDrop             opts.ListOpts
 	groupAdd            opts.ListOpts
@@ -180,7 +181,7 @@ func addFlags(flags *pflag.FlagSet) *containerOptions {
 		ulimits:           opts.NewUlimitOpt(nil),
 		volumes:           opts.NewListOpts(nil),
 		volumesFrom:       opts.NewListOpts(nil),
-		annotations:       opts.NewMapOpts(nil, nil),
+		annotations:       opts.NewMapOpts(nil, opts.ValidateAnnotation),
 	}
 
 	// General purpose flags
@@ -191,6 +192,7 @@ func addFlags(flags *pflag.FlagSet) *containerOptions {
 	flags.SetAnnotation("gpus", "version", []string{"1.40"})
 	flags.VarP(&copts.env, "env", "e", "Set environment variables")
 	flags.Var(&copts.envFile, "env-file", "Read in a file of environment variables")
+	flags.StringVar(&copts.envFileFormat, "env-file-format", opts.EnvFileFormatV1, `Format of the env-files ("v1", "v2" for compose-compatible files)`)
 	flags.StringVar(&copts.entrypoint, "entrypoint", "", "Overwrite the default ENTRYPOINT of the image")
 	flags.Var(&copts.groupAdd, "group-add", "Add additional groups to join")
 	flags.StringVarP(&copts.hostname, "hostname", "h", "", "Container host name")
@@ -493,7 +495,7 @@ func parse(flags *pflag.FlagSet, copts *containerOptions, serverOS string) (*con
 	}
 
 	// collect all the environment variables for the container
-	envVariables, err := opts.ReadKVEnvStrings(copts.envFile.GetAll(), copts.env.GetAll())
+	envVariables, err := opts.ReadKVEnvStringsWithFormat(copts.envFileFormat, copts.envFile.GetAll(), copts.env.GetAll())
 	if err != nil {
 		return nil, err
 	}
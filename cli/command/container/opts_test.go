@@ -904,6 +904,15 @@ func TestParseEnvfileVariables(t *testing.T) {
 	}
 }
 
+func TestParseEnvfileVariablesV2(t *testing.T) {
+	config, _, _, err := parseRun([]string{"--env-file=testdata/valid-v2.env", "--env-file-format=v2", "img", "cmd"})
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(config.Env, []string{"ENV1=value1", "ENV2=multi\nline", "ENV3=value1-suffix"}))
+
+	_, _, _, err = parseRun([]string{"--env-file=testdata/valid-v2.env", "--env-file-format=v3", "img", "cmd"})
+	assert.Check(t, is.Error(err, `invalid env-file format "v3": must be "v1" or "v2"`))
+}
+
 func TestParseEnvfileVariablesWithBOMUnicode(t *testing.T) {
 	// UTF8 with BOM
 	config, _, _, err := parseRun([]string{"--env-file=testdata/utf8.env", "img", "cmd"})
@@ -931,6 +940,26 @@ func TestParseEnvfileVariablesWithBOMUnicode(t *testing.T) {
 	}
 }
 
+func TestParseAnnotations(t *testing.T) {
+	_, hostConfig, _, err := parseRun([]string{
+		"--annotation", "com.example.runtime=kata",
+		"--annotation", "io.kubernetes.cri.container-type=sandbox",
+		"--annotation", "com.example.empty=",
+		"img", "cmd",
+	})
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(hostConfig.Annotations, map[string]string{
+		"com.example.runtime":              "kata",
+		"io.kubernetes.cri.container-type": "sandbox",
+		"com.example.empty":                "",
+	}))
+
+	// A key without a value sets an annotation with an empty value.
+	_, hostConfig, _, err = parseRun([]string{"--annotation", "com.example.runtime", "img", "cmd"})
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(hostConfig.Annotations, map[string]string{"com.example.runtime": ""}))
+}
+
 func TestParseLabelfileVariables(t *testing.T) {
 	e := "open nonexistent: no such file or directory"
 	if runtime.GOOS == "windows" {
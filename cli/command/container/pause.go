@@ -5,26 +5,29 @@
 	"fmt"
 	"strings"
 
-	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types/container"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 type pauseOptions struct {
+	all    bool
+	filter opts.FilterOpt
+
 	containers []string
 }
 
 // NewPauseCommand creates a new cobra.Command for `docker pause`
 func NewPauseCommand(dockerCli command.Cli) *cobra.Command {
-	var opts pauseOptions
+	opts := pauseOptions{filter: opts.NewFilterOpt()}
 
-	return &cobra.Command{
-		Use:   "pause CONTAINER [CONTAINER...]",
+	cmd := &cobra.Command{
+		Use:   "pause [OPTIONS] CONTAINER [CONTAINER...]",
 		Short: "Pause all processes within one or more containers",
-		Args:  cli.RequiresMinArgs(1),
+		Args:  requiresContainersFilterOrAll(&opts.filter, &opts.all),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.containers = args
 			return runPause(cmd.Context(), dockerCli, &opts)
@@ -36,9 +39,21 @@ func NewPauseCommand(dockerCli command.Cli) *cobra.Command {
 			return ctr.State != "paused"
 		}),
 	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.all, "all", "a", false, "Pause all running containers (matching the filter, if any)")
+	flags.Var(&opts.filter, "filter", `Pause all running containers matching the filter (e.g. "label=project=foo")`)
+
+	return cmd
 }
 
 func runPause(ctx context.Context, dockerCli command.Cli, opts *pauseOptions) error {
+	containers, err := containersWithStatus(ctx, dockerCli.Client(), opts.containers, opts.filter.Value(), opts.all, "running")
+	if err != nil {
+		return err
+	}
+	opts.containers = containers
+
 	var errs []string
 	errChan := parallelOperation(ctx, opts.containers, dockerCli.Client().ContainerPause)
 	for _, ctr := range opts.containers {
@@ -0,0 +1,83 @@
+package container
+
+import (
+	"io"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/container"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestPauseFilter(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		paused []string
+	)
+	cli := test.NewFakeCli(&fakeClient{
+		containerListFunc: func(options container.ListOptions) ([]container.Summary, error) {
+			assert.Check(t, is.DeepEqual(options.Filters.Get("label"), []string{"project=foo"}))
+			assert.Check(t, is.DeepEqual(options.Filters.Get("status"), []string{"running"}))
+			return []container.Summary{
+				{ID: "id-1", Names: []string{"/one"}},
+				{ID: "id-2", Names: []string{"/two"}},
+			}, nil
+		},
+		containerPauseFunc: func(containerID string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			paused = append(paused, containerID)
+			return nil
+		},
+	})
+	cmd := NewPauseCommand(cli)
+	cmd.SetArgs([]string{"--filter", "label=project=foo"})
+	assert.NilError(t, cmd.Execute())
+	sort.Strings(paused)
+	assert.Check(t, is.DeepEqual(paused, []string{"one", "two"}))
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "one\ntwo\n"))
+}
+
+func TestUnpauseAll(t *testing.T) {
+	var unpaused []string
+	cli := test.NewFakeCli(&fakeClient{
+		containerListFunc: func(options container.ListOptions) ([]container.Summary, error) {
+			assert.Check(t, is.DeepEqual(options.Filters.Get("status"), []string{"paused"}))
+			return []container.Summary{{ID: "id-1", Names: []string{"/one"}}}, nil
+		},
+		containerUnpauseFunc: func(containerID string) error {
+			unpaused = append(unpaused, containerID)
+			return nil
+		},
+	})
+	cmd := NewUnpauseCommand(cli)
+	cmd.SetArgs([]string{"--all"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.DeepEqual(unpaused, []string{"one"}))
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "one\n"))
+}
+
+func TestPauseAllNoMatch(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		containerListFunc: func(container.ListOptions) ([]container.Summary, error) {
+			return nil, nil
+		},
+	})
+	cmd := NewPauseCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--all"})
+	assert.Check(t, is.Error(cmd.Execute(), "no containers match the given filter"))
+}
+
+func TestPauseAllWithContainers(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{})
+	cmd := NewPauseCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--all", "foo"})
+	assert.Check(t, is.Error(cmd.Execute(), "conflicting options: cannot specify both --all and container names"))
+}
@@ -11,6 +11,8 @@
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/cli/command/formatter/tabwriter"
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/go-connections/nat"
 	"github.com/fvbommel/sortorder"
 	"github.com/pkg/errors"
@@ -21,6 +23,7 @@ type portOptions struct {
 	container string
 
 	port string
+	host string
 }
 
 // NewPortCommand creates a new cobra.Command for `docker port`
@@ -28,10 +31,18 @@ func NewPortCommand(dockerCli command.Cli) *cobra.Command {
 	var opts portOptions
 
 	cmd := &cobra.Command{
-		Use:   "port CONTAINER [PRIVATE_PORT[/PROTO]]",
+		Use:   "port [OPTIONS] CONTAINER [PRIVATE_PORT[/PROTO]]",
 		Short: "List port mappings or a specific mapping for the container",
-		Args:  cli.RequiresRangeArgs(1, 2),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.host != "" {
+				return cli.NoArgs(cmd, args)
+			}
+			return cli.RequiresRangeArgs(1, 2)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.host != "" {
+				return runPortHost(cmd.Context(), dockerCli, opts.host)
+			}
 			opts.container = args[0]
 			if len(args) > 1 {
 				opts.port = args[1]
@@ -43,6 +54,10 @@ func NewPortCommand(dockerCli command.Cli) *cobra.Command {
 		},
 		ValidArgsFunction: completion.ContainerNames(dockerCli, false),
 	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.host, "host", "", "Show the running containers publishing the given host port (PORT[/PROTO])")
+
 	return cmd
 }
 
@@ -91,3 +106,49 @@ func runPort(ctx context.Context, dockerCli command.Cli, opts *portOptions) erro
 
 	return nil
 }
+
+// runPortHost shows the running containers that publish the given host port,
+// and the bindings of that port. If no protocol is specified, bindings of any
+// protocol are shown.
+func runPortHost(ctx context.Context, dockerCli command.Cli, hostPort string) error {
+	port, proto, _ := strings.Cut(hostPort, "/")
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return errors.Wrapf(err, "Error: invalid port (%s)", port)
+	}
+
+	containers, err := dockerCli.Client().ContainerList(ctx, container.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	var out []string
+	for _, c := range containers {
+		name := c.ID
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		for _, binding := range c.Ports {
+			if binding.PublicPort != uint16(p) || (proto != "" && binding.Type != proto) {
+				continue
+			}
+			hostIP := binding.IP
+			if hostIP == "" {
+				hostIP = "0.0.0.0"
+			}
+			out = append(out, fmt.Sprintf("%s\t%s -> %d/%s", name, net.JoinHostPort(hostIP, port), binding.PrivatePort, binding.Type))
+		}
+	}
+	if len(out) == 0 {
+		return errors.Errorf("Error: No running container publishes host port '%s'", hostPort)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return sortorder.NaturalLess(out[i], out[j])
+	})
+	w := tabwriter.NewWriter(dockerCli.Out(), 10, 1, 3, ' ', 0)
+	for _, line := range out {
+		_, _ = fmt.Fprintln(w, line)
+	}
+	return w.Flush()
+}
@@ -8,6 +8,7 @@
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/go-connections/nat"
 	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
 	"gotest.tools/v3/golden"
 )
 
@@ -76,3 +77,46 @@ func TestNewPortCommandOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestPortHost(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		containerListFunc: func(options container.ListOptions) ([]container.Summary, error) {
+			assert.Check(t, !options.All)
+			return []container.Summary{
+				{
+					ID:    "id-web",
+					Names: []string{"/web"},
+					Ports: []container.Port{
+						{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+						{IP: "::", PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+						{IP: "0.0.0.0", PrivatePort: 443, PublicPort: 8443, Type: "tcp"},
+					},
+				},
+				{
+					ID:    "id-dns",
+					Names: []string{"/dns"},
+					Ports: []container.Port{
+						{IP: "127.0.0.1", PrivatePort: 53, PublicPort: 8080, Type: "udp"},
+					},
+				},
+			}, nil
+		},
+	})
+
+	cmd := NewPortCommand(cli)
+	cmd.SetArgs([]string{"--host", "8080"})
+	assert.NilError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "container-port-host.golden")
+
+	cli.OutBuffer().Reset()
+	cmd = NewPortCommand(cli)
+	cmd.SetArgs([]string{"--host", "8080/udp"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "dns       127.0.0.1:8080 -> 53/udp\n"))
+
+	cmd = NewPortCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--host", "9090"})
+	assert.Check(t, is.Error(cmd.Execute(), "Error: No running container publishes host port '9090'"))
+}
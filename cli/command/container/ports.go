@@ -0,0 +1,146 @@
+package container
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/cli/command/formatter"
+	flagsHelper "github.com/docker/cli/cli/flags"
+	"github.com/docker/cli/opts"
+	"github.com/docker/docker/api/types/container"
+	"github.com/fvbommel/sortorder"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type portsOptions struct {
+	filter opts.FilterOpt
+	sort   string
+	format string
+}
+
+// publishedPort is a port of a container that is published on the host.
+type publishedPort struct {
+	Container     string
+	HostIP        string
+	HostPort      uint16
+	ContainerPort uint16
+	Protocol      string
+}
+
+// portsSortKeys are the columns that the output of "docker container ports"
+// can be sorted by, and the functions comparing two ports by that column.
+var portsSortKeys = map[string]func(a, b publishedPort) bool{
+	"container": func(a, b publishedPort) bool {
+		return sortorder.NaturalLess(a.Container, b.Container)
+	},
+	"host-ip": func(a, b publishedPort) bool {
+		return a.HostIP < b.HostIP
+	},
+	"host-port": func(a, b publishedPort) bool {
+		return a.HostPort < b.HostPort
+	},
+	"container-port": func(a, b publishedPort) bool {
+		return a.ContainerPort < b.ContainerPort
+	},
+	"protocol": func(a, b publishedPort) bool {
+		return a.Protocol < b.Protocol
+	},
+}
+
+// NewPortsCommand creates a new cobra.Command for `docker container ports`
+func NewPortsCommand(dockerCli command.Cli) *cobra.Command {
+	options := portsOptions{filter: opts.NewFilterOpt()}
+
+	cmd := &cobra.Command{
+		Use:   "ports [OPTIONS]",
+		Short: "List the ports published by all running containers",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPorts(cmd.Context(), dockerCli, &options)
+		},
+		ValidArgsFunction: completion.NoComplete,
+	}
+
+	flags := cmd.Flags()
+	flags.Var(&options.filter, "filter", `Only show the ports of containers matching the filter (e.g. "label=project=foo")`)
+	flags.StringVar(&options.sort, "sort", "host-port", "Sort by column (container, host-ip, host-port, container-port, protocol)")
+	flags.StringVar(&options.format, "format", "", flagsHelper.FormatHelp)
+
+	_ = cmd.RegisterFlagCompletionFunc("sort", completion.FromList("container", "host-ip", "host-port", "container-port", "protocol"))
+
+	return cmd
+}
+
+func runPorts(ctx context.Context, dockerCli command.Cli, options *portsOptions) error {
+	less, ok := portsSortKeys[options.sort]
+	if !ok {
+		return errors.Errorf("invalid sort column: %s", options.sort)
+	}
+
+	containers, err := dockerCli.Client().ContainerList(ctx, container.ListOptions{Filters: options.filter.Value()})
+	if err != nil {
+		return err
+	}
+
+	ports := publishedPorts(containers)
+	sort.SliceStable(ports, func(i, j int) bool {
+		return less(ports[i], ports[j])
+	})
+
+	format := options.format
+	if format == "" {
+		format = formatter.TableFormatKey
+	}
+	portsCtx := formatter.Context{
+		Output: dockerCli.Out(),
+		Format: NewPortsFormat(format),
+	}
+	return portsFormatWrite(portsCtx, ports)
+}
+
+// publishedPorts returns the ports that the containers publish on the host,
+// sorted by host port, host IP, protocol and container.
+func publishedPorts(containers []container.Summary) []publishedPort {
+	var ports []publishedPort
+	for _, c := range containers {
+		name := c.ID
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		for _, p := range c.Ports {
+			if p.PublicPort == 0 {
+				continue
+			}
+			hostIP := p.IP
+			if hostIP == "" {
+				hostIP = "0.0.0.0"
+			}
+			ports = append(ports, publishedPort{
+				Container:     name,
+				HostIP:        hostIP,
+				HostPort:      p.PublicPort,
+				ContainerPort: p.PrivatePort,
+				Protocol:      p.Type,
+			})
+		}
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		a, b := ports[i], ports[j]
+		switch {
+		case a.HostPort != b.HostPort:
+			return a.HostPort < b.HostPort
+		case a.HostIP != b.HostIP:
+			return a.HostIP < b.HostIP
+		case a.Protocol != b.Protocol:
+			return a.Protocol < b.Protocol
+		default:
+			return sortorder.NaturalLess(a.Container, b.Container)
+		}
+	})
+	return ports
+}
@@ -0,0 +1,87 @@
+package container
+
+import (
+	"io"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/container"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/golden"
+)
+
+func TestContainerPorts(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		containerListFunc: func(options container.ListOptions) ([]container.Summary, error) {
+			assert.Check(t, is.DeepEqual(options.Filters.Get("label"), []string{"project=foo"}))
+			return []container.Summary{
+				{
+					ID:    "id-web",
+					Names: []string{"/web"},
+					Ports: []container.Port{
+						{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+						{IP: "::", PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+						{IP: "0.0.0.0", PrivatePort: 443, PublicPort: 8443, Type: "tcp"},
+						{PrivatePort: 9000, Type: "tcp"},
+					},
+				},
+				{
+					ID:    "id-dns",
+					Names: []string{"/dns"},
+					Ports: []container.Port{
+						{IP: "127.0.0.1", PrivatePort: 53, PublicPort: 5353, Type: "udp"},
+					},
+				},
+				{
+					ID:    "id-worker",
+					Names: []string{"/worker"},
+				},
+			}, nil
+		},
+	})
+
+	cmd := NewPortsCommand(cli)
+	cmd.SetArgs([]string{"--filter", "label=project=foo"})
+	assert.NilError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "container-ports.golden")
+
+	cli.OutBuffer().Reset()
+	cmd = NewPortsCommand(cli)
+	cmd.SetArgs([]string{"--filter", "label=project=foo", "--sort", "protocol"})
+	assert.NilError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "container-ports-sort-protocol.golden")
+}
+
+func TestContainerPortsFormat(t *testing.T) {
+	fakeCLI := test.NewFakeCli(&fakeClient{
+		containerListFunc: func(container.ListOptions) ([]container.Summary, error) {
+			return []container.Summary{
+				{
+					ID:    "id-web",
+					Names: []string{"/web"},
+					Ports: []container.Port{{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080, Type: "tcp"}},
+				},
+			}, nil
+		},
+	})
+
+	cmd := NewPortsCommand(fakeCLI)
+	cmd.SetArgs([]string{"--format", "{{.Container}}:{{.HostPort}}->{{.ContainerPort}}/{{.Protocol}}"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(fakeCLI.OutBuffer().String(), "web:8080->80/tcp\n"))
+
+	fakeCLI.OutBuffer().Reset()
+	cmd = NewPortsCommand(fakeCLI)
+	cmd.SetArgs([]string{"--format", "json"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(fakeCLI.OutBuffer().String(), `{"Container":"web","ContainerPort":"80","HostIP":"0.0.0.0","HostPort":"8080","Protocol":"tcp"}`+"\n"))
+}
+
+func TestContainerPortsInvalidSort(t *testing.T) {
+	cmd := NewPortsCommand(test.NewFakeCli(&fakeClient{}))
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--sort", "name"})
+	assert.Check(t, is.Error(cmd.Execute(), "invalid sort column: name"))
+}
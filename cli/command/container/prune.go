@@ -3,11 +3,15 @@
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
 	"github.com/docker/cli/opts"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	timetypes "github.com/docker/docker/api/types/time"
 	"github.com/docker/docker/errdefs"
 	units "github.com/docker/go-units"
 	"github.com/pkg/errors"
@@ -16,6 +20,7 @@
 
 type pruneOptions struct {
 	force  bool
+	dryRun bool
 	filter opts.FilterOpt
 }
 
@@ -35,7 +40,11 @@ func NewPruneCommand(dockerCli command.Cli) *cobra.Command {
 			if output != "" {
 				fmt.Fprintln(dockerCli.Out(), output)
 			}
-			fmt.Fprintln(dockerCli.Out(), "Total reclaimed space:", units.HumanSize(float64(spaceReclaimed)))
+			if options.dryRun {
+				fmt.Fprintln(dockerCli.Out(), "Total reclaimable space:", units.HumanSize(float64(spaceReclaimed)))
+			} else {
+				fmt.Fprintln(dockerCli.Out(), "Total reclaimed space:", units.HumanSize(float64(spaceReclaimed)))
+			}
 			return nil
 		},
 		Annotations:       map[string]string{"version": "1.25"},
@@ -45,6 +54,7 @@ func NewPruneCommand(dockerCli command.Cli) *cobra.Command {
 	flags := cmd.Flags()
 	flags.BoolVarP(&options.force, "force", "f", false, "Do not prompt for confirmation")
 	flags.Var(&options.filter, "filter", `Provide filter values (e.g. "until=<timestamp>")`)
+	flags.BoolVar(&options.dryRun, "dry-run", false, "Show the containers that would be removed, without removing them")
 
 	return cmd
 }
@@ -55,6 +65,10 @@ func NewPruneCommand(dockerCli command.Cli) *cobra.Command {
 func runPrune(ctx context.Context, dockerCli command.Cli, options pruneOptions) (spaceReclaimed uint64, output string, err error) {
 	pruneFilters := command.PruneFilters(dockerCli, options.filter.Value())
 
+	if options.dryRun {
+		return dryRunPrune(ctx, dockerCli, pruneFilters)
+	}
+
 	if !options.force {
 		r, err := command.PromptForConfirmation(ctx, dockerCli.In(), dockerCli.Out(), warning)
 		if err != nil {
@@ -81,6 +95,62 @@ func runPrune(ctx context.Context, dockerCli command.Cli, options pruneOptions)
 	return spaceReclaimed, output, nil
 }
 
+// dryRunPrune lists the containers that would be removed by a prune with the
+// given filters. The selection mirrors the one of the daemon: containers that
+// are not running, created before the "until" filter, and matching the "label"
+// and "label!" filters.
+func dryRunPrune(ctx context.Context, dockerCli command.Cli, pruneFilters filters.Args) (spaceReclaimable uint64, output string, err error) {
+	if err := pruneFilters.Validate(map[string]bool{"until": true, "label": true, "label!": true}); err != nil {
+		return 0, "", err
+	}
+	var until time.Time
+	if u := pruneFilters.Get("until"); len(u) > 0 {
+		if len(u) > 1 {
+			return 0, "", errdefs.InvalidParameter(errors.New("more than one until filter specified"))
+		}
+		ts, err := timetypes.GetTimestamp(u[0], time.Now())
+		if err != nil {
+			return 0, "", errdefs.InvalidParameter(err)
+		}
+		seconds, nanoseconds, err := timetypes.ParseTimestamps(ts, 0)
+		if err != nil {
+			return 0, "", errdefs.InvalidParameter(err)
+		}
+		until = time.Unix(seconds, nanoseconds)
+	}
+
+	containers, err := dockerCli.Client().ContainerList(ctx, container.ListOptions{
+		All:  true,
+		Size: true,
+		Filters: filters.NewArgs(
+			filters.Arg("status", "created"),
+			filters.Arg("status", "exited"),
+			filters.Arg("status", "dead"),
+		),
+	})
+	if err != nil {
+		return 0, "", err
+	}
+
+	for _, c := range containers {
+		if !until.IsZero() && time.Unix(c.Created, 0).After(until) {
+			continue
+		}
+		if !pruneFilters.MatchKVList("label", c.Labels) {
+			continue
+		}
+		if pruneFilters.Contains("label!") && pruneFilters.MatchKVList("label!", c.Labels) {
+			continue
+		}
+		if output == "" {
+			output = "Would delete Containers:\n"
+		}
+		output += c.ID + "\n"
+		spaceReclaimable += uint64(c.SizeRw)
+	}
+	return spaceReclaimable, output, nil
+}
+
 // RunPrune calls the Container Prune API
 // This returns the amount of space reclaimed and a detailed output string
 func RunPrune(ctx context.Context, dockerCli command.Cli, _ bool, filter opts.FilterOpt) (uint64, string, error) {
@@ -3,12 +3,16 @@
 import (
 	"context"
 	"io"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/docker/cli/internal/test"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/pkg/errors"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
 )
 
 func TestContainerPrunePromptTermination(t *testing.T) {
@@ -25,3 +29,28 @@ func TestContainerPrunePromptTermination(t *testing.T) {
 	cmd.SetErr(io.Discard)
 	test.TerminatePrompt(ctx, t, cmd, cli)
 }
+
+func TestContainerPruneDryRun(t *testing.T) {
+	now := time.Now()
+	cli := test.NewFakeCli(&fakeClient{
+		containerPruneFunc: func(ctx context.Context, pruneFilters filters.Args) (container.PruneReport, error) {
+			return container.PruneReport{}, errors.New("fakeClient containerPruneFunc should not be called")
+		},
+		containerListFunc: func(options container.ListOptions) ([]container.Summary, error) {
+			assert.Check(t, options.All)
+			assert.Check(t, options.Size)
+			status := options.Filters.Get("status")
+			sort.Strings(status)
+			assert.Check(t, is.DeepEqual(status, []string{"created", "dead", "exited"}))
+			return []container.Summary{
+				{ID: "old", Created: now.Add(-48 * time.Hour).Unix(), SizeRw: 1000},
+				{ID: "recent", Created: now.Unix(), SizeRw: 2000},
+				{ID: "kept", Created: now.Add(-48 * time.Hour).Unix(), SizeRw: 4000, Labels: map[string]string{"keep": "true"}},
+			}, nil
+		},
+	})
+	cmd := NewPruneCommand(cli)
+	cmd.SetArgs([]string{"--dry-run", "--filter", "until=24h", "--filter", "label!=keep"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "Would delete Containers:\nold\n\nTotal reclaimable space: 1kB\n"))
+}
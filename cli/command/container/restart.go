@@ -4,10 +4,11 @@
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
-	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types/container"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -17,18 +18,21 @@ type restartOptions struct {
 	signal         string
 	timeout        int
 	timeoutChanged bool
+	waitHealthy    bool
+	waitTimeout    time.Duration
 
+	filter     opts.FilterOpt
 	containers []string
 }
 
 // NewRestartCommand creates a new cobra.Command for `docker restart`
 func NewRestartCommand(dockerCli command.Cli) *cobra.Command {
-	var opts restartOptions
+	opts := restartOptions{filter: opts.NewFilterOpt()}
 
 	cmd := &cobra.Command{
 		Use:   "restart [OPTIONS] CONTAINER [CONTAINER...]",
 		Short: "Restart one or more containers",
-		Args:  cli.RequiresMinArgs(1),
+		Args:  requiresContainersOrFilter(&opts.filter),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.containers = args
 			opts.timeoutChanged = cmd.Flags().Changed("time")
@@ -43,6 +47,9 @@ func NewRestartCommand(dockerCli command.Cli) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVarP(&opts.signal, "signal", "s", "", "Signal to send to the container")
 	flags.IntVarP(&opts.timeout, "time", "t", 0, "Seconds to wait before killing the container")
+	flags.BoolVar(&opts.waitHealthy, "wait-healthy", false, "Wait for each container to be healthy before restarting the next one")
+	flags.DurationVar(&opts.waitTimeout, "wait-timeout", 0, "Maximum time to wait for each container to be healthy (0 to wait indefinitely)")
+	flags.Var(&opts.filter, "filter", `Restart all containers matching the filter (e.g. "label=project=foo")`)
 
 	_ = cmd.RegisterFlagCompletionFunc("signal", completeSignals)
 
@@ -50,6 +57,16 @@ func NewRestartCommand(dockerCli command.Cli) *cobra.Command {
 }
 
 func runRestart(ctx context.Context, dockerCli command.Cli, opts *restartOptions) error {
+	if opts.waitTimeout != 0 && !opts.waitHealthy {
+		return errors.New("--wait-timeout can only be used with --wait-healthy")
+	}
+
+	containers, err := containersWithFilter(ctx, dockerCli.Client(), opts.containers, opts.filter.Value(), true)
+	if err != nil {
+		return err
+	}
+	opts.containers = containers
+
 	var errs []string
 	var timeout *int
 	if opts.timeoutChanged {
@@ -64,6 +81,14 @@ func runRestart(ctx context.Context, dockerCli command.Cli, opts *restartOptions
 			errs = append(errs, err.Error())
 			continue
 		}
+		if opts.waitHealthy {
+			if err := waitRestartedHealthy(ctx, dockerCli, name, opts.waitTimeout); err != nil {
+				// Stop here, to not restart the other containers while this one
+				// is not healthy.
+				errs = append(errs, err.Error())
+				break
+			}
+		}
 		_, _ = fmt.Fprintln(dockerCli.Out(), name)
 	}
 	if len(errs) > 0 {
@@ -71,3 +96,27 @@ func runRestart(ctx context.Context, dockerCli command.Cli, opts *restartOptions
 	}
 	return nil
 }
+
+// waitRestartedHealthy waits for a restarted container to be healthy. Containers
+// without a health check are considered healthy once restarted.
+func waitRestartedHealthy(ctx context.Context, dockerCli command.Cli, ctr string, timeout time.Duration) error {
+	c, err := dockerCli.Client().ContainerInspect(ctx, ctr)
+	if err != nil {
+		return err
+	}
+	if c.State == nil || c.State.Health == nil {
+		return nil
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if err := waitContainerHealthy(ctx, dockerCli, ctr); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return errors.Errorf("timed out after %s waiting for container %s to be healthy", timeout, ctr)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package container
+
+import (
+	"io"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestRestartWaitHealthy(t *testing.T) {
+	var calls []string
+	health := map[string]string{}
+	cli := test.NewFakeCli(&fakeClient{
+		containerRestartFunc: func(containerID string, _ container.StopOptions) error {
+			calls = append(calls, "restart "+containerID)
+			health[containerID] = container.Starting
+			return nil
+		},
+		inspectFunc: func(containerID string) (container.InspectResponse, error) {
+			calls = append(calls, "inspect "+containerID)
+			state := &container.State{Running: true}
+			if containerID != "no-healthcheck" {
+				state.Health = &container.Health{Status: health[containerID]}
+			}
+			return container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{ID: containerID, State: state},
+			}, nil
+		},
+		eventsFunc: func(options events.ListOptions) (<-chan events.Message, <-chan error) {
+			containerID := options.Filters.Get("container")[0]
+			calls = append(calls, "events "+containerID)
+			action := events.ActionHealthStatusHealthy
+			if containerID == "broken" {
+				action = events.ActionDie
+			}
+			eventC := make(chan events.Message, 1)
+			eventC <- events.Message{Action: action}
+			return eventC, make(chan error)
+		},
+	})
+	cmd := NewRestartCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--wait-healthy", "web", "no-healthcheck", "broken", "db"})
+	assert.Check(t, is.Error(cmd.Execute(), "container broken stopped before becoming healthy"))
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "web\nno-healthcheck\n"))
+	assert.Check(t, is.DeepEqual(calls, []string{
+		"restart web", "inspect web", "inspect web", "events web",
+		"restart no-healthcheck", "inspect no-healthcheck",
+		"restart broken", "inspect broken", "inspect broken", "events broken",
+	}))
+}
+
+func TestRestartWaitTimeoutWithoutWaitHealthy(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{})
+	cmd := NewRestartCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--wait-timeout", "1m", "web"})
+	assert.Check(t, is.Error(cmd.Execute(), "--wait-timeout can only be used with --wait-healthy"))
+}
@@ -4,12 +4,15 @@
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
-	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/cli/command/formatter/tabwriter"
+	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/errdefs"
+	units "github.com/docker/go-units"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -18,19 +21,23 @@ type rmOptions struct {
 	rmVolumes bool
 	rmLink    bool
 	force     bool
+	yes       bool
 
+	ignoreMissing bool
+
+	filter     opts.FilterOpt
 	containers []string
 }
 
 // NewRmCommand creates a new cobra.Command for `docker rm`
 func NewRmCommand(dockerCli command.Cli) *cobra.Command {
-	var opts rmOptions
+	opts := rmOptions{filter: opts.NewFilterOpt()}
 
 	cmd := &cobra.Command{
 		Use:     "rm [OPTIONS] CONTAINER [CONTAINER...]",
 		Aliases: []string{"remove"},
 		Short:   "Remove one or more containers",
-		Args:    cli.RequiresMinArgs(1),
+		Args:    requiresContainersOrFilter(&opts.filter),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.containers = args
 			return runRm(cmd.Context(), dockerCli, &opts)
@@ -45,10 +52,25 @@ func NewRmCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVarP(&opts.rmVolumes, "volumes", "v", false, "Remove anonymous volumes associated with the container")
 	flags.BoolVarP(&opts.rmLink, "link", "l", false, "Remove the specified link")
 	flags.BoolVarP(&opts.force, "force", "f", false, "Force the removal of a running container (uses SIGKILL)")
+	flags.BoolVarP(&opts.yes, "yes", "y", false, "Do not prompt for confirmation when using --force on running containers")
+	flags.BoolVar(&opts.ignoreMissing, "ignore-missing", false, "Do not fail if a container does not exist")
+	flags.Var(&opts.filter, "filter", `Remove all containers matching the filter (e.g. "label=project=foo")`)
 	return cmd
 }
 
 func runRm(ctx context.Context, dockerCli command.Cli, opts *rmOptions) error {
+	containers, err := containersWithFilter(ctx, dockerCli.Client(), opts.containers, opts.filter.Value(), true)
+	if err != nil {
+		return err
+	}
+	opts.containers = containers
+
+	if opts.force && !opts.yes && dockerCli.In().IsTerminal() {
+		if err := confirmRemoveRunning(ctx, dockerCli, opts.containers); err != nil {
+			return err
+		}
+	}
+
 	var errs []string
 	errChan := parallelOperation(ctx, opts.containers, func(ctx context.Context, ctrID string) error {
 		ctrID = strings.Trim(ctrID, "/")
@@ -64,6 +86,9 @@ func runRm(ctx context.Context, dockerCli command.Cli, opts *rmOptions) error {
 
 	for _, name := range opts.containers {
 		if err := <-errChan; err != nil {
+			if opts.ignoreMissing && errdefs.IsNotFound(err) {
+				continue
+			}
 			if opts.force && errdefs.IsNotFound(err) {
 				fmt.Fprintln(dockerCli.Err(), err)
 				continue
@@ -78,3 +103,41 @@ func runRm(ctx context.Context, dockerCli command.Cli, opts *rmOptions) error {
 	}
 	return nil
 }
+
+const rmRunningWarning = "WARNING! The following running container(s) will be killed and removed:"
+
+// confirmRemoveRunning asks the user to confirm the removal of the containers
+// that are running. Containers that can't be inspected are skipped, as the
+// error is reported when removing them.
+func confirmRemoveRunning(ctx context.Context, dockerCli command.Cli, containers []string) error {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 3, ' ', 0)
+	var running int
+	for _, name := range containers {
+		c, err := dockerCli.Client().ContainerInspect(ctx, strings.Trim(name, "/"))
+		if err != nil || c.State == nil || !c.State.Running {
+			continue
+		}
+		running++
+		uptime := "up"
+		if startedAt, err := time.Parse(time.RFC3339Nano, c.State.StartedAt); err == nil {
+			uptime = "up " + units.HumanDuration(time.Since(startedAt))
+		}
+		_, _ = fmt.Fprintf(w, "  %s\t(%s)\n", strings.TrimPrefix(c.Name, "/"), uptime)
+	}
+	if running == 0 {
+		return nil
+	}
+	_ = w.Flush()
+
+	_, _ = fmt.Fprintln(dockerCli.Out(), rmRunningWarning)
+	_, _ = fmt.Fprint(dockerCli.Out(), b.String())
+	r, err := command.PromptForConfirmation(ctx, dockerCli.In(), dockerCli.Out(), "Are you sure you want to continue?")
+	if err != nil {
+		return err
+	}
+	if !r {
+		return errdefs.Cancelled(errors.New("container removal has been cancelled"))
+	}
+	return nil
+}
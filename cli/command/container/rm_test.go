@@ -5,13 +5,17 @@
 	"errors"
 	"io"
 	"sort"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/docker/cli/cli/streams"
 	"github.com/docker/cli/internal/test"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/errdefs"
 	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
 )
 
 func TestRemoveForce(t *testing.T) {
@@ -59,3 +63,142 @@ func TestRemoveForce(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoveFilter(t *testing.T) {
+	var removed []string
+	mutex := new(sync.Mutex)
+
+	cli := test.NewFakeCli(&fakeClient{
+		containerListFunc: func(options container.ListOptions) ([]container.Summary, error) {
+			assert.Check(t, options.All)
+			assert.Check(t, is.DeepEqual(options.Filters.Get("label"), []string{"project=foo"}))
+			return []container.Summary{
+				{ID: "id-web", Names: []string{"/web"}},
+				{ID: "id-db", Names: []string{"/db"}},
+			}, nil
+		},
+		containerRemoveFunc: func(ctx context.Context, container string, options container.RemoveOptions) error {
+			mutex.Lock()
+			removed = append(removed, container)
+			mutex.Unlock()
+			return nil
+		},
+	})
+	cmd := NewRmCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--filter", "label=project=foo", "web", "other"})
+	assert.NilError(t, cmd.Execute())
+
+	sort.Strings(removed)
+	assert.Check(t, is.DeepEqual(removed, []string{"db", "other", "web"}))
+}
+
+func TestRemoveFilterNoMatch(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		containerListFunc: func(container.ListOptions) ([]container.Summary, error) {
+			return nil, nil
+		},
+	})
+	cmd := NewRmCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--filter", "label=project=foo"})
+	assert.Check(t, is.Error(cmd.Execute(), "no containers match the given filter"))
+}
+
+func TestRemoveForceRunning(t *testing.T) {
+	newClient := func(removed *[]string) *fakeClient {
+		var mu sync.Mutex
+		return &fakeClient{
+			inspectFunc: func(containerID string) (container.InspectResponse, error) {
+				if containerID == "nosuchcontainer" {
+					return container.InspectResponse{}, errdefs.NotFound(errors.New("no such container"))
+				}
+				return container.InspectResponse{
+					ContainerJSONBase: &container.ContainerJSONBase{
+						Name: "/" + containerID,
+						State: &container.State{
+							Running:   containerID == "web",
+							StartedAt: time.Now().Add(-3 * time.Hour).Format(time.RFC3339Nano),
+						},
+					},
+				}, nil
+			},
+			containerRemoveFunc: func(_ context.Context, containerID string, _ container.RemoveOptions) error {
+				mu.Lock()
+				*removed = append(*removed, containerID)
+				mu.Unlock()
+				if containerID == "nosuchcontainer" {
+					return errdefs.NotFound(errors.New("Error: no such container: " + containerID))
+				}
+				return nil
+			},
+		}
+	}
+	newInput := func(answer string) *streams.In {
+		in := streams.NewIn(io.NopCloser(strings.NewReader(answer)))
+		in.SetIsTerminal(true)
+		return in
+	}
+
+	t.Run("confirmed", func(t *testing.T) {
+		var removed []string
+		cli := test.NewFakeCli(newClient(&removed))
+		cli.SetIn(newInput("y\n"))
+		cmd := NewRmCommand(cli)
+		cmd.SetArgs([]string{"--force", "web", "stopped"})
+		assert.NilError(t, cmd.Execute())
+		assert.Check(t, is.Len(removed, 2))
+		assert.Check(t, is.Contains(cli.OutBuffer().String(), "WARNING! The following running container(s) will be killed and removed:\n  web   (up 3 hours)\n"))
+		assert.Check(t, !strings.Contains(cli.OutBuffer().String(), "  stopped"))
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		var removed []string
+		cli := test.NewFakeCli(newClient(&removed))
+		cli.SetIn(newInput("n\n"))
+		cmd := NewRmCommand(cli)
+		cmd.SetArgs([]string{"--force", "web"})
+		assert.Check(t, is.Error(cmd.Execute(), "container removal has been cancelled"))
+		assert.Check(t, is.Len(removed, 0))
+	})
+
+	t.Run("yes", func(t *testing.T) {
+		var removed []string
+		cli := test.NewFakeCli(newClient(&removed))
+		cli.SetIn(newInput(""))
+		cmd := NewRmCommand(cli)
+		cmd.SetArgs([]string{"--force", "--yes", "web"})
+		assert.NilError(t, cmd.Execute())
+		assert.Check(t, is.DeepEqual(removed, []string{"web"}))
+		assert.Check(t, is.Equal(cli.OutBuffer().String(), "web\n"))
+	})
+
+	t.Run("not running", func(t *testing.T) {
+		var removed []string
+		cli := test.NewFakeCli(newClient(&removed))
+		cli.SetIn(newInput(""))
+		cmd := NewRmCommand(cli)
+		cmd.SetArgs([]string{"--force", "stopped", "nosuchcontainer"})
+		assert.NilError(t, cmd.Execute())
+		assert.Check(t, is.Len(removed, 2))
+		assert.Check(t, is.Equal(cli.OutBuffer().String(), "stopped\n"))
+	})
+}
+
+func TestRemoveIgnoreMissing(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		containerRemoveFunc: func(_ context.Context, containerID string, _ container.RemoveOptions) error {
+			if containerID == "nosuchcontainer" {
+				return errdefs.NotFound(errors.New("Error: no such container: " + containerID))
+			}
+			return nil
+		},
+	})
+	cmd := NewRmCommand(cli)
+	cmd.SetArgs([]string{"--ignore-missing", "nosuchcontainer", "mycontainer"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "mycontainer\n"))
+	assert.Check(t, is.Equal(cli.ErrBuffer().String(), ""))
+}
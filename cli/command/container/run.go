@@ -6,12 +6,14 @@
 	"io"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
 	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
 	"github.com/moby/sys/signal"
 	"github.com/moby/term"
 	"github.com/pkg/errors"
@@ -25,6 +27,11 @@ type runOptions struct {
 	detach     bool
 	sigProxy   bool
 	detachKeys string
+	template   string
+	retries    int
+	retryDelay time.Duration
+	secrets    runSecretOpt
+	progress   string
 }
 
 // NewRunCommand create a new `docker run` command
@@ -60,6 +67,12 @@ func NewRunCommand(dockerCli command.Cli) *cobra.Command {
 	flags.StringVar(&options.detachKeys, "detach-keys", "", "Override the key sequence for detaching a container")
 	flags.StringVar(&options.pull, "pull", PullImageMissing, `Pull image before running ("`+PullImageAlways+`", "`+PullImageMissing+`", "`+PullImageNever+`")`)
 	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Suppress the pull output")
+	flags.BoolVar(&options.dryRun, "dry-run", false, "Validate the container configuration and print it without running the container")
+	flags.StringVar(&options.template, "template", "", "Apply the options of a run template")
+	flags.IntVar(&options.retries, "retries", 0, "Number of times to retry creating or starting the container after a transient failure")
+	flags.DurationVar(&options.retryDelay, "retry-delay", time.Second, "Delay between retries")
+	flags.StringVar(&options.progress, "progress", command.ProgressAuto, `Set type of progress output ("auto", "json"). Use "json" to print lifecycle events as JSON to STDERR`)
+	flags.Var(&options.secrets, "secret", `Copy a secret from a file to an in-memory mount in the container (e.g. "id=foo,src=./foo.txt[,target=/run/secrets/foo]")`)
 
 	// Add an explicit help that doesn't have a `-h` to prevent the conflict
 	// with hostname
@@ -73,21 +86,47 @@ func NewRunCommand(dockerCli command.Cli) *cobra.Command {
 	_ = cmd.RegisterFlagCompletionFunc("cap-drop", completeLinuxCapabilityNames)
 	_ = cmd.RegisterFlagCompletionFunc("env", completion.EnvVarNames)
 	_ = cmd.RegisterFlagCompletionFunc("env-file", completion.FileNames)
+	_ = cmd.RegisterFlagCompletionFunc("env-file-format", completion.FromList(opts.EnvFileFormatV1, opts.EnvFileFormatV2))
 	_ = cmd.RegisterFlagCompletionFunc("network", completion.NetworkNames(dockerCli))
+	_ = cmd.RegisterFlagCompletionFunc("progress", completion.FromList(command.ProgressAuto, command.ProgressJSON))
 	_ = cmd.RegisterFlagCompletionFunc("pull", completion.FromList(PullImageAlways, PullImageMissing, PullImageNever))
 	_ = cmd.RegisterFlagCompletionFunc("restart", completeRestartPolicies)
 	_ = cmd.RegisterFlagCompletionFunc("stop-signal", completeSignals)
 	_ = cmd.RegisterFlagCompletionFunc("volumes-from", completion.ContainerNames(dockerCli, true))
+	_ = cmd.RegisterFlagCompletionFunc("template", completeRunTemplateNames)
 	return cmd
 }
 
 func runRun(ctx context.Context, dockerCli command.Cli, flags *pflag.FlagSet, ropts *runOptions, copts *containerOptions) error {
+	if ropts.template != "" {
+		if err := applyRunTemplate(flags, ropts.template); err != nil {
+			return cli.StatusError{
+				Status:     withHelp(err, "run").Error(),
+				StatusCode: 125,
+			}
+		}
+	}
 	if err := validatePullOpt(ropts.pull); err != nil {
 		return cli.StatusError{
 			Status:     withHelp(err, "run").Error(),
 			StatusCode: 125,
 		}
 	}
+	if err := command.ValidateProgressOpt(ropts.progress); err != nil {
+		return cli.StatusError{
+			Status:     withHelp(err, "run").Error(),
+			StatusCode: 125,
+		}
+	}
+	if ropts.progress == command.ProgressJSON {
+		ropts.events = newRunEventWriter(dockerCli.Err())
+	}
+	if ropts.retries < 0 || ropts.retryDelay < 0 {
+		return cli.StatusError{
+			Status:     withHelp(errors.New("--retries and --retry-delay must be positive"), "run").Error(),
+			StatusCode: 125,
+		}
+	}
 	proxyConfig := dockerCli.ConfigFile().ParseProxyConfig(dockerCli.Client().DaemonHost(), opts.ConvertKVStringsToMapWithNil(copts.env.GetAll()))
 	newEnv := []string{}
 	for k, v := range proxyConfig {
@@ -112,6 +151,18 @@ func runRun(ctx context.Context, dockerCli command.Cli, flags *pflag.FlagSet, ro
 			StatusCode: 125,
 		}
 	}
+	if err := addSecretMounts(containerCfg, ropts.secrets.Value()); err != nil {
+		return cli.StatusError{
+			Status:     withHelp(err, "run").Error(),
+			StatusCode: 125,
+		}
+	}
+	if ropts.dryRun {
+		if err := dryRunContainer(ctx, dockerCli, containerCfg, &ropts.createOptions); err != nil {
+			return toStatusError(err)
+		}
+		return nil
+	}
 	return runContainer(ctx, dockerCli, ropts, copts, containerCfg)
 }
 
@@ -140,13 +191,39 @@ func runContainer(ctx context.Context, dockerCli command.Cli, runOpts *runOption
 		config.StdinOnce = false
 	}
 
+	// Read the secrets before creating the container, so that a missing or
+	// unreadable secret does not leave a container behind.
+	secrets, err := readSecrets(runOpts.secrets.Value())
+	if err != nil {
+		return cli.StatusError{
+			Status:     withHelp(err, "run").Error(),
+			StatusCode: 125,
+		}
+	}
+
 	ctx, cancelFun := context.WithCancel(ctx)
 	defer cancelFun()
 
-	containerID, err := createContainer(ctx, dockerCli, containerCfg, &runOpts.createOptions)
+	var containerID string
+	err = retryRun(ctx, stderr, runOpts, "create", func() (err error) {
+		containerID, err = createContainer(ctx, dockerCli, containerCfg, &runOpts.createOptions)
+		return err
+	})
+	if isNoMatchingPlatformError(err) {
+		var platform string
+		if platform, err = platformFallback(ctx, dockerCli, config.Image, runOpts.platform, err); err == nil {
+			runOpts.platform = platform
+			err = retryRun(ctx, stderr, runOpts, "create", func() (err error) {
+				containerID, err = createContainer(ctx, dockerCli, containerCfg, &runOpts.createOptions)
+				return err
+			})
+		}
+	}
 	if err != nil {
+		runOpts.events.failed("", err)
 		return toStatusError(err)
 	}
+	runOpts.events.emit(runEvent{Event: runEventCreated, ID: containerID, Image: config.Image})
 	if runOpts.sigProxy {
 		sigc := notifyAllSignals()
 		// since we're explicitly setting up signal handling here, and the daemon will
@@ -188,9 +265,11 @@ func runContainer(ctx context.Context, dockerCli command.Cli, runOpts *runOption
 			DetachKeys: detachKeys,
 		})
 		if err != nil {
+			runOpts.events.failed(containerID, err)
 			return err
 		}
 		defer closeFn()
+		runOpts.events.emit(runEvent{Event: runEventAttached, ID: containerID})
 	}
 
 	// New context here because we don't to cancel waiting on container exit/remove
@@ -199,8 +278,25 @@ func runContainer(ctx context.Context, dockerCli command.Cli, runOpts *runOption
 	defer cancelStatusCtx()
 	statusChan := waitExitOrRemoved(statusCtx, apiClient, containerID, copts.autoRemove)
 
-	// start the container
-	if err := apiClient.ContainerStart(ctx, containerID, container.StartOptions{}); err != nil {
+	// start the container. A container that failed to start is removed by the
+	// daemon if auto-remove is set, in which case it can't be started again.
+	startOpts := runOpts
+	if copts.autoRemove {
+		startOpts = &runOptions{}
+	}
+	err = retryRun(ctx, stderr, startOpts, "start", func() error {
+		return apiClient.ContainerStart(ctx, containerID, container.StartOptions{})
+	})
+	if err == nil && len(secrets) > 0 {
+		// Secrets can only be copied once the container is started, as the
+		// content of the mounts they're copied to is lost when they're
+		// unmounted. The container is killed if they can't be copied, as it
+		// would otherwise keep running without its secrets.
+		if err = copySecrets(ctx, apiClient, containerID, secrets); err != nil {
+			_ = apiClient.ContainerKill(ctx, containerID, "")
+		}
+	}
+	if err != nil {
 		// If we have hijackedIOStreamer, we should notify
 		// hijackedIOStreamer we are going to exit and wait
 		// to avoid the terminal are not restored.
@@ -213,8 +309,10 @@ func runContainer(ctx context.Context, dockerCli command.Cli, runOpts *runOption
 			// wait container to be removed
 			<-statusChan
 		}
+		runOpts.events.failed(containerID, err)
 		return toStatusError(err)
 	}
+	runOpts.events.emit(runEvent{Event: runEventStarted, ID: containerID})
 
 	if (config.AttachStdin || config.AttachStdout || config.AttachStderr) && config.Tty && dockerCli.Out().IsTerminal() {
 		if err := MonitorTtySize(ctx, dockerCli, containerID, false); err != nil {
@@ -242,12 +340,46 @@ func runContainer(ctx context.Context, dockerCli command.Cli, runOpts *runOption
 	}
 
 	status := <-statusChan
+	runOpts.events.exited(containerID, status)
 	if status != 0 {
 		return cli.StatusError{StatusCode: status}
 	}
 	return nil
 }
 
+// retryRun calls fn until it succeeds, it fails with an error that is not
+// transient, or the number of retries in runOpts is exhausted. Every failed
+// attempt is reported to stderr.
+func retryRun(ctx context.Context, stderr io.Writer, runOpts *runOptions, action string, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt > runOpts.retries || !isTransientRunError(err) {
+			return err
+		}
+		command.PrintRetry(stderr, "Container "+action, err, runOpts.retryDelay, attempt, runOpts.retries)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(runOpts.retryDelay):
+		}
+	}
+}
+
+// isTransientRunError returns whether creating or starting a container may
+// succeed if retried after err: the name of the container is still used by a
+// container being removed, a network is temporarily missing, or a device is
+// busy.
+func isTransientRunError(err error) bool {
+	switch {
+	case errdefs.IsConflict(err), errdefs.IsUnavailable(err):
+		return true
+	case errdefs.IsNotFound(err):
+		return strings.Contains(strings.ToLower(err.Error()), "network")
+	default:
+		return strings.Contains(err.Error(), syscall.EBUSY.Error())
+	}
+}
+
 func attachContainer(ctx context.Context, dockerCli command.Cli, containerID string, errCh *chan error, config *container.Config, options container.AttachOptions) (func(), error) {
 	resp, errAttach := dockerCli.Client().ContainerAttach(ctx, containerID, options)
 	if errAttach != nil {
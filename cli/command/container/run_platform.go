@@ -0,0 +1,77 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/containerd/platforms"
+	"github.com/docker/cli/cli/command"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// isNoMatchingPlatformError returns whether err indicates that the image has
+// no variant for the requested platform. Both the error of the classic image
+// store and of the containerd image store are detected.
+func isNoMatchingPlatformError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "no matching manifest for") || strings.Contains(msg, "no match for platform in manifest")
+}
+
+// platformFallback is called when the image to run has no variant for the
+// requested platform. It looks up the platforms that are available for the
+// image and, if a terminal is attached, offers to run the image for one of
+// these platforms under emulation. It returns the platform to use, or an
+// error listing the available platforms.
+func platformFallback(ctx context.Context, dockerCli command.Cli, img string, requested string, pullErr error) (string, error) {
+	encodedAuth, err := command.RetrieveAuthTokenFromImage(dockerCli.ConfigFile(), img)
+	if err != nil {
+		return "", pullErr
+	}
+	distributionInspect, err := dockerCli.Client().DistributionInspect(ctx, img, encodedAuth)
+	if err != nil || len(distributionInspect.Platforms) == 0 {
+		return "", pullErr
+	}
+
+	available := make([]string, 0, len(distributionInspect.Platforms))
+	for _, p := range distributionInspect.Platforms {
+		available = append(available, platforms.Format(p))
+	}
+	if requested == "" {
+		requested = platforms.Format(platforms.DefaultSpec())
+	}
+	suggested := suggestPlatform(requested, distributionInspect.Platforms)
+
+	if !dockerCli.In().IsTerminal() || !dockerCli.Err().IsTerminal() {
+		return "", errors.Errorf("image %s is not available for platform %s\n\nAvailable platforms: %s\nUse --platform to run the image for one of these platforms using emulation, for example: --platform %s",
+			img, requested, strings.Join(available, ", "), suggested)
+	}
+
+	_, _ = fmt.Fprintf(dockerCli.Err(), "Image %s is not available for platform %s.\nAvailable platforms: %s\n", img, requested, strings.Join(available, ", "))
+	ok, err := command.PromptForConfirmation(ctx, dockerCli.In(), dockerCli.Err(), fmt.Sprintf("Run the image for platform %s using emulation?", suggested))
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", pullErr
+	}
+	return suggested, nil
+}
+
+// suggestPlatform returns the platform of the image to suggest as fallback
+// for the requested platform, preferring a platform with the same operating
+// system.
+func suggestPlatform(requested string, available []ocispec.Platform) string {
+	if p, err := platforms.Parse(requested); err == nil {
+		for _, a := range available {
+			if a.OS == p.OS {
+				return platforms.Format(a)
+			}
+		}
+	}
+	return platforms.Format(available[0])
+}
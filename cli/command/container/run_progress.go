@@ -0,0 +1,60 @@
+package container
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Lifecycle events emitted by "docker run --progress json".
+const (
+	runEventPulling  = "pulling"
+	runEventPulled   = "pulled"
+	runEventCreated  = "created"
+	runEventStarted  = "started"
+	runEventAttached = "attached"
+	runEventExited   = "exited"
+	runEventError    = "error"
+)
+
+// runEvent is a lifecycle event of a container started with "docker run".
+type runEvent struct {
+	Time     time.Time `json:"time"`
+	Event    string    `json:"event"`
+	Image    string    `json:"image,omitempty"`
+	ID       string    `json:"id,omitempty"`
+	ExitCode *int      `json:"exitCode,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// runEventWriter writes lifecycle events as newline-delimited JSON. A nil
+// runEventWriter discards all events.
+type runEventWriter struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func newRunEventWriter(out io.Writer) *runEventWriter {
+	return &runEventWriter{out: out}
+}
+
+func (w *runEventWriter) emit(ev runEvent) {
+	if w == nil {
+		return
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now().UTC()
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_ = json.NewEncoder(w.out).Encode(ev)
+}
+
+func (w *runEventWriter) exited(containerID string, exitCode int) {
+	w.emit(runEvent{Event: runEventExited, ID: containerID, ExitCode: &exitCode})
+}
+
+func (w *runEventWriter) failed(containerID string, err error) {
+	w.emit(runEvent{Event: runEventError, ID: containerID, Error: err.Error()})
+}
@@ -0,0 +1,227 @@
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/cli/cli/compose/loader"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/client"
+	"github.com/pkg/errors"
+)
+
+// defaultSecretsDir is the directory in the container in which secrets are
+// mounted if no target is specified, matching the location used by BuildKit
+// and swarm services.
+const defaultSecretsDir = "/run/secrets"
+
+// runSecret is a secret to materialize in the container by "docker run".
+type runSecret struct {
+	ID     string
+	Source string
+	Target string
+	UID    int
+	GID    int
+	Mode   os.FileMode
+}
+
+// runSecretOpt is a [pflag.Value] for the "--secret" option of "docker run".
+type runSecretOpt struct {
+	values []runSecret
+}
+
+// Set parses a secret in the "id=foo,src=./foo.txt[,target=/run/secrets/foo]"
+// format, and adds it to the list of secrets.
+func (o *runSecretOpt) Set(value string) error {
+	fields, err := csv.NewReader(strings.NewReader(value)).Read()
+	if err != nil {
+		return err
+	}
+
+	secret := runSecret{Mode: 0o444}
+	for _, field := range fields {
+		key, val, ok := strings.Cut(field, "=")
+		if !ok || key == "" {
+			return errors.Errorf("invalid field '%s' must be a key=value pair", field)
+		}
+		switch key {
+		case "id":
+			secret.ID = val
+		case "source", "src":
+			secret.Source = val
+		case "target", "dst":
+			secret.Target = val
+		case "uid", "gid":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 0 {
+				return errors.Errorf("invalid %s specified: %s", key, val)
+			}
+			if key == "uid" {
+				secret.UID = n
+			} else {
+				secret.GID = n
+			}
+		case "mode":
+			m, err := strconv.ParseUint(val, 0, 32)
+			if err != nil {
+				return errors.Errorf("invalid mode specified: %v", err)
+			}
+			secret.Mode = os.FileMode(m)
+		default:
+			return errors.Errorf("invalid field in secret request: %s", key)
+		}
+	}
+
+	if secret.ID == "" {
+		return errors.New("id is required")
+	}
+	if secret.Source == "" {
+		secret.Source = secret.ID
+	}
+	if secret.Target == "" {
+		secret.Target = path.Join(defaultSecretsDir, secret.ID)
+	}
+	if !path.IsAbs(secret.Target) || path.Clean(secret.Target) == "/" {
+		return errors.Errorf("invalid target for secret %s: %s: must be an absolute path to a file", secret.ID, secret.Target)
+	}
+	secret.Target = path.Clean(secret.Target)
+	o.values = append(o.values, secret)
+	return nil
+}
+
+// Type returns the type of this option
+func (o *runSecretOpt) Type() string {
+	return "secret"
+}
+
+// String returns a string repr of this option
+func (o *runSecretOpt) String() string {
+	secrets := make([]string, 0, len(o.values))
+	for _, s := range o.values {
+		secrets = append(secrets, fmt.Sprintf("%s -> %s", s.ID, s.Target))
+	}
+	return strings.Join(secrets, ", ")
+}
+
+// Value returns the secrets
+func (o *runSecretOpt) Value() []runSecret {
+	return o.values
+}
+
+// addSecretMounts adds an in-memory mount to the configuration of the
+// container for each directory that secrets are copied to, so that secrets
+// are never written to the filesystem of the container, and are not included
+// by "docker commit" or "docker export".
+//
+// The mounts are anonymous volumes of the "local" driver backed by a tmpfs,
+// rather than tmpfs mounts, as the daemon doesn't copy files to the tmpfs
+// mounts of a container, but to the filesystem underneath. Secrets can't be
+// copied to a directory that already has a mount, or that is on a tmpfs
+// mount, as they would be stored on that mount, or in the filesystem of the
+// container.
+func addSecretMounts(cfg *containerConfig, secrets []runSecret) error {
+	var tmpfs []string
+	for target := range cfg.HostConfig.Tmpfs {
+		tmpfs = append(tmpfs, path.Clean(target))
+	}
+	mounted := make(map[string]bool)
+	for _, m := range cfg.HostConfig.Mounts {
+		if m.Type == mount.TypeTmpfs {
+			tmpfs = append(tmpfs, path.Clean(m.Target))
+		} else {
+			mounted[path.Clean(m.Target)] = true
+		}
+	}
+	for _, bind := range cfg.HostConfig.Binds {
+		if v, err := loader.ParseVolume(bind); err == nil {
+			mounted[path.Clean(v.Target)] = true
+		}
+	}
+	for target := range cfg.Config.Volumes {
+		mounted[path.Clean(target)] = true
+	}
+
+	added := make(map[string]bool)
+	for _, s := range secrets {
+		for _, dir := range tmpfs {
+			if strings.HasPrefix(s.Target, strings.TrimSuffix(dir, "/")+"/") {
+				return errors.Errorf("invalid target for secret %s: %s is on the tmpfs mount %s", s.ID, s.Target, dir)
+			}
+		}
+		dir := path.Dir(s.Target)
+		if mounted[dir] {
+			return errors.Errorf("invalid target for secret %s: %s already has a mount", s.ID, dir)
+		}
+		if added[dir] {
+			continue
+		}
+		added[dir] = true
+		cfg.HostConfig.Mounts = append(cfg.HostConfig.Mounts, mount.Mount{
+			Type:   mount.TypeVolume,
+			Target: dir,
+			VolumeOptions: &mount.VolumeOptions{
+				DriverConfig: &mount.Driver{
+					Name:    "local",
+					Options: map[string]string{"type": "tmpfs", "device": "tmpfs", "o": "mode=0755"},
+				},
+			},
+		})
+	}
+	return nil
+}
+
+// readSecrets reads the content of the secrets from their source on the
+// client, and returns an archive of the secrets to extract at the root of the
+// filesystem of the container.
+func readSecrets(secrets []runSecret) ([]byte, error) {
+	if len(secrets) == 0 {
+		return nil, nil
+	}
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	now := time.Now()
+	for _, s := range secrets {
+		content, err := os.ReadFile(s.Source)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read secret %s", s.ID)
+		}
+		// Missing parent directories are created by the daemon.
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     strings.TrimPrefix(s.Target, "/"),
+			Mode:     int64(s.Mode.Perm()),
+			Uid:      s.UID,
+			Gid:      s.GID,
+			Size:     int64(len(content)),
+			ModTime:  now,
+		}); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// copySecrets copies the secrets read by [readSecrets] to the mounts added by
+// [addSecretMounts]. It must be called once the container is started, as the
+// content of the mounts is lost when they're unmounted.
+func copySecrets(ctx context.Context, apiClient client.ContainerAPIClient, containerID string, archive []byte) error {
+	if err := apiClient.CopyToContainer(ctx, containerID, "/", bytes.NewReader(archive), container.CopyToContainerOptions{}); err != nil {
+		return errors.Wrap(err, "failed to copy secrets")
+	}
+	return nil
+}
@@ -0,0 +1,318 @@
+package container
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/formatter/tabwriter"
+	"github.com/docker/cli/cli/config"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const runTemplatesDir = "run-templates"
+
+var validRunTemplateName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// runTemplate is a set of `docker run` flag presets, stored as a YAML file in
+// the run-templates directory of the CLI configuration directory.
+//
+// Flags are keyed by their long name. A value is either a scalar, which is
+// ignored if the flag is also set on the command-line, or a list of values,
+// which are added to the values set on the command-line.
+type runTemplate struct {
+	Flags map[string]any `yaml:"flags"`
+}
+
+func runTemplatePath(name string) (string, error) {
+	if !validRunTemplateName.MatchString(name) {
+		return "", errors.Errorf("invalid run template name %q: only [a-zA-Z0-9][a-zA-Z0-9_.-] are allowed", name)
+	}
+	return filepath.Join(config.Dir(), runTemplatesDir, name+".yaml"), nil
+}
+
+func loadRunTemplate(name string) (*runTemplate, error) {
+	p, err := runTemplatePath(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Errorf("run template %q not found", name)
+		}
+		return nil, err
+	}
+	var tmpl runTemplate
+	if err := yaml.Unmarshal(data, &tmpl); err != nil {
+		return nil, errors.Wrapf(err, "invalid run template %q", name)
+	}
+	return &tmpl, nil
+}
+
+func listRunTemplates() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(config.Dir(), runTemplatesDir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		names = append(names, strings.TrimSuffix(filepath.Base(f), ".yaml"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// applyRunTemplate sets the flags of the template on flags.
+func applyRunTemplate(flags *pflag.FlagSet, name string) error {
+	tmpl, err := loadRunTemplate(name)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(tmpl.Flags))
+	for n := range tmpl.Flags {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		f := flags.Lookup(n)
+		if f == nil || n == "template" || n == "help" {
+			return errors.Errorf("run template %q: unknown flag: --%s", name, n)
+		}
+		switch v := tmpl.Flags[n].(type) {
+		case []any:
+			for _, item := range v {
+				if err := flags.Set(n, fmt.Sprint(item)); err != nil {
+					return errors.Wrapf(err, "run template %q: invalid value for --%s", name, n)
+				}
+			}
+		default:
+			if f.Changed {
+				// Flags set on the command-line take precedence.
+				continue
+			}
+			if err := flags.Set(n, fmt.Sprint(v)); err != nil {
+				return errors.Wrapf(err, "run template %q: invalid value for --%s", name, n)
+			}
+		}
+	}
+	return nil
+}
+
+// NewTemplateCommand returns a cobra command for `container template`
+// subcommands, to manage the run templates of `docker run --template`.
+func NewTemplateCommand(dockerCli command.Cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Manage run templates",
+		Args:  cli.NoArgs,
+		RunE:  command.ShowHelp(dockerCli.Err()),
+	}
+	cmd.AddCommand(
+		newRunTemplateListCommand(dockerCli),
+		newRunTemplateRemoveCommand(dockerCli),
+		newRunTemplateShowCommand(dockerCli),
+		newRunTemplateSaveCommand(dockerCli),
+	)
+	return cmd
+}
+
+func newRunTemplateListCommand(dockerCli command.Cli) *cobra.Command {
+	var quiet bool
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List run templates",
+		Args:    cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			names, err := listRunTemplates()
+			if err != nil {
+				return err
+			}
+			if quiet {
+				for _, n := range names {
+					fmt.Fprintln(dockerCli.Out(), n)
+				}
+				return nil
+			}
+			w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
+			fmt.Fprintln(w, "NAME\tFLAGS")
+			for _, n := range names {
+				tmpl, err := loadRunTemplate(n)
+				if err != nil {
+					return err
+				}
+				flags := make([]string, 0, len(tmpl.Flags))
+				for f := range tmpl.Flags {
+					flags = append(flags, "--"+f)
+				}
+				sort.Strings(flags)
+				fmt.Fprintf(w, "%s\t%s\n", n, strings.Join(flags, " "))
+			}
+			return w.Flush()
+		},
+	}
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Only display template names")
+	return cmd
+}
+
+func newRunTemplateShowCommand(dockerCli command.Cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show TEMPLATE",
+		Short: "Display a run template",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := runTemplatePath(args[0])
+			if err != nil {
+				return err
+			}
+			// Make sure the template is valid before printing it.
+			if _, err := loadRunTemplate(args[0]); err != nil {
+				return err
+			}
+			data, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			_, err = dockerCli.Out().Write(data)
+			return err
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return completeRunTemplateNames(cmd, args, toComplete)
+		},
+	}
+}
+
+func newRunTemplateSaveCommand(dockerCli command.Cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "save TEMPLATE [OPTIONS]",
+		Short: "Save `docker run` options as a run template",
+		Long: "Save `docker run` options as a run template\n\n" +
+			"The options following the template name are the options of `docker run`\n" +
+			"to store in the template, for example:\n\n" +
+			"    docker container template save dev-shell -it --rm -v .:/src -w /src",
+		Args:                  cli.RequiresMinArgs(1),
+		DisableFlagParsing:    true,
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if args[0] == "--help" || args[0] == "-h" {
+				return cmd.Help()
+			}
+			return runSaveRunTemplate(dockerCli, args[0], args[1:])
+		},
+	}
+}
+
+func newRunTemplateRemoveCommand(dockerCli command.Cli) *cobra.Command {
+	return &cobra.Command{
+		Use:     "rm TEMPLATE [TEMPLATE...]",
+		Aliases: []string{"remove"},
+		Short:   "Remove one or more run templates",
+		Args:    cli.RequiresMinArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRemoveRunTemplates(dockerCli, args)
+		},
+		ValidArgsFunction: completeRunTemplateNames,
+	}
+}
+
+func runRemoveRunTemplates(dockerCli command.Cli, names []string) error {
+	var errs []string
+	for _, name := range names {
+		p, err := runTemplatePath(name)
+		if err == nil {
+			err = os.Remove(p)
+			if os.IsNotExist(err) {
+				err = errors.Errorf("run template %q not found", name)
+			}
+		}
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		_, _ = fmt.Fprintln(dockerCli.Out(), name)
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// runSaveRunTemplate parses args as `docker run` flags, and stores the flags
+// that were set as the template.
+func runSaveRunTemplate(dockerCli command.Cli, name string, args []string) error {
+	p, err := runTemplatePath(name)
+	if err != nil {
+		return err
+	}
+
+	flags := NewRunCommand(dockerCli).Flags()
+	values := map[string][]string{}
+	err = flags.ParseAll(args, func(f *pflag.Flag, value string) error {
+		if f.Name == "template" || f.Name == "help" {
+			return errors.Errorf("--%s can not be saved in a run template", f.Name)
+		}
+		values[f.Name] = append(values[f.Name], value)
+		return flags.Set(f.Name, value)
+	})
+	if err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return errors.Errorf("unexpected argument %q: a run template can only contain options", flags.Arg(0))
+	}
+
+	tmpl := runTemplate{Flags: make(map[string]any, len(values))}
+	for n, v := range values {
+		f := flags.Lookup(n)
+		switch {
+		case len(v) > 1 || isListFlag(f):
+			tmpl.Flags[n] = v
+		case f.Value.Type() == "bool":
+			tmpl.Flags[n] = v[0] == "true"
+		default:
+			tmpl.Flags[n] = v[0]
+		}
+	}
+	data, err := yaml.Marshal(&tmpl)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(p, data, 0o644); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(dockerCli.Out(), name)
+	return nil
+}
+
+// isListFlag returns whether the flag accepts multiple values.
+func isListFlag(f *pflag.Flag) bool {
+	if _, ok := f.Value.(pflag.SliceValue); ok {
+		return true
+	}
+	switch f.Value.Type() {
+	case "list", "map", "mount", "network", "port", "ulimit":
+		return true
+	}
+	return false
+}
+
+func completeRunTemplateNames(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	names, _ := listRunTemplates()
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,100 @@
+package container
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/cli/cli/config"
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/cli/opts"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestRunTemplateSaveAndApply(t *testing.T) {
+	dir := t.TempDir()
+	config.SetDir(dir)
+	t.Cleanup(func() { config.SetDir("") })
+
+	cli := test.NewFakeCli(&fakeClient{})
+	cmd := NewTemplateCommand(cli)
+	cmd.SetArgs([]string{"save", "dev-shell", "-it", "--rm", "-e", "FOO=bar", "-w", "/src", "--memory", "1g"})
+	assert.NilError(t, cmd.Execute())
+
+	data, err := os.ReadFile(filepath.Join(dir, "run-templates", "dev-shell.yaml"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(data), `flags:
+  env:
+  - FOO=bar
+  interactive: true
+  memory: 1g
+  rm: true
+  tty: true
+  workdir: /src
+`))
+
+	runCmd := NewRunCommand(cli)
+	flags := runCmd.Flags()
+	assert.NilError(t, flags.Parse([]string{"-e", "BAZ=qux", "--workdir", "/app", "alpine"}))
+	assert.NilError(t, applyRunTemplate(flags, "dev-shell"))
+
+	env := flags.Lookup("env").Value.(*opts.ListOpts).GetAll()
+	assert.Check(t, is.DeepEqual(env, []string{"BAZ=qux", "FOO=bar"}))
+	workdir, err := flags.GetString("workdir")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(workdir, "/app"))
+	tty, err := flags.GetBool("tty")
+	assert.NilError(t, err)
+	assert.Check(t, tty)
+	assert.Check(t, flags.Lookup("memory").Changed)
+
+	cli.OutBuffer().Reset()
+	cmd = NewTemplateCommand(cli)
+	cmd.SetArgs([]string{"ls"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "NAME                FLAGS\ndev-shell           --env --interactive --memory --rm --tty --workdir\n"))
+
+	cli.OutBuffer().Reset()
+	cmd = NewTemplateCommand(cli)
+	cmd.SetArgs([]string{"rm", "dev-shell"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "dev-shell\n"))
+	_, err = os.Stat(filepath.Join(dir, "run-templates", "dev-shell.yaml"))
+	assert.Check(t, os.IsNotExist(err))
+}
+
+func TestRunTemplateErrors(t *testing.T) {
+	dir := t.TempDir()
+	config.SetDir(dir)
+	t.Cleanup(func() { config.SetDir("") })
+
+	assert.NilError(t, os.MkdirAll(filepath.Join(dir, "run-templates"), 0o755))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "run-templates", "bad.yaml"), []byte("flags:\n  no-such-flag: true\n"), 0o644))
+
+	cli := test.NewFakeCli(&fakeClient{})
+	flags := NewRunCommand(cli).Flags()
+	assert.Check(t, is.Error(applyRunTemplate(flags, "bad"), `run template "bad": unknown flag: --no-such-flag`))
+	assert.Check(t, is.Error(applyRunTemplate(flags, "missing"), `run template "missing" not found`))
+	assert.Check(t, is.ErrorContains(applyRunTemplate(flags, "../escape"), "invalid run template name"))
+
+	cmd := NewTemplateCommand(cli)
+	cmd.SetArgs([]string{"save", "with-image", "-it", "alpine"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	assert.Check(t, is.Error(cmd.Execute(), `unexpected argument "alpine": a run template can only contain options`))
+
+	cmd = NewTemplateCommand(cli)
+	cmd.SetArgs([]string{"rm", "missing"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	assert.Check(t, is.Error(cmd.Execute(), `run template "missing" not found`))
+}
+
+func TestRunCommandHasNoSubcommands(t *testing.T) {
+	// "docker run" must remain a leaf command, so that images named like a
+	// subcommand, such as "template", are run.
+	cmd := NewRunCommand(test.NewFakeCli(&fakeClient{}))
+	assert.Check(t, !cmd.HasSubCommands())
+}
@@ -1,14 +1,19 @@
 package container
 
 import (
+	"archive/tar"
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"net"
+	"slices"
+	"strings"
 	"syscall"
 	"testing"
 	"time"
 
+	"github.com/containerd/platforms"
 	"github.com/creack/pty"
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/streams"
@@ -16,11 +21,17 @@
 	"github.com/docker/cli/internal/test/notary"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/api/types/system"
+	"github.com/docker/docker/errdefs"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/pflag"
 	"gotest.tools/v3/assert"
 	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/fs"
 )
 
 func TestRunLabel(t *testing.T) {
@@ -275,3 +286,323 @@ func TestRunContainerImagePullPolicyInvalid(t *testing.T) {
 		})
 	}
 }
+
+func TestRunRetries(t *testing.T) {
+	var creates, starts int
+	fakeCLI := test.NewFakeCli(&fakeClient{
+		createContainerFunc: func(_ *container.Config, _ *container.HostConfig, _ *network.NetworkingConfig, _ *specs.Platform, _ string) (container.CreateResponse, error) {
+			creates++
+			if creates == 1 {
+				return container.CreateResponse{}, errdefs.Conflict(errors.New(`Conflict. The container name "/web" is already in use`))
+			}
+			return container.CreateResponse{ID: "id"}, nil
+		},
+		containerStartFunc: func(string, container.StartOptions) error {
+			starts++
+			if starts < 3 {
+				return errdefs.NotFound(errors.New("network mynet not found"))
+			}
+			return nil
+		},
+		Version: "1.36",
+	})
+	cmd := NewRunCommand(fakeCLI)
+	cmd.SetArgs([]string{"--detach", "--name", "web", "--retries", "3", "--retry-delay", "1ms", "busybox"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(creates, 2))
+	assert.Check(t, is.Equal(starts, 3))
+	assert.Check(t, is.Equal(fakeCLI.ErrBuffer().String(), `Container create failed: Conflict. The container name "/web" is already in use
+Retrying in 1ms (1 of 3)
+Container start failed: network mynet not found
+Retrying in 1ms (1 of 3)
+Container start failed: network mynet not found
+Retrying in 1ms (2 of 3)
+`))
+}
+
+func TestRunRetriesNotTransient(t *testing.T) {
+	var starts int
+	fakeCLI := test.NewFakeCli(&fakeClient{
+		createContainerFunc: func(_ *container.Config, _ *container.HostConfig, _ *network.NetworkingConfig, _ *specs.Platform, _ string) (container.CreateResponse, error) {
+			return container.CreateResponse{ID: "id"}, nil
+		},
+		containerStartFunc: func(string, container.StartOptions) error {
+			starts++
+			return errors.New("exec: \"foo\": executable file not found in $PATH")
+		},
+		Version: "1.36",
+	})
+	cmd := NewRunCommand(fakeCLI)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--detach", "--retries", "3", "--retry-delay", "1ms", "busybox", "foo"})
+	err := cmd.Execute()
+	assert.Check(t, is.ErrorContains(err, "executable file not found"))
+	assert.Check(t, is.Equal(starts, 1))
+}
+
+func TestRunSecret(t *testing.T) {
+	secretFile := fs.NewFile(t, "secret", fs.WithContent("hunter2"))
+	var (
+		copied  map[string]string
+		started bool
+	)
+	fakeCLI := test.NewFakeCli(&fakeClient{
+		createContainerFunc: func(config *container.Config, hostConfig *container.HostConfig, _ *network.NetworkingConfig, _ *specs.Platform, _ string) (container.CreateResponse, error) {
+			assert.Check(t, is.Len(config.Env, 0))
+			secretsMount := func(dir string) mount.Mount {
+				return mount.Mount{
+					Type:   mount.TypeVolume,
+					Target: dir,
+					VolumeOptions: &mount.VolumeOptions{DriverConfig: &mount.Driver{
+						Name:    "local",
+						Options: map[string]string{"type": "tmpfs", "device": "tmpfs", "o": "mode=0755"},
+					}},
+				}
+			}
+			assert.Check(t, is.DeepEqual(hostConfig.Mounts, []mount.Mount{secretsMount("/run/secrets"), secretsMount("/etc/app")}))
+			return container.CreateResponse{ID: "id"}, nil
+		},
+		containerStartFunc: func(string, container.StartOptions) error {
+			assert.Check(t, copied == nil, "secrets must be copied after the container is started")
+			started = true
+			return nil
+		},
+		containerCopyToFunc: func(containerID, path string, content io.Reader, _ container.CopyToContainerOptions) error {
+			assert.Check(t, started, "secrets must be copied after the container is started")
+			assert.Check(t, is.Equal(containerID, "id"))
+			assert.Check(t, is.Equal(path, "/"))
+			copied = make(map[string]string)
+			tr := tar.NewReader(content)
+			for {
+				hdr, err := tr.Next()
+				if err == io.EOF {
+					return nil
+				}
+				assert.NilError(t, err)
+				assert.Check(t, is.Equal(hdr.Mode, int64(0o400)))
+				b, err := io.ReadAll(tr)
+				assert.NilError(t, err)
+				copied[hdr.Name] = string(b)
+			}
+		},
+		Version: "1.36",
+	})
+	cmd := NewRunCommand(fakeCLI)
+	cmd.SetArgs([]string{
+		"--detach",
+		"--secret", "id=token,src=" + secretFile.Path() + ",mode=0400",
+		"--secret", "id=password,src=" + secretFile.Path() + ",target=/etc/app/db-password,mode=0400",
+		"busybox",
+	})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, started)
+	assert.Check(t, is.DeepEqual(copied, map[string]string{"run/secrets/token": "hunter2", "etc/app/db-password": "hunter2"}))
+}
+
+func TestRunSecretCopyFailed(t *testing.T) {
+	secretFile := fs.NewFile(t, "secret", fs.WithContent("hunter2"))
+	var killed bool
+	fakeCLI := test.NewFakeCli(&fakeClient{
+		createContainerFunc: func(_ *container.Config, _ *container.HostConfig, _ *network.NetworkingConfig, _ *specs.Platform, _ string) (container.CreateResponse, error) {
+			return container.CreateResponse{ID: "id"}, nil
+		},
+		containerCopyToFunc: func(string, string, io.Reader, container.CopyToContainerOptions) error {
+			return errors.New("no space left on device")
+		},
+		containerKillFunc: func(_ context.Context, containerID, _ string) error {
+			assert.Check(t, is.Equal(containerID, "id"))
+			killed = true
+			return nil
+		},
+		Version: "1.36",
+	})
+	cmd := NewRunCommand(fakeCLI)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--detach", "--secret", "id=token,src=" + secretFile.Path(), "busybox"})
+	err := cmd.Execute()
+	assert.Check(t, is.ErrorContains(err, "failed to copy secrets: no space left on device"))
+	assert.Check(t, killed)
+}
+
+func TestRunSecretOnTmpfs(t *testing.T) {
+	fakeCLI := test.NewFakeCli(&fakeClient{Version: "1.36"})
+	cmd := NewRunCommand(fakeCLI)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--tmpfs", "/run", "--secret", "id=token", "busybox"})
+	err := cmd.Execute()
+	assert.Check(t, is.ErrorContains(err, "invalid target for secret token: /run/secrets/token is on the tmpfs mount /run"))
+}
+
+func TestRunSecretOnMount(t *testing.T) {
+	fakeCLI := test.NewFakeCli(&fakeClient{Version: "1.36"})
+	cmd := NewRunCommand(fakeCLI)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"-v", "secrets:/run/secrets", "--secret", "id=token", "busybox"})
+	err := cmd.Execute()
+	assert.Check(t, is.ErrorContains(err, "invalid target for secret token: /run/secrets already has a mount"))
+}
+
+func TestRunSecretMissingSource(t *testing.T) {
+	fakeCLI := test.NewFakeCli(&fakeClient{
+		createContainerFunc: func(_ *container.Config, _ *container.HostConfig, _ *network.NetworkingConfig, _ *specs.Platform, _ string) (container.CreateResponse, error) {
+			t.Fatal("container should not be created")
+			return container.CreateResponse{}, nil
+		},
+		Version: "1.36",
+	})
+	cmd := NewRunCommand(fakeCLI)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--secret", "id=token,src=/no/such/file", "busybox"})
+	err := cmd.Execute()
+	assert.Check(t, is.ErrorContains(err, "failed to read secret token"))
+}
+
+func TestRunSecretOpt(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    runSecret
+		expectedErr string
+	}{
+		{
+			value:    "id=foo",
+			expected: runSecret{ID: "foo", Source: "foo", Target: "/run/secrets/foo", Mode: 0o444},
+		},
+		{
+			value:    "id=foo,src=./foo.txt,target=/etc/app/foo.conf,uid=1000,gid=1000,mode=0400",
+			expected: runSecret{ID: "foo", Source: "./foo.txt", Target: "/etc/app/foo.conf", UID: 1000, GID: 1000, Mode: 0o400},
+		},
+		{value: "src=./foo.txt", expectedErr: "id is required"},
+		{value: "id=foo,target=foo", expectedErr: "invalid target for secret foo: foo: must be an absolute path to a file"},
+		{value: "id=foo,uid=-1", expectedErr: "invalid uid specified: -1"},
+		{value: "id=foo,bar=baz", expectedErr: "invalid field in secret request: bar"},
+		{value: "foo", expectedErr: "invalid field 'foo' must be a key=value pair"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.value, func(t *testing.T) {
+			var o runSecretOpt
+			err := o.Set(tc.value)
+			if tc.expectedErr != "" {
+				assert.Check(t, is.Error(err, tc.expectedErr))
+				return
+			}
+			assert.NilError(t, err)
+			assert.Check(t, is.DeepEqual(o.Value(), []runSecret{tc.expected}))
+		})
+	}
+}
+
+func TestRunProgressJSON(t *testing.T) {
+	var creates int
+	fakeCLI := test.NewFakeCli(&fakeClient{
+		createContainerFunc: func(_ *container.Config, _ *container.HostConfig, _ *network.NetworkingConfig, _ *specs.Platform, _ string) (container.CreateResponse, error) {
+			creates++
+			if creates == 1 {
+				return container.CreateResponse{}, fakeNotFound{}
+			}
+			return container.CreateResponse{ID: "id"}, nil
+		},
+		imageCreateFunc: func(string, image.CreateOptions) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(`{"status":"Pulling from library/busybox"}`)), nil
+		},
+		infoFunc: func() (system.Info, error) {
+			return system.Info{IndexServerAddress: "https://indexserver.example.com"}, nil
+		},
+		Version: "1.36",
+	})
+	cmd := NewRunCommand(fakeCLI)
+	cmd.SetArgs([]string{"--detach", "--progress", "json", "busybox"})
+	assert.NilError(t, cmd.Execute())
+
+	var actual []string
+	dec := json.NewDecoder(fakeCLI.ErrBuffer())
+	for dec.More() {
+		var ev runEvent
+		assert.NilError(t, dec.Decode(&ev))
+		assert.Check(t, !ev.Time.IsZero())
+		actual = append(actual, ev.Event+" "+ev.Image+" "+ev.ID)
+	}
+	assert.Check(t, is.DeepEqual(actual, []string{
+		"pulling busybox ",
+		"pulled busybox ",
+		"created busybox id",
+		"started  id",
+	}))
+	assert.Check(t, is.Equal(fakeCLI.OutBuffer().String(), "id\n"))
+}
+
+func TestRunProgressInvalid(t *testing.T) {
+	fakeCLI := test.NewFakeCli(&fakeClient{Version: "1.36"})
+	cmd := NewRunCommand(fakeCLI)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--progress", "plain", "busybox"})
+	err := cmd.Execute()
+	assert.Check(t, is.ErrorContains(err, `invalid progress type "plain": must be "auto" or "json"`))
+}
+
+func TestRunPlatformFallback(t *testing.T) {
+	newClient := func(pulled *[]string) *fakeClient {
+		return &fakeClient{
+			createContainerFunc: func(_ *container.Config, _ *container.HostConfig, _ *network.NetworkingConfig, platform *specs.Platform, _ string) (container.CreateResponse, error) {
+				if platform == nil || platforms.Format(*platform) != "linux/amd64" || !slices.Contains(*pulled, "linux/amd64") {
+					return container.CreateResponse{}, fakeNotFound{}
+				}
+				return container.CreateResponse{ID: "id"}, nil
+			},
+			imageCreateFunc: func(_ string, options image.CreateOptions) (io.ReadCloser, error) {
+				*pulled = append(*pulled, options.Platform)
+				if options.Platform != "linux/amd64" {
+					return io.NopCloser(strings.NewReader(`{"errorDetail":{"message":"no matching manifest for linux/s390x in the manifest list entries"},"error":"no matching manifest for linux/s390x in the manifest list entries"}`)), nil
+				}
+				return io.NopCloser(strings.NewReader("")), nil
+			},
+			distributionInspectFunc: func(string) (registry.DistributionInspect, error) {
+				return registry.DistributionInspect{
+					Platforms: []specs.Platform{
+						{OS: "windows", Architecture: "amd64"},
+						{OS: "linux", Architecture: "amd64"},
+						{OS: "linux", Architecture: "arm64"},
+					},
+				}, nil
+			},
+			infoFunc: func() (system.Info, error) {
+				return system.Info{IndexServerAddress: "https://indexserver.example.com"}, nil
+			},
+			Version: "1.41",
+		}
+	}
+
+	t.Run("prompt", func(t *testing.T) {
+		var pulled []string
+		fakeCLI := test.NewFakeCli(newClient(&pulled))
+		in := streams.NewIn(io.NopCloser(strings.NewReader("y\n")))
+		in.SetIsTerminal(true)
+		fakeCLI.SetIn(in)
+		fakeCLI.Err().SetIsTerminal(true)
+		cmd := NewRunCommand(fakeCLI)
+		cmd.SetArgs([]string{"--detach", "--platform", "linux/s390x", "busybox"})
+		assert.NilError(t, cmd.Execute())
+		assert.Check(t, is.DeepEqual(pulled, []string{"linux/s390x", "linux/amd64"}))
+		assert.Check(t, is.Contains(fakeCLI.ErrBuffer().String(), "Available platforms: windows/amd64, linux/amd64, linux/arm64"))
+		assert.Check(t, is.Contains(fakeCLI.ErrBuffer().String(), "Run the image for platform linux/amd64 using emulation? [y/N]"))
+	})
+
+	t.Run("no terminal", func(t *testing.T) {
+		var pulled []string
+		fakeCLI := test.NewFakeCli(newClient(&pulled))
+		cmd := NewRunCommand(fakeCLI)
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		cmd.SetArgs([]string{"--detach", "--platform", "linux/s390x", "busybox"})
+		err := cmd.Execute()
+		assert.Check(t, is.ErrorContains(err, "image busybox is not available for platform linux/s390x"))
+		assert.Check(t, is.ErrorContains(err, "Available platforms: windows/amd64, linux/amd64, linux/arm64"))
+		assert.Check(t, is.ErrorContains(err, "--platform linux/amd64"))
+		assert.Check(t, is.DeepEqual(pulled, []string{"linux/s390x"}))
+	})
+}
@@ -5,11 +5,14 @@
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/docker/api/types/checkpoint"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/moby/sys/signal"
 	"github.com/moby/term"
 	"github.com/pkg/errors"
@@ -68,12 +71,26 @@ func RunStart(ctx context.Context, dockerCli command.Cli, opts *StartOptions) er
 	ctx, cancelFun := context.WithCancel(ctx)
 	defer cancelFun()
 
+	if opts.CheckpointDir != "" && opts.Checkpoint == "" {
+		return errors.New("--checkpoint-dir can only be used with --checkpoint")
+	}
+	if opts.Checkpoint != "" {
+		if len(opts.Containers) > 1 {
+			return errors.New("you cannot restore multiple containers at once")
+		}
+		if err := validateCheckpoint(ctx, dockerCli, opts.Containers[0], opts); err != nil {
+			return err
+		}
+	}
+
 	switch {
+	case opts.Attach && !opts.OpenStdin && len(opts.Containers) > 1:
+		return startAndAttachMultiple(ctx, dockerCli, opts.Containers)
 	case opts.Attach || opts.OpenStdin:
 		// We're going to attach to a container.
 		// 1. Ensure we only have one container.
 		if len(opts.Containers) > 1 {
-			return errors.New("you cannot start and attach multiple containers at once")
+			return errors.New("you cannot start and attach the STDIN of multiple containers at once")
 		}
 
 		// 2. Attach to the container.
@@ -154,6 +171,9 @@ func RunStart(ctx context.Context, dockerCli command.Cli, opts *StartOptions) er
 				// wait container to be removed
 				<-statusChan
 			}
+			if opts.Checkpoint != "" {
+				return command.WithCheckpointHint(err)
+			}
 			return err
 		}
 
@@ -176,14 +196,11 @@ func RunStart(ctx context.Context, dockerCli command.Cli, opts *StartOptions) er
 		}
 		return nil
 	case opts.Checkpoint != "":
-		if len(opts.Containers) > 1 {
-			return errors.New("you cannot restore multiple containers at once")
-		}
 		ctr := opts.Containers[0]
-		return dockerCli.Client().ContainerStart(ctx, ctr, container.StartOptions{
+		return command.WithCheckpointHint(dockerCli.Client().ContainerStart(ctx, ctr, container.StartOptions{
 			CheckpointID:  opts.Checkpoint,
 			CheckpointDir: opts.CheckpointDir,
-		})
+		}))
 	default:
 		// We're not going to attach to anything.
 		// Start as many containers as we want.
@@ -191,6 +208,97 @@ func RunStart(ctx context.Context, dockerCli command.Cli, opts *StartOptions) er
 	}
 }
 
+// startAndAttachMultiple starts multiple containers and multiplexes their
+// output, prefixing each line with the name of the container it originates
+// from. It waits for all containers to exit, and returns the exit code of the
+// first container that exited with a non-zero exit code.
+func startAndAttachMultiple(ctx context.Context, dockerCli command.Cli, containers []string) error {
+	stdouts, stderrs := newPrefixWriters(dockerCli, containers)
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		status int
+		errs   = make([]error, len(containers))
+	)
+	for i, ctr := range containers {
+		wg.Add(1)
+		go func(i int, ctr string) {
+			defer wg.Done()
+			exitCode, err := startAndAttachOutput(ctx, dockerCli, ctr, stdouts[i], stderrs[i])
+			_ = stdouts[i].Flush()
+			_ = stderrs[i].Flush()
+
+			mu.Lock()
+			defer mu.Unlock()
+			errs[i] = err
+			if err == nil && exitCode != 0 && status == 0 {
+				status = exitCode
+			}
+		}(i, ctr)
+	}
+	wg.Wait()
+
+	if err := containersError(containers, errs); err != nil {
+		return err
+	}
+	if status != 0 {
+		return cli.StatusError{StatusCode: status}
+	}
+	return nil
+}
+
+// startAndAttachOutput starts the container, and copies its output to stdout
+// and stderr until it exits. It returns the exit code of the container.
+func startAndAttachOutput(ctx context.Context, dockerCli command.Cli, ctr string, stdout, stderr io.Writer) (int, error) {
+	apiClient := dockerCli.Client()
+	c, err := apiClient.ContainerInspect(ctx, ctr)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := apiClient.ContainerAttach(ctx, c.ID, container.AttachOptions{
+		Stream: true,
+		Stdout: true,
+		Stderr: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Close()
+
+	if !c.Config.Tty {
+		sigc := notifyAllSignals()
+		go ForwardAllSignals(context.WithoutCancel(ctx), apiClient, c.ID, sigc)
+		defer signal.StopCatch(sigc)
+	}
+
+	copied := make(chan struct{})
+	go func() {
+		defer close(copied)
+		if c.Config.Tty {
+			_, _ = io.Copy(stdout, resp.Reader)
+		} else {
+			_, _ = stdcopy.StdCopy(stdout, stderr, resp.Reader)
+		}
+	}()
+
+	statusChan := waitExitOrRemoved(ctx, apiClient, c.ID, c.HostConfig.AutoRemove)
+	if err := apiClient.ContainerStart(ctx, c.ID, container.StartOptions{}); err != nil {
+		resp.Close()
+		<-copied
+		if c.HostConfig.AutoRemove {
+			// wait container to be removed
+			<-statusChan
+		}
+		return 0, err
+	}
+
+	exitCode := <-statusChan
+	<-copied
+	return exitCode, nil
+}
+
 func startContainersWithoutAttachments(ctx context.Context, dockerCli command.Cli, containers []string) error {
 	var failedContainers []string
 	for _, ctr := range containers {
@@ -207,3 +315,25 @@ func startContainersWithoutAttachments(ctx context.Context, dockerCli command.Cl
 	}
 	return nil
 }
+
+// validateCheckpoint verifies that the checkpoint to restore the container
+// from exists, to produce a more helpful error than the daemon does.
+func validateCheckpoint(ctx context.Context, dockerCli command.Cli, ctr string, opts *StartOptions) error {
+	checkpoints, err := dockerCli.Client().CheckpointList(ctx, ctr, checkpoint.ListOptions{
+		CheckpointDir: opts.CheckpointDir,
+	})
+	if err != nil {
+		return command.WithCheckpointHint(err)
+	}
+	names := make([]string, 0, len(checkpoints))
+	for _, cp := range checkpoints {
+		if cp.Name == opts.Checkpoint {
+			return nil
+		}
+		names = append(names, cp.Name)
+	}
+	if len(names) == 0 {
+		return errors.Errorf("checkpoint %s does not exist for container %s: the container has no checkpoints", opts.Checkpoint, ctr)
+	}
+	return errors.Errorf("checkpoint %s does not exist for container %s: available checkpoints are: %s", opts.Checkpoint, ctr, strings.Join(names, ", "))
+}
@@ -0,0 +1,148 @@
+package container
+
+import (
+	"context"
+	"io"
+	"net"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/checkpoint"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestStartCheckpoint(t *testing.T) {
+	var started container.StartOptions
+	cli := test.NewFakeCli(&fakeClient{
+		checkpointListFunc: func(_ string, options checkpoint.ListOptions) ([]checkpoint.Summary, error) {
+			assert.Check(t, is.Equal(options.CheckpointDir, "/dir/foo"))
+			return []checkpoint.Summary{{Name: "checkpoint-foo"}}, nil
+		},
+		containerStartFunc: func(_ string, options container.StartOptions) error {
+			started = options
+			return nil
+		},
+	})
+	cmd := NewStartCommand(cli)
+	cmd.SetArgs([]string{"--checkpoint", "checkpoint-foo", "--checkpoint-dir", "/dir/foo", "container-foo"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(started.CheckpointID, "checkpoint-foo"))
+	assert.Check(t, is.Equal(started.CheckpointDir, "/dir/foo"))
+}
+
+func TestStartCheckpointErrors(t *testing.T) {
+	testCases := []struct {
+		args          []string
+		checkpoints   []checkpoint.Summary
+		expectedError string
+	}{
+		{
+			args:          []string{"--checkpoint-dir", "/dir/foo", "container-foo"},
+			expectedError: "--checkpoint-dir can only be used with --checkpoint",
+		},
+		{
+			args:          []string{"--checkpoint", "checkpoint-foo", "container-foo", "container-bar"},
+			expectedError: "you cannot restore multiple containers at once",
+		},
+		{
+			args:          []string{"--checkpoint", "checkpoint-foo", "container-foo"},
+			expectedError: "checkpoint checkpoint-foo does not exist for container container-foo: the container has no checkpoints",
+		},
+		{
+			args:          []string{"--checkpoint", "checkpoint-foo", "container-foo"},
+			checkpoints:   []checkpoint.Summary{{Name: "checkpoint-bar"}, {Name: "checkpoint-baz"}},
+			expectedError: "checkpoint checkpoint-foo does not exist for container container-foo: available checkpoints are: checkpoint-bar, checkpoint-baz",
+		},
+	}
+	for _, tc := range testCases {
+		cli := test.NewFakeCli(&fakeClient{
+			checkpointListFunc: func(string, checkpoint.ListOptions) ([]checkpoint.Summary, error) {
+				return tc.checkpoints, nil
+			},
+			containerStartFunc: func(string, container.StartOptions) error {
+				t.Error("container should not be started")
+				return nil
+			},
+		})
+		cmd := NewStartCommand(cli)
+		cmd.SetArgs(tc.args)
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		assert.Check(t, is.Error(cmd.Execute(), tc.expectedError))
+	}
+}
+
+func TestStartAttachMultipleContainers(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		started []string
+	)
+	fakeCLI := test.NewFakeCli(&fakeClient{
+		inspectFunc: func(containerID string) (container.InspectResponse, error) {
+			return container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					ID:         containerID,
+					HostConfig: &container.HostConfig{},
+				},
+				Config: &container.Config{Tty: containerID == "tty"},
+			}, nil
+		},
+		containerAttachFunc: func(_ context.Context, containerID string, options container.AttachOptions) (types.HijackedResponse, error) {
+			assert.Check(t, !options.Stdin)
+			server, client := net.Pipe()
+			go func() {
+				defer server.Close()
+				if containerID == "tty" {
+					_, _ = io.WriteString(server, "hello from tty\n")
+					return
+				}
+				_, _ = io.WriteString(stdcopy.NewStdWriter(server, stdcopy.Stdout), "hello from stdout\n")
+				_, _ = io.WriteString(stdcopy.NewStdWriter(server, stdcopy.Stderr), "hello from stderr\n")
+			}()
+			return types.NewHijackedResponse(client, ""), nil
+		},
+		containerStartFunc: func(containerID string, _ container.StartOptions) error {
+			mu.Lock()
+			defer mu.Unlock()
+			started = append(started, containerID)
+			return nil
+		},
+		waitFunc: func(containerID string) (<-chan container.WaitResponse, <-chan error) {
+			resultC := make(chan container.WaitResponse, 1)
+			if containerID == "tty" {
+				resultC <- container.WaitResponse{StatusCode: 3}
+			} else {
+				resultC <- container.WaitResponse{}
+			}
+			return resultC, make(chan error)
+		},
+		Version: "1.36",
+	})
+	cmd := NewStartCommand(fakeCLI)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--attach", "web", "tty"})
+
+	err := cmd.Execute()
+	assert.Check(t, is.DeepEqual(err, cli.StatusError{StatusCode: 3}))
+	sort.Strings(started)
+	assert.Check(t, is.DeepEqual(started, []string{"tty", "web"}))
+	assert.Check(t, is.Contains(fakeCLI.OutBuffer().String(), "web | hello from stdout\n"))
+	assert.Check(t, is.Contains(fakeCLI.OutBuffer().String(), "tty | hello from tty\n"))
+	assert.Check(t, is.Equal(fakeCLI.ErrBuffer().String(), "web | hello from stderr\n"))
+}
+
+func TestStartInteractiveMultipleContainers(t *testing.T) {
+	cmd := NewStartCommand(test.NewFakeCli(&fakeClient{}))
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--attach", "--interactive", "web", "db"})
+	assert.Check(t, is.Error(cmd.Execute(), "you cannot start and attach the STDIN of multiple containers at once"))
+}
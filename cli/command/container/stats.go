@@ -36,9 +36,28 @@ type StatsOptions struct {
 	NoTrunc bool
 
 	// Format is a custom template to use for presenting the stats.
-	// Refer to [flagsHelper.FormatHelp] for accepted formats.
+	// Refer to [flagsHelper.FormatHelp] for accepted formats. When using
+	// the "json" format, one JSON object is printed per container and per
+	// sample, without clearing the screen in between.
 	Format string
 
+	// Prometheus is the address to serve the stats on in Prometheus format,
+	// instead of printing them. Metrics are available on the "/metrics" path.
+	Prometheus string
+
+	// Record is the path of a file to append samples of the stats to, instead
+	// of printing them. Samples are written as CSV or newline-delimited JSON,
+	// depending on RecordFormat.
+	Record string
+
+	// RecordFormat is the format to use for Record ("csv" or "json"). If not
+	// set, the format is detected from the file extension, and defaults to
+	// "json".
+	RecordFormat string
+
+	// RecordInterval is the interval at which samples are written to Record.
+	RecordInterval time.Duration
+
 	// Containers is the list of container names or IDs to include in the stats.
 	// If empty, all containers are included. It is mutually exclusive with the
 	// Filters option, and an error is produced if both are set.
@@ -85,6 +104,12 @@ func NewStatsCommand(dockerCLI command.Cli) *cobra.Command {
 	flags.BoolVar(&options.NoStream, "no-stream", false, "Disable streaming stats and only pull the first result")
 	flags.BoolVar(&options.NoTrunc, "no-trunc", false, "Do not truncate output")
 	flags.StringVar(&options.Format, "format", "", flagsHelper.FormatHelp)
+	flags.StringVar(&options.Prometheus, "prometheus", "", `Serve the stats in Prometheus format on the given address (e.g. ":9323")`)
+	flags.StringVar(&options.Record, "record", "", "Append samples of the stats to a file, instead of printing them")
+	flags.StringVar(&options.RecordFormat, "record-format", "", `Format of the record file ("csv" or "json"). Detected from the file extension if not set`)
+	flags.DurationVar(&options.RecordInterval, "record-interval", time.Second, "Interval between samples written to the record file")
+
+	_ = cmd.RegisterFlagCompletionFunc("record-format", completion.FromList(recordFormatCSV, recordFormatJSON))
 	return cmd
 }
 
@@ -104,6 +129,20 @@ func NewStatsCommand(dockerCLI command.Cli) *cobra.Command {
 //
 //nolint:gocyclo
 func RunStats(ctx context.Context, dockerCLI command.Cli, options *StatsOptions) error {
+	if options.Prometheus != "" && options.NoStream {
+		return errors.New("--prometheus and --no-stream can not be combined")
+	}
+	if options.Prometheus != "" && options.Record != "" {
+		return errors.New("--prometheus and --record can not be combined")
+	}
+	if options.Record != "" {
+		if _, err := statsRecordFormat(options.RecordFormat, options.Record); err != nil {
+			return err
+		}
+		if options.RecordInterval < 0 {
+			return errors.New("--record-interval must not be negative")
+		}
+	}
 	apiClient := dockerCLI.Client()
 
 	// waitFirst is a WaitGroup to wait first stat data's reach for each container
@@ -250,6 +289,13 @@ func RunStats(ctx context.Context, dockerCLI command.Cli, options *StatsOptions)
 		}
 	}
 
+	if options.Prometheus != "" {
+		return serveStatsMetrics(ctx, dockerCLI, options.Prometheus, &cStats, closeChan)
+	}
+	if options.Record != "" {
+		return recordStats(ctx, dockerCLI, options, &cStats, closeChan)
+	}
+
 	format := options.Format
 	if len(format) == 0 {
 		if len(dockerCLI.ConfigFile().StatsFormat) > 0 {
@@ -269,7 +315,9 @@ func RunStats(ctx context.Context, dockerCLI command.Cli, options *StatsOptions)
 		Format: NewStatsFormat(format, daemonOSType),
 	}
 	cleanScreen := func() {
-		if !options.NoStream {
+		// JSON is printed as a stream of samples (NDJSON), which should not
+		// be interleaved with escape sequences.
+		if !options.NoStream && format != formatter.JSONFormatKey {
 			_, _ = fmt.Fprint(dockerCLI.Out(), "\033[2J")
 			_, _ = fmt.Fprint(dockerCLI.Out(), "\033[H")
 		}
@@ -50,6 +50,50 @@ func (s *stats) isKnownContainer(cid string) (int, bool) {
 	return -1, false
 }
 
+// entries returns the latest statistics of each container.
+func (s *stats) entries() []StatsEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entries := make([]StatsEntry, 0, len(s.cs))
+	for _, c := range s.cs {
+		entries = append(entries, c.GetStatistics())
+	}
+	return entries
+}
+
+// watchStats calls fn every interval, until ctx is cancelled, fn returns an
+// error, or an error is received on errCh. fn is never called if interval is
+// zero. "unexpected EOF" errors received on errCh are not returned, so that
+// the CLI shuts down cleanly when the daemon restarts.
+func watchStats(ctx context.Context, errCh <-chan error, interval time.Duration, fn func() error) error {
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-tick:
+			if err := fn(); err != nil {
+				return err
+			}
+		case err, ok := <-errCh:
+			if !ok {
+				// No more asynchronous errors to expect.
+				errCh = nil
+				continue
+			}
+			if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+				return err
+			}
+			return nil
+		}
+	}
+}
+
 func collect(ctx context.Context, s *Stats, cli client.ContainerAPIClient, streamStats bool, waitFirst *sync.WaitGroup) {
 	logrus.Debugf("collecting stats for %s", s.Container)
 	var (
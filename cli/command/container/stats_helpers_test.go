@@ -1,11 +1,16 @@
 package container
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types/container"
 	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
 )
 
 func TestCalculateMemUsageUnixNoCache(t *testing.T) {
@@ -61,3 +66,25 @@ func inDelta(x, y, delta float64) func() (bool, string) {
 		return true, ""
 	}
 }
+
+func TestWatchStats(t *testing.T) {
+	errCh := make(chan error, 1)
+	ticks := 0
+	err := watchStats(context.Background(), errCh, time.Millisecond, func() error {
+		ticks++
+		if ticks == 3 {
+			errCh <- io.ErrUnexpectedEOF
+		}
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.Check(t, ticks >= 3)
+
+	errCh <- errors.New("daemon error")
+	assert.Check(t, is.Error(watchStats(context.Background(), errCh, 0, nil), "daemon error"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	close(errCh)
+	assert.NilError(t, watchStats(ctx, errCh, 0, nil))
+}
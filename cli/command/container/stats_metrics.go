@@ -0,0 +1,133 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var statsMetricLabels = []string{"id", "name"}
+
+// statsMetric describes how a metric is derived from a [StatsEntry].
+type statsMetric struct {
+	desc      *prometheus.Desc
+	valueType prometheus.ValueType
+	value     func(StatsEntry) float64
+}
+
+var statsMetrics = []statsMetric{
+	{
+		desc:      prometheus.NewDesc("docker_container_cpu_usage_ratio", "CPU usage of the container, where 1 is one fully used CPU.", statsMetricLabels, nil),
+		valueType: prometheus.GaugeValue,
+		value:     func(s StatsEntry) float64 { return s.CPUPercentage / 100 },
+	},
+	{
+		desc:      prometheus.NewDesc("docker_container_memory_usage_bytes", "Memory usage of the container.", statsMetricLabels, nil),
+		valueType: prometheus.GaugeValue,
+		value:     func(s StatsEntry) float64 { return s.Memory },
+	},
+	{
+		desc:      prometheus.NewDesc("docker_container_memory_limit_bytes", "Memory limit of the container.", statsMetricLabels, nil),
+		valueType: prometheus.GaugeValue,
+		value:     func(s StatsEntry) float64 { return s.MemoryLimit },
+	},
+	{
+		desc:      prometheus.NewDesc("docker_container_network_receive_bytes_total", "Bytes received by the container over the network.", statsMetricLabels, nil),
+		valueType: prometheus.CounterValue,
+		value:     func(s StatsEntry) float64 { return s.NetworkRx },
+	},
+	{
+		desc:      prometheus.NewDesc("docker_container_network_transmit_bytes_total", "Bytes sent by the container over the network.", statsMetricLabels, nil),
+		valueType: prometheus.CounterValue,
+		value:     func(s StatsEntry) float64 { return s.NetworkTx },
+	},
+	{
+		desc:      prometheus.NewDesc("docker_container_block_read_bytes_total", "Bytes read by the container from block devices.", statsMetricLabels, nil),
+		valueType: prometheus.CounterValue,
+		value:     func(s StatsEntry) float64 { return s.BlockRead },
+	},
+	{
+		desc:      prometheus.NewDesc("docker_container_block_write_bytes_total", "Bytes written by the container to block devices.", statsMetricLabels, nil),
+		valueType: prometheus.CounterValue,
+		value:     func(s StatsEntry) float64 { return s.BlockWrite },
+	},
+	{
+		desc:      prometheus.NewDesc("docker_container_pids", "Number of processes running in the container.", statsMetricLabels, nil),
+		valueType: prometheus.GaugeValue,
+		value:     func(s StatsEntry) float64 { return float64(s.PidsCurrent) },
+	},
+}
+
+// statsCollector is a [prometheus.Collector] exposing the latest statistics
+// collected for each container.
+type statsCollector struct {
+	stats *stats
+}
+
+func (c *statsCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, m := range statsMetrics {
+		ch <- m.desc
+	}
+}
+
+func (c *statsCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, e := range c.stats.entries() {
+		if e.IsInvalid || e.ID == "" {
+			continue
+		}
+		name := strings.TrimPrefix(e.Name, "/")
+		for _, m := range statsMetrics {
+			ch <- prometheus.MustNewConstMetric(m.desc, m.valueType, m.value(e), e.ID, name)
+		}
+	}
+}
+
+// serveStatsMetrics serves the statistics of the containers in Prometheus
+// format on addr until ctx is cancelled, or an error is received on errCh.
+func serveStatsMetrics(ctx context.Context, dockerCLI command.Cli, addr string, cStats *stats, errCh <-chan error) error {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(&statsCollector{stats: cStats})
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Stop serving the metrics if the server fails.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	srvErr := make(chan error, 1)
+	go func() {
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			srvErr <- err
+			cancel()
+		}
+	}()
+	_, _ = fmt.Fprintf(dockerCLI.Err(), "Serving container metrics on http://%s/metrics\n", addr)
+
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	if err := watchStats(ctx, errCh, 0, nil); err != nil {
+		return err
+	}
+	select {
+	case err := <-srvErr:
+		return err
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,54 @@
+package container
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestStatsCollector(t *testing.T) {
+	cStats := &stats{cs: []*Stats{
+		{StatsEntry: StatsEntry{
+			Container:     "web",
+			ID:            "abc123",
+			Name:          "/web",
+			CPUPercentage: 150,
+			Memory:        1024,
+			MemoryLimit:   4096,
+			NetworkRx:     10,
+			NetworkTx:     20,
+			BlockRead:     30,
+			BlockWrite:    40,
+			PidsCurrent:   5,
+		}},
+		{StatsEntry: StatsEntry{Container: "invalid", ID: "def456", IsInvalid: true}},
+	}}
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(&statsCollector{stats: cStats})
+
+	rec := httptest.NewRecorder()
+	promhttp.HandlerFor(registry, promhttp.HandlerOpts{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, rec.Code, http.StatusOK)
+
+	body := rec.Body.String()
+	for _, expected := range []string{
+		`docker_container_cpu_usage_ratio{id="abc123",name="web"} 1.5`,
+		`docker_container_memory_usage_bytes{id="abc123",name="web"} 1024`,
+		`docker_container_memory_limit_bytes{id="abc123",name="web"} 4096`,
+		`# TYPE docker_container_network_receive_bytes_total counter`,
+		`docker_container_network_receive_bytes_total{id="abc123",name="web"} 10`,
+		`docker_container_network_transmit_bytes_total{id="abc123",name="web"} 20`,
+		`docker_container_block_read_bytes_total{id="abc123",name="web"} 30`,
+		`docker_container_block_write_bytes_total{id="abc123",name="web"} 40`,
+		`docker_container_pids{id="abc123",name="web"} 5`,
+	} {
+		assert.Check(t, is.Contains(body, expected))
+	}
+	assert.Check(t, !strings.Contains(body, "def456"), "invalid stats should not be exported")
+}
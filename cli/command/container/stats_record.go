@@ -0,0 +1,188 @@
+package container
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/pkg/errors"
+)
+
+const (
+	recordFormatCSV  = "csv"
+	recordFormatJSON = "json"
+)
+
+var statsRecordHeader = []string{
+	"timestamp",
+	"container",
+	"id",
+	"name",
+	"cpu_percent",
+	"memory_usage_bytes",
+	"memory_limit_bytes",
+	"memory_percent",
+	"network_rx_bytes",
+	"network_tx_bytes",
+	"block_read_bytes",
+	"block_write_bytes",
+	"pids",
+}
+
+// statsRecord is a single sample of the statistics of a container, as
+// written to the record file.
+type statsRecord struct {
+	Timestamp        time.Time `json:"timestamp"`
+	Container        string    `json:"container"`
+	ID               string    `json:"id"`
+	Name             string    `json:"name"`
+	CPUPercentage    float64   `json:"cpu_percent"`
+	Memory           float64   `json:"memory_usage_bytes"`
+	MemoryLimit      float64   `json:"memory_limit_bytes"`
+	MemoryPercentage float64   `json:"memory_percent"`
+	NetworkRx        float64   `json:"network_rx_bytes"`
+	NetworkTx        float64   `json:"network_tx_bytes"`
+	BlockRead        float64   `json:"block_read_bytes"`
+	BlockWrite       float64   `json:"block_write_bytes"`
+	PidsCurrent      uint64    `json:"pids"`
+}
+
+func newStatsRecord(ts time.Time, e StatsEntry) statsRecord {
+	return statsRecord{
+		Timestamp:        ts,
+		Container:        e.Container,
+		ID:               e.ID,
+		Name:             strings.TrimPrefix(e.Name, "/"),
+		CPUPercentage:    e.CPUPercentage,
+		Memory:           e.Memory,
+		MemoryLimit:      e.MemoryLimit,
+		MemoryPercentage: e.MemoryPercentage,
+		NetworkRx:        e.NetworkRx,
+		NetworkTx:        e.NetworkTx,
+		BlockRead:        e.BlockRead,
+		BlockWrite:       e.BlockWrite,
+		PidsCurrent:      e.PidsCurrent,
+	}
+}
+
+func (r statsRecord) csv() []string {
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	return []string{
+		r.Timestamp.Format(time.RFC3339Nano),
+		r.Container,
+		r.ID,
+		r.Name,
+		strconv.FormatFloat(r.CPUPercentage, 'f', 2, 64),
+		f(r.Memory),
+		f(r.MemoryLimit),
+		strconv.FormatFloat(r.MemoryPercentage, 'f', 2, 64),
+		f(r.NetworkRx),
+		f(r.NetworkTx),
+		f(r.BlockRead),
+		f(r.BlockWrite),
+		strconv.FormatUint(r.PidsCurrent, 10),
+	}
+}
+
+// statsRecordFormat returns the format to use for the record file. If no
+// format is set, it is detected from the extension of the file, and defaults
+// to newline-delimited JSON.
+func statsRecordFormat(format, path string) (string, error) {
+	switch strings.ToLower(format) {
+	case "":
+		if strings.EqualFold(filepath.Ext(path), ".csv") {
+			return recordFormatCSV, nil
+		}
+		return recordFormatJSON, nil
+	case recordFormatCSV:
+		return recordFormatCSV, nil
+	case recordFormatJSON, "ndjson":
+		return recordFormatJSON, nil
+	default:
+		return "", errors.Errorf("invalid record format %q: must be %q or %q", format, recordFormatCSV, recordFormatJSON)
+	}
+}
+
+// writeStatsRecords writes a row for each valid entry in entries to w. The
+// header is only written for the csv format, and if header is true.
+func writeStatsRecords(w io.Writer, format string, ts time.Time, entries []StatsEntry, header bool) error {
+	switch format {
+	case recordFormatCSV:
+		cw := csv.NewWriter(w)
+		if header {
+			if err := cw.Write(statsRecordHeader); err != nil {
+				return err
+			}
+		}
+		for _, e := range entries {
+			if e.IsInvalid || e.ID == "" {
+				continue
+			}
+			if err := cw.Write(newStatsRecord(ts, e).csv()); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		enc := json.NewEncoder(w)
+		for _, e := range entries {
+			if e.IsInvalid || e.ID == "" {
+				continue
+			}
+			if err := enc.Encode(newStatsRecord(ts, e)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// recordStats appends the statistics of the containers to the file at path
+// every interval, until ctx is cancelled, or an error is received on errCh.
+// If [StatsOptions.NoStream] is set, a single sample is recorded.
+func recordStats(ctx context.Context, dockerCLI command.Cli, options *StatsOptions, cStats *stats, errCh <-chan error) error {
+	format, err := statsRecordFormat(options.RecordFormat, options.Record)
+	if err != nil {
+		return err
+	}
+	interval := options.RecordInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	f, err := os.OpenFile(options.Record, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return errors.Wrap(err, "failed to open record file")
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	header := fi.Size() == 0
+
+	if !options.NoStream {
+		_, _ = fmt.Fprintf(dockerCLI.Err(), "Recording container stats to %s every %s\n", options.Record, interval)
+	}
+
+	record := func() error {
+		if err := writeStatsRecords(f, format, time.Now().UTC(), cStats.entries(), header); err != nil {
+			return errors.Wrap(err, "failed to write record file")
+		}
+		header = false
+		return nil
+	}
+	if err := record(); err != nil || options.NoStream {
+		return err
+	}
+	return watchStats(ctx, errCh, interval, record)
+}
@@ -0,0 +1,72 @@
+package container
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestWriteStatsRecords(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	entries := []StatsEntry{
+		{
+			Container:        "web",
+			ID:               "abc123",
+			Name:             "/web",
+			CPUPercentage:    12.345,
+			Memory:           1024,
+			MemoryLimit:      4096,
+			MemoryPercentage: 25,
+			NetworkRx:        10,
+			NetworkTx:        20,
+			BlockRead:        30,
+			BlockWrite:       40,
+			PidsCurrent:      5,
+		},
+		{Container: "invalid", ID: "def456", IsInvalid: true},
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NilError(t, writeStatsRecords(&buf, recordFormatCSV, ts, entries, true))
+		assert.NilError(t, writeStatsRecords(&buf, recordFormatCSV, ts, entries, false))
+		const row = "2024-01-02T03:04:05Z,web,abc123,web,12.35,1024,4096,25.00,10,20,30,40,5\n"
+		expected := "timestamp,container,id,name,cpu_percent,memory_usage_bytes,memory_limit_bytes,memory_percent,network_rx_bytes,network_tx_bytes,block_read_bytes,block_write_bytes,pids\n" + row + row
+		assert.Check(t, is.Equal(buf.String(), expected))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NilError(t, writeStatsRecords(&buf, recordFormatJSON, ts, entries, true))
+		expected := `{"timestamp":"2024-01-02T03:04:05Z","container":"web","id":"abc123","name":"web","cpu_percent":12.345,"memory_usage_bytes":1024,"memory_limit_bytes":4096,"memory_percent":25,"network_rx_bytes":10,"network_tx_bytes":20,"block_read_bytes":30,"block_write_bytes":40,"pids":5}` + "\n"
+		assert.Check(t, is.Equal(buf.String(), expected))
+	})
+}
+
+func TestStatsRecordFormat(t *testing.T) {
+	tests := []struct {
+		format, path, expected, expectedErr string
+	}{
+		{path: "stats.csv", expected: recordFormatCSV},
+		{path: "stats.CSV", expected: recordFormatCSV},
+		{path: "stats.ndjson", expected: recordFormatJSON},
+		{path: "stats", expected: recordFormatJSON},
+		{format: "csv", path: "stats.log", expected: recordFormatCSV},
+		{format: "ndjson", path: "stats.csv", expected: recordFormatJSON},
+		{format: "xml", path: "stats.xml", expectedErr: `invalid record format "xml": must be "csv" or "json"`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.format+"/"+tc.path, func(t *testing.T) {
+			actual, err := statsRecordFormat(tc.format, tc.path)
+			if tc.expectedErr != "" {
+				assert.Check(t, is.Error(err, tc.expectedErr))
+				return
+			}
+			assert.NilError(t, err)
+			assert.Check(t, is.Equal(actual, tc.expected))
+		})
+	}
+}
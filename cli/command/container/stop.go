@@ -5,9 +5,9 @@
 	"fmt"
 	"strings"
 
-	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types/container"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -18,17 +18,18 @@ type stopOptions struct {
 	timeout        int
 	timeoutChanged bool
 
+	filter     opts.FilterOpt
 	containers []string
 }
 
 // NewStopCommand creates a new cobra.Command for `docker stop`
 func NewStopCommand(dockerCli command.Cli) *cobra.Command {
-	var opts stopOptions
+	opts := stopOptions{filter: opts.NewFilterOpt()}
 
 	cmd := &cobra.Command{
 		Use:   "stop [OPTIONS] CONTAINER [CONTAINER...]",
 		Short: "Stop one or more running containers",
-		Args:  cli.RequiresMinArgs(1),
+		Args:  requiresContainersOrFilter(&opts.filter),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.containers = args
 			opts.timeoutChanged = cmd.Flags().Changed("time")
@@ -43,6 +44,7 @@ func NewStopCommand(dockerCli command.Cli) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVarP(&opts.signal, "signal", "s", "", "Signal to send to the container")
 	flags.IntVarP(&opts.timeout, "time", "t", 0, "Seconds to wait before killing the container")
+	flags.Var(&opts.filter, "filter", `Stop all running containers matching the filter (e.g. "label=project=foo")`)
 
 	_ = cmd.RegisterFlagCompletionFunc("signal", completeSignals)
 
@@ -50,6 +52,12 @@ func NewStopCommand(dockerCli command.Cli) *cobra.Command {
 }
 
 func runStop(ctx context.Context, dockerCli command.Cli, opts *stopOptions) error {
+	containers, err := containersWithFilter(ctx, dockerCli.Client(), opts.containers, opts.filter.Value(), false)
+	if err != nil {
+		return err
+	}
+	opts.containers = containers
+
 	var timeout *int
 	if opts.timeoutChanged {
 		timeout = &opts.timeout
@@ -8,12 +8,16 @@
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/cli/command/formatter"
 	"github.com/docker/cli/cli/command/formatter/tabwriter"
+	flagsHelper "github.com/docker/cli/cli/flags"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 type topOptions struct {
 	container string
+	format    string
 
 	args []string
 }
@@ -23,7 +27,7 @@ func NewTopCommand(dockerCli command.Cli) *cobra.Command {
 	var opts topOptions
 
 	cmd := &cobra.Command{
-		Use:   "top CONTAINER [ps OPTIONS]",
+		Use:   "top [OPTIONS] CONTAINER [ps OPTIONS]",
 		Short: "Display the running processes of a container",
 		Args:  cli.RequiresMinArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -39,11 +43,16 @@ func NewTopCommand(dockerCli command.Cli) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.SetInterspersed(false)
+	flags.StringVar(&opts.format, "format", "", flagsHelper.FormatHelp)
 
 	return cmd
 }
 
 func runTop(ctx context.Context, dockerCli command.Cli, opts *topOptions) error {
+	if opts.format != "" {
+		return runTopFormat(ctx, dockerCli, opts)
+	}
+
 	procList, err := dockerCli.Client().ContainerTop(ctx, opts.container, opts.args)
 	if err != nil {
 		return err
@@ -58,3 +67,20 @@ func runTop(ctx context.Context, dockerCli command.Cli, opts *topOptions) error
 	w.Flush()
 	return nil
 }
+
+// runTopFormat collects a known set of columns for the processes of the
+// container, and prints them using the given format.
+func runTopFormat(ctx context.Context, dockerCli command.Cli, opts *topOptions) error {
+	if len(opts.args) > 0 {
+		return errors.New("ps options cannot be used with --format")
+	}
+	procList, err := dockerCli.Client().ContainerTop(ctx, opts.container, topPsArgs)
+	if err != nil {
+		return err
+	}
+	topCtx := formatter.Context{
+		Output: dockerCli.Out(),
+		Format: NewTopFormat(opts.format),
+	}
+	return TopFormatWrite(topCtx, procList)
+}
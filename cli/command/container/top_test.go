@@ -0,0 +1,84 @@
+package container
+
+import (
+	"io"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/container"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func topProcesses(_ string, arguments []string) (container.ContainerTopOKBody, error) {
+	if len(arguments) == 0 {
+		return container.ContainerTopOKBody{
+			Titles:    []string{"UID", "PID", "PPID", "C", "STIME", "TTY", "TIME", "CMD"},
+			Processes: [][]string{{"root", "1234", "1200", "0", "10:00", "?", "00:00:00", "nginx: master process"}},
+		}, nil
+	}
+	return container.ContainerTopOKBody{
+		Titles: []string{"PID", "PPID", "USER", "%CPU", "%MEM", "COMMAND"},
+		Processes: [][]string{
+			{"1234", "1200", "root", "0.0", "0.1", "nginx: master process"},
+			{"1301", "1234", "nginx", "1.5", "0.4", "nginx: worker process"},
+		},
+	}, nil
+}
+
+func TestTopFormat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{
+			name: "default",
+			args: []string{"foo"},
+			expected: `UID                 PID                 PPID                C                   STIME               TTY                 TIME                CMD
+root                1234                1200                0                   10:00               ?                   00:00:00            nginx: master process
+`,
+		},
+		{
+			name: "table",
+			args: []string{"--format", "table", "foo"},
+			expected: `PID       PPID      USER      %CPU      %MEM      COMMAND
+1234      1200      root      0.0       0.1       nginx: master process
+1301      1234      nginx     1.5       0.4       nginx: worker process
+`,
+		},
+		{
+			name: "template",
+			args: []string{"--format", "{{.PID}} {{.Command}}", "foo"},
+			expected: `1234 nginx: master process
+1301 nginx: worker process
+`,
+		},
+		{
+			name: "json",
+			args: []string{"--format", "json", "foo"},
+			expected: `{"CPU":"0.0","Command":"nginx: master process","Mem":"0.1","PID":"1234","PPID":"1200","User":"root"}
+{"CPU":"1.5","Command":"nginx: worker process","Mem":"0.4","PID":"1301","PPID":"1234","User":"nginx"}
+`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cli := test.NewFakeCli(&fakeClient{containerTopFunc: topProcesses})
+			cmd := NewTopCommand(cli)
+			cmd.SetArgs(tc.args)
+			assert.NilError(t, cmd.Execute())
+			assert.Check(t, is.Equal(cli.OutBuffer().String(), tc.expected))
+		})
+	}
+}
+
+func TestTopFormatWithPsOptions(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{containerTopFunc: topProcesses})
+	cmd := NewTopCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--format", "json", "foo", "aux"})
+	assert.Check(t, is.Error(cmd.Execute(), "ps options cannot be used with --format"))
+}
@@ -5,26 +5,29 @@
 	"fmt"
 	"strings"
 
-	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types/container"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 type unpauseOptions struct {
+	all    bool
+	filter opts.FilterOpt
+
 	containers []string
 }
 
 // NewUnpauseCommand creates a new cobra.Command for `docker unpause`
 func NewUnpauseCommand(dockerCli command.Cli) *cobra.Command {
-	var opts unpauseOptions
+	opts := unpauseOptions{filter: opts.NewFilterOpt()}
 
 	cmd := &cobra.Command{
-		Use:   "unpause CONTAINER [CONTAINER...]",
+		Use:   "unpause [OPTIONS] CONTAINER [CONTAINER...]",
 		Short: "Unpause all processes within one or more containers",
-		Args:  cli.RequiresMinArgs(1),
+		Args:  requiresContainersFilterOrAll(&opts.filter, &opts.all),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.containers = args
 			return runUnpause(cmd.Context(), dockerCli, &opts)
@@ -36,10 +39,21 @@ func NewUnpauseCommand(dockerCli command.Cli) *cobra.Command {
 			return ctr.State == "paused"
 		}),
 	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.all, "all", "a", false, "Unpause all paused containers (matching the filter, if any)")
+	flags.Var(&opts.filter, "filter", `Unpause all paused containers matching the filter (e.g. "label=project=foo")`)
+
 	return cmd
 }
 
 func runUnpause(ctx context.Context, dockerCli command.Cli, opts *unpauseOptions) error {
+	containers, err := containersWithStatus(ctx, dockerCli.Client(), opts.containers, opts.filter.Value(), opts.all, "paused")
+	if err != nil {
+		return err
+	}
+	opts.containers = containers
+
 	var errs []string
 	errChan := parallelOperation(ctx, opts.containers, dockerCli.Client().ContainerUnpause)
 	for _, ctr := range opts.containers {
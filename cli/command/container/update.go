@@ -5,7 +5,6 @@
 	"fmt"
 	"strings"
 
-	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
 	"github.com/docker/cli/opts"
@@ -33,20 +32,25 @@ type updateOptions struct {
 
 	nFlag int
 
+	filter     opts.FilterOpt
 	containers []string
 }
 
 // NewUpdateCommand creates a new cobra.Command for `docker update`
 func NewUpdateCommand(dockerCli command.Cli) *cobra.Command {
-	var options updateOptions
+	options := updateOptions{filter: opts.NewFilterOpt()}
 
 	cmd := &cobra.Command{
 		Use:   "update [OPTIONS] CONTAINER [CONTAINER...]",
 		Short: "Update configuration of one or more containers",
-		Args:  cli.RequiresMinArgs(1),
+		Args:  requiresContainersOrFilter(&options.filter),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			options.containers = args
 			options.nFlag = cmd.Flags().NFlag()
+			if cmd.Flags().Changed("filter") {
+				// --filter selects containers, and is not a configuration change.
+				options.nFlag--
+			}
 			return runUpdate(cmd.Context(), dockerCli, &options)
 		},
 		Annotations: map[string]string{
@@ -83,6 +87,8 @@ func NewUpdateCommand(dockerCli command.Cli) *cobra.Command {
 	flags.Var(&options.cpus, "cpus", "Number of CPUs")
 	flags.SetAnnotation("cpus", "version", []string{"1.29"})
 
+	flags.Var(&options.filter, "filter", `Update all containers matching the filter (e.g. "label=project=foo")`)
+
 	_ = cmd.RegisterFlagCompletionFunc("restart", completeRestartPolicies)
 
 	return cmd
@@ -95,6 +101,11 @@ func runUpdate(ctx context.Context, dockerCli command.Cli, options *updateOption
 		return errors.New("you must provide one or more flags when using this command")
 	}
 
+	containers, err := containersWithFilter(ctx, dockerCli.Client(), options.containers, options.filter.Value(), true)
+	if err != nil {
+		return err
+	}
+
 	var restartPolicy containertypes.RestartPolicy
 	if options.restartPolicy != "" {
 		restartPolicy, err = opts.ParseRestartPolicy(options.restartPolicy)
@@ -132,7 +143,7 @@ func runUpdate(ctx context.Context, dockerCli command.Cli, options *updateOption
 		warns []string
 		errs  []string
 	)
-	for _, ctr := range options.containers {
+	for _, ctr := range containers {
 		r, err := dockerCli.Client().ContainerUpdate(ctx, ctr, updateConfig)
 		if err != nil {
 			errs = append(errs, err.Error())
@@ -0,0 +1,51 @@
+package container
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/container"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestUpdateFilter(t *testing.T) {
+	var updated []string
+	cli := test.NewFakeCli(&fakeClient{
+		containerListFunc: func(options container.ListOptions) ([]container.Summary, error) {
+			assert.Check(t, options.All)
+			assert.Check(t, is.DeepEqual(options.Filters.Get("label"), []string{"project=foo"}))
+			return []container.Summary{
+				{ID: "id-web", Names: []string{"/web"}},
+				{ID: "id-db", Names: []string{"/db"}},
+			}, nil
+		},
+		containerUpdateFunc: func(containerID string, updateConfig container.UpdateConfig) (container.ContainerUpdateOKBody, error) {
+			assert.Check(t, is.Equal(updateConfig.Memory, int64(512*1024*1024)))
+			assert.Check(t, is.Equal(updateConfig.RestartPolicy.Name, container.RestartPolicyUnlessStopped))
+			updated = append(updated, containerID)
+			if containerID == "db" {
+				return container.ContainerUpdateOKBody{}, errors.New("cannot update container db")
+			}
+			return container.ContainerUpdateOKBody{}, nil
+		},
+	})
+	cmd := NewUpdateCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--filter", "label=project=foo", "--memory", "512m", "--restart", "unless-stopped"})
+	assert.Check(t, is.Error(cmd.Execute(), "cannot update container db"))
+	assert.Check(t, is.DeepEqual(updated, []string{"web", "db"}))
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "web\n"))
+}
+
+func TestUpdateFilterWithoutChanges(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{})
+	cmd := NewUpdateCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--filter", "label=project=foo"})
+	assert.Check(t, is.Error(cmd.Execute(), "you must provide one or more flags when using this command"))
+}
@@ -4,13 +4,17 @@
 	"context"
 	"errors"
 	"strconv"
+	"strings"
 
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/docker/client"
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
 )
 
 func waitExitOrRemoved(ctx context.Context, apiClient client.APIClient, containerID string, waitRemove bool) <-chan int {
@@ -164,3 +168,85 @@ func parallelOperation(ctx context.Context, containers []string, op func(ctx con
 	}()
 	return errChan
 }
+
+// requiresContainersOrFilter returns an argument validator for commands that
+// operate on the containers passed as arguments, or on the containers matching
+// filter, in which case no argument is required.
+func requiresContainersOrFilter(filter *opts.FilterOpt) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if filter.Value().Len() > 0 {
+			return nil
+		}
+		return cli.RequiresMinArgs(1)(cmd, args)
+	}
+}
+
+// requiresContainersFilterOrAll is like requiresContainersOrFilter, but no
+// arguments are accepted if all is set.
+func requiresContainersFilterOrAll(filter *opts.FilterOpt, all *bool) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if *all {
+			if len(args) > 0 {
+				return errors.New("conflicting options: cannot specify both --all and container names")
+			}
+			return nil
+		}
+		return requiresContainersOrFilter(filter)(cmd, args)
+	}
+}
+
+// containersWithFilter returns the given containers, followed by the names of
+// the containers matching the filter (if any), without duplicates. Stopped
+// containers are only included if all is set. An error is returned if the
+// resulting list is empty.
+func containersWithFilter(ctx context.Context, apiClient client.APIClient, containers []string, filter filters.Args, all bool) ([]string, error) {
+	if filter.Len() == 0 {
+		return containers, nil
+	}
+
+	list, err := apiClient.ContainerList(ctx, container.ListOptions{
+		All:     all,
+		Filters: filter,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := append([]string{}, containers...)
+	seen := make(map[string]struct{}, len(containers))
+	for _, c := range containers {
+		seen[c] = struct{}{}
+	}
+	for _, c := range list {
+		name := c.ID
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		if _, ok := seen[c.ID]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		result = append(result, name)
+	}
+	if len(result) == 0 {
+		return nil, errors.New("no containers match the given filter")
+	}
+	return result, nil
+}
+
+// containersWithStatus is like containersWithFilter, but only matches the
+// containers with the given status (unless the filter has its own "status"
+// filter). If all is set, all the containers with that status are matched
+// when no filter is given.
+func containersWithStatus(ctx context.Context, apiClient client.APIClient, containers []string, filter filters.Args, all bool, status string) ([]string, error) {
+	if !all && filter.Len() == 0 {
+		return containers, nil
+	}
+	filter = filter.Clone()
+	if !filter.Contains("status") {
+		filter.Add("status", status)
+	}
+	return containersWithFilter(ctx, apiClient, containers, filter, true)
+}
@@ -4,15 +4,33 @@
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
+// waitConditionHealthy waits for the health status of the container to be
+// "healthy". Unlike the other conditions, it is handled client-side.
+const waitConditionHealthy = "healthy"
+
+var waitConditions = []string{
+	string(container.WaitConditionNotRunning),
+	string(container.WaitConditionNextExit),
+	string(container.WaitConditionRemoved),
+	waitConditionHealthy,
+}
+
 type waitOptions struct {
+	timeout   time.Duration
+	condition string
+
 	containers []string
 }
 
@@ -21,7 +39,7 @@ func NewWaitCommand(dockerCli command.Cli) *cobra.Command {
 	var opts waitOptions
 
 	cmd := &cobra.Command{
-		Use:   "wait CONTAINER [CONTAINER...]",
+		Use:   "wait [OPTIONS] CONTAINER [CONTAINER...]",
 		Short: "Block until one or more containers stop, then print their exit codes",
 		Args:  cli.RequiresMinArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -34,18 +52,36 @@ func NewWaitCommand(dockerCli command.Cli) *cobra.Command {
 		ValidArgsFunction: completion.ContainerNames(dockerCli, false),
 	}
 
+	flags := cmd.Flags()
+	flags.DurationVar(&opts.timeout, "timeout", 0, "Maximum time to wait before failing (0 to wait indefinitely)")
+	flags.StringVar(&opts.condition, "condition", string(container.WaitConditionNotRunning), `Condition to wait for ("not-running", "next-exit", "removed", "healthy")`)
+
+	_ = cmd.RegisterFlagCompletionFunc("condition", completion.FromList(waitConditions...))
+
 	return cmd
 }
 
 func runWait(ctx context.Context, dockerCli command.Cli, opts *waitOptions) error {
+	if !isValidWaitCondition(opts.condition) {
+		return errors.Errorf("invalid condition %q: must be one of %s", opts.condition, strings.Join(waitConditions, ", "))
+	}
+	if opts.timeout < 0 {
+		return errors.Errorf("invalid timeout %s: must be positive", opts.timeout)
+	}
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+	}
+
 	var errs []string
 	for _, ctr := range opts.containers {
-		resultC, errC := dockerCli.Client().ContainerWait(ctx, ctr, "")
-
-		select {
-		case result := <-resultC:
-			_, _ = fmt.Fprintf(dockerCli.Out(), "%d\n", result.StatusCode)
-		case err := <-errC:
+		err := waitContainer(ctx, dockerCli, ctr, opts.condition)
+		if err != nil {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				errs = append(errs, fmt.Sprintf("timed out after %s waiting for container %s", opts.timeout, ctr))
+				break
+			}
 			errs = append(errs, err.Error())
 		}
 	}
@@ -54,3 +90,83 @@ func runWait(ctx context.Context, dockerCli command.Cli, opts *waitOptions) erro
 	}
 	return nil
 }
+
+func isValidWaitCondition(condition string) bool {
+	for _, c := range waitConditions {
+		if c == condition {
+			return true
+		}
+	}
+	return false
+}
+
+// waitContainer waits for the container to reach the given condition, and
+// prints its exit code, or its name for the "healthy" condition.
+func waitContainer(ctx context.Context, dockerCli command.Cli, ctr string, condition string) error {
+	if condition == waitConditionHealthy {
+		if err := waitContainerHealthy(ctx, dockerCli, ctr); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(dockerCli.Out(), ctr)
+		return nil
+	}
+
+	resultC, errC := dockerCli.Client().ContainerWait(ctx, ctr, container.WaitCondition(condition))
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case result := <-resultC:
+		_, _ = fmt.Fprintf(dockerCli.Out(), "%d\n", result.StatusCode)
+		return nil
+	case err := <-errC:
+		return err
+	}
+}
+
+// waitContainerHealthy blocks until the health status of the container is
+// "healthy". An error is returned if the container has no health check, or if
+// it stops before becoming healthy.
+func waitContainerHealthy(ctx context.Context, dockerCli command.Cli, ctr string) error {
+	since := time.Now()
+	c, err := dockerCli.Client().ContainerInspect(ctx, ctr)
+	if err != nil {
+		return err
+	}
+	if c.State == nil || c.State.Health == nil {
+		return errors.Errorf("container %s has no health check", ctr)
+	}
+	if c.State.Health.Status == container.Healthy {
+		return nil
+	}
+	if !c.State.Running {
+		return errors.Errorf("container %s is not running", ctr)
+	}
+
+	// Replay the events since the container was inspected, so that a status
+	// change between the inspect and the subscription is not missed.
+	eventChan, errChan := dockerCli.Client().Events(ctx, events.ListOptions{
+		Since: fmt.Sprintf("%d.%09d", since.Unix(), since.Nanosecond()),
+		Filters: filters.NewArgs(
+			filters.Arg("type", string(events.ContainerEventType)),
+			filters.Arg("container", c.ID),
+			filters.Arg("event", string(events.ActionHealthStatus)),
+			filters.Arg("event", string(events.ActionDie)),
+			filters.Arg("event", string(events.ActionDestroy)),
+		),
+	})
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e := <-eventChan:
+			switch e.Action {
+			case events.ActionHealthStatusHealthy:
+				return nil
+			case events.ActionDie, events.ActionDestroy:
+				return errors.Errorf("container %s stopped before becoming healthy", ctr)
+			}
+		case err := <-errChan:
+			return err
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package container
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestWaitTimeout(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		waitFunc: func(string) (<-chan container.WaitResponse, <-chan error) {
+			// Never returns.
+			return make(chan container.WaitResponse), make(chan error)
+		},
+	})
+	cmd := NewWaitCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--timeout", "10ms", "foo", "bar"})
+	assert.Check(t, is.Error(cmd.Execute(), "timed out after 10ms waiting for container foo"))
+}
+
+func TestWaitInvalidCondition(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{})
+	cmd := NewWaitCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--condition", "stopped", "foo"})
+	assert.Check(t, is.Error(cmd.Execute(), `invalid condition "stopped": must be one of not-running, next-exit, removed, healthy`))
+}
+
+func TestWaitHealthy(t *testing.T) {
+	inspect := func(status string) func(string) (container.InspectResponse, error) {
+		return func(string) (container.InspectResponse, error) {
+			return container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					ID: "container-id",
+					State: &container.State{
+						Running: true,
+						Health:  &container.Health{Status: status},
+					},
+				},
+			}, nil
+		}
+	}
+
+	t.Run("already healthy", func(t *testing.T) {
+		cli := test.NewFakeCli(&fakeClient{inspectFunc: inspect(container.Healthy)})
+		cmd := NewWaitCommand(cli)
+		cmd.SetArgs([]string{"--condition", "healthy", "foo"})
+		assert.NilError(t, cmd.Execute())
+		assert.Check(t, is.Equal(cli.OutBuffer().String(), "foo\n"))
+	})
+
+	t.Run("becomes healthy", func(t *testing.T) {
+		cli := test.NewFakeCli(&fakeClient{
+			inspectFunc: inspect(container.Starting),
+			eventsFunc: func(options events.ListOptions) (<-chan events.Message, <-chan error) {
+				assert.Check(t, is.DeepEqual(options.Filters.Get("container"), []string{"container-id"}))
+				eventC := make(chan events.Message, 2)
+				eventC <- events.Message{Action: events.ActionHealthStatusUnhealthy}
+				eventC <- events.Message{Action: events.ActionHealthStatusHealthy}
+				return eventC, make(chan error)
+			},
+		})
+		cmd := NewWaitCommand(cli)
+		cmd.SetArgs([]string{"--condition", "healthy", "foo"})
+		assert.NilError(t, cmd.Execute())
+		assert.Check(t, is.Equal(cli.OutBuffer().String(), "foo\n"))
+	})
+
+	t.Run("stops before healthy", func(t *testing.T) {
+		cli := test.NewFakeCli(&fakeClient{
+			inspectFunc: inspect(container.Starting),
+			eventsFunc: func(events.ListOptions) (<-chan events.Message, <-chan error) {
+				eventC := make(chan events.Message, 1)
+				eventC <- events.Message{Action: events.ActionDie}
+				return eventC, make(chan error)
+			},
+		})
+		cmd := NewWaitCommand(cli)
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		cmd.SetArgs([]string{"--condition", "healthy", "--timeout", time.Minute.String(), "foo"})
+		assert.Check(t, is.Error(cmd.Execute(), "container foo stopped before becoming healthy"))
+	})
+
+	t.Run("no health check", func(t *testing.T) {
+		cli := test.NewFakeCli(&fakeClient{inspectFunc: func(string) (container.InspectResponse, error) {
+			return container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{State: &container.State{Running: true}},
+			}, nil
+		}})
+		cmd := NewWaitCommand(cli)
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		cmd.SetArgs([]string{"--condition", "healthy", "foo"})
+		assert.Check(t, is.Error(cmd.Execute(), "container foo has no health check"))
+	})
+}
@@ -26,6 +26,8 @@
 	mountsHeader     = "MOUNTS"
 	localVolumes     = "LOCAL VOLUMES"
 	networksHeader   = "NETWORKS"
+	sizeRwHeader     = "SIZE RW"
+	sizeRootFsHeader = "SIZE ROOT FS"
 )
 
 // NewContainerFormat returns a Format for rendering using a Context
@@ -107,6 +109,8 @@ func NewContainerContext() *ContainerContext {
 		"State":        StateHeader,
 		"Status":       StatusHeader,
 		"Size":         SizeHeader,
+		"SizeRw":       sizeRwHeader,
+		"SizeRootFs":   sizeRootFsHeader,
 		"Labels":       LabelsHeader,
 		"Mounts":       mountsHeader,
 		"LocalVolumes": localVolumes,
@@ -231,10 +235,7 @@ func (c *ContainerContext) Status() string {
 
 // Size returns the container's size and virtual size (e.g. "2B (virtual 21.5MB)")
 func (c *ContainerContext) Size() string {
-	if c.FieldsUsed == nil {
-		c.FieldsUsed = map[string]any{}
-	}
-	c.FieldsUsed["Size"] = struct{}{}
+	c.markSizeUsed()
 	srw := units.HumanSizeWithPrecision(float64(c.c.SizeRw), 3)
 	sv := units.HumanSizeWithPrecision(float64(c.c.SizeRootFs), 3)
 
@@ -245,6 +246,27 @@ func (c *ContainerContext) Size() string {
 	return sf
 }
 
+// SizeRw returns the size in bytes of the files created or changed by the
+// container. Use the "humanSize" template function for a human-readable size.
+func (c *ContainerContext) SizeRw() int64 {
+	c.markSizeUsed()
+	return c.c.SizeRw
+}
+
+// SizeRootFs returns the total size in bytes of all the files in the
+// container, including the files of its image.
+func (c *ContainerContext) SizeRootFs() int64 {
+	c.markSizeUsed()
+	return c.c.SizeRootFs
+}
+
+func (c *ContainerContext) markSizeUsed() {
+	if c.FieldsUsed == nil {
+		c.FieldsUsed = map[string]any{}
+	}
+	c.FieldsUsed["Size"] = struct{}{}
+}
+
 // Labels returns a comma-separated string of labels present on the container.
 func (c *ContainerContext) Labels() string {
 	if c.c.Labels == nil {
@@ -422,6 +422,23 @@ func TestContainerContextWriteWithNoContainers(t *testing.T) {
 	}
 }
 
+func TestContainerContextWriteSize(t *testing.T) {
+	containers := []container.Summary{
+		{Names: []string{"/small"}, SizeRw: 10, SizeRootFs: 77800000},
+		{Names: []string{"/big"}, SizeRw: 2300000000, SizeRootFs: 2400000000},
+	}
+	out := bytes.NewBufferString("")
+	ctx := Context{
+		Format: "table {{.Names}}\t{{.SizeRw}}\t{{humanSize .SizeRw}}\t{{humanSize .SizeRootFs}}",
+		Output: out,
+	}
+	assert.NilError(t, ContainerWrite(ctx, containers))
+	assert.Check(t, is.Equal(out.String(), `NAMES     SIZE RW      SIZE RW   SIZE ROOT FS
+small     10           10B       77.8MB
+big       2300000000   2.3GB     2.4GB
+`))
+}
+
 func TestContainerContextWriteJSON(t *testing.T) {
 	unix := time.Now().Add(-65 * time.Second).Unix()
 	containers := []container.Summary{
@@ -443,6 +460,8 @@ func TestContainerContextWriteJSON(t *testing.T) {
 			"Ports":        "",
 			"RunningFor":   "About a minute ago",
 			"Size":         "0B",
+			"SizeRootFs":   float64(0),
+			"SizeRw":       float64(0),
 			"State":        "running",
 			"Status":       "",
 		},
@@ -459,6 +478,8 @@ func TestContainerContextWriteJSON(t *testing.T) {
 			"Ports":        "",
 			"RunningFor":   "About a minute ago",
 			"Size":         "0B",
+			"SizeRootFs":   float64(0),
+			"SizeRw":       float64(0),
 			"State":        "running",
 			"Status":       "",
 		},
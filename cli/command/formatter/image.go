@@ -1,9 +1,12 @@
 package formatter
 
 import (
+	"encoding/json"
+	"sort"
 	"strconv"
 	"time"
 
+	"github.com/containerd/platforms"
 	"github.com/distribution/reference"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/pkg/stringid"
@@ -18,12 +21,18 @@
 	repositoryHeader = "REPOSITORY"
 	tagHeader        = "TAG"
 	digestHeader     = "DIGEST"
+	platformHeader   = "PLATFORM"
 )
 
 // ImageContext contains image specific information required by the formatter, encapsulate a Context struct.
 type ImageContext struct {
 	Context
 	Digest bool
+
+	// Platforms adds a row for each platform of multi-platform images, after
+	// the rows of the image. It requires the images to be listed with their
+	// manifests.
+	Platforms bool
 }
 
 func isDangling(img image.Summary) bool {
@@ -101,15 +110,55 @@ func imageFormat(ctx ImageContext, images []image.Summary, format func(subContex
 		} else {
 			formatted = imageFormatTaggedAndDigest(ctx, img)
 		}
+		var manifests []image.ManifestSummary
+		if ctx.Platforms {
+			manifests = platformManifests(img)
+		}
 		for _, imageCtx := range formatted {
 			if err := format(imageCtx); err != nil {
 				return err
 			}
+			for i := range manifests {
+				if err := format(&imageContext{
+					trunc:    ctx.Trunc,
+					i:        img,
+					repo:     imageCtx.repo,
+					tag:      imageCtx.tag,
+					digest:   manifests[i].Descriptor.Digest.String(),
+					manifest: &manifests[i],
+				}); err != nil {
+					return err
+				}
+			}
 		}
 	}
 	return nil
 }
 
+// platformManifests returns the manifests of the platforms of a
+// multi-platform image that are available locally, sorted by platform. It
+// returns nil for single-platform images.
+func platformManifests(img image.Summary) []image.ManifestSummary {
+	var count int
+	var manifests []image.ManifestSummary
+	for _, m := range img.Manifests {
+		if m.Kind != image.ManifestKindImage || m.ImageData == nil {
+			continue
+		}
+		count++
+		if m.Available {
+			manifests = append(manifests, m)
+		}
+	}
+	if count < 2 {
+		return nil
+	}
+	sort.SliceStable(manifests, func(i, j int) bool {
+		return platforms.Format(manifests[i].ImageData.Platform) < platforms.Format(manifests[j].ImageData.Platform)
+	})
+	return manifests
+}
+
 func imageFormatTaggedAndDigest(ctx ImageContext, img image.Summary) []*imageContext {
 	repoTags := map[string][]string{}
 	repoDigests := map[string][]string{}
@@ -190,6 +239,10 @@ type imageContext struct {
 	repo   string
 	tag    string
 	digest string
+
+	// manifest is the manifest of the platform of the row, for the rows of
+	// the platforms of a multi-platform image.
+	manifest *image.ManifestSummary
 }
 
 func newImageContext() *imageContext {
@@ -199,6 +252,7 @@ func newImageContext() *imageContext {
 		"Repository":   repositoryHeader,
 		"Tag":          tagHeader,
 		"Digest":       digestHeader,
+		"Platform":     platformHeader,
 		"CreatedSince": CreatedSinceHeader,
 		"CreatedAt":    CreatedAtHeader,
 		"Size":         SizeHeader,
@@ -211,14 +265,27 @@ func newImageContext() *imageContext {
 }
 
 func (c *imageContext) MarshalJSON() ([]byte, error) {
-	return MarshalJSON(c)
+	m, err := marshalMap(c)
+	if err != nil {
+		return nil, err
+	}
+	// The platform is only set for the rows of the platforms of
+	// multi-platform images, listed with the "--platforms" option.
+	if c.manifest == nil {
+		delete(m, "Platform")
+	}
+	return json.Marshal(m)
 }
 
 func (c *imageContext) ID() string {
+	id := c.i.ID
+	if c.manifest != nil {
+		id = c.manifest.ID
+	}
 	if c.trunc {
-		return stringid.TruncateID(c.i.ID)
+		return stringid.TruncateID(id)
 	}
-	return c.i.ID
+	return id
 }
 
 func (c *imageContext) Repository() string {
@@ -233,6 +300,15 @@ func (c *imageContext) Digest() string {
 	return c.digest
 }
 
+// Platform returns the platform of the row of a platform of a multi-platform
+// image, or an empty string for the rows of images.
+func (c *imageContext) Platform() string {
+	if c.manifest == nil {
+		return ""
+	}
+	return platforms.Format(c.manifest.ImageData.Platform)
+}
+
 func (c *imageContext) CreatedSince() string {
 	createdAt := time.Unix(c.i.Created, 0)
 
@@ -248,10 +324,16 @@ func (c *imageContext) CreatedAt() string {
 }
 
 func (c *imageContext) Size() string {
+	if c.manifest != nil {
+		return units.HumanSizeWithPrecision(float64(c.manifest.Size.Total), 3)
+	}
 	return units.HumanSizeWithPrecision(float64(c.i.Size), 3)
 }
 
 func (c *imageContext) Containers() string {
+	if c.manifest != nil {
+		return strconv.Itoa(len(c.manifest.ImageData.Containers))
+	}
 	if c.i.Containers == -1 {
 		return "N/A"
 	}
@@ -264,18 +346,21 @@ func (c *imageContext) Containers() string {
 //
 // Deprecated: VirtualSize is deprecated, and equivalent to [imageContext.Size].
 func (c *imageContext) VirtualSize() string {
+	if c.manifest != nil {
+		return units.HumanSize(float64(c.manifest.Size.Total))
+	}
 	return units.HumanSize(float64(c.i.Size))
 }
 
 func (c *imageContext) SharedSize() string {
-	if c.i.SharedSize == -1 {
+	if c.manifest != nil || c.i.SharedSize == -1 {
 		return "N/A"
 	}
 	return units.HumanSize(float64(c.i.SharedSize))
 }
 
 func (c *imageContext) UniqueSize() string {
-	if c.i.Size == -1 || c.i.SharedSize == -1 {
+	if c.manifest != nil || c.i.Size == -1 || c.i.SharedSize == -1 {
 		return "N/A"
 	}
 	return units.HumanSize(float64(c.i.Size - c.i.SharedSize))
@@ -2,6 +2,7 @@
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
@@ -10,6 +11,7 @@
 	"github.com/docker/cli/internal/test"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/pkg/stringid"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"gotest.tools/v3/assert"
 	is "gotest.tools/v3/assert/cmp"
 )
@@ -375,3 +377,85 @@ func TestImageContextWriteWithNoImage(t *testing.T) {
 		})
 	}
 }
+
+func TestImageContextWritePlatforms(t *testing.T) {
+	amd64 := image.ManifestSummary{
+		ID:         "sha256:aaaa",
+		Descriptor: ocispec.Descriptor{Digest: "sha256:aaaa"},
+		Available:  true,
+		Kind:       image.ManifestKindImage,
+		ImageData:  &image.ImageProperties{Platform: ocispec.Platform{OS: "linux", Architecture: "amd64"}, Containers: []string{"c1"}},
+	}
+	arm64 := image.ManifestSummary{
+		ID:         "sha256:bbbb",
+		Descriptor: ocispec.Descriptor{Digest: "sha256:bbbb"},
+		Kind:       image.ManifestKindImage,
+		ImageData:  &image.ImageProperties{Platform: ocispec.Platform{OS: "linux", Architecture: "arm64"}},
+	}
+	images := []image.Summary{
+		{ID: "sha256:1111", RepoTags: []string{"multi:latest"}, Containers: -1, Manifests: []image.ManifestSummary{arm64, amd64}},
+		{ID: "sha256:2222", RepoTags: []string{"single:latest"}, Containers: -1, Manifests: []image.ManifestSummary{amd64}},
+	}
+
+	out := bytes.NewBufferString("")
+	err := ImageWrite(ImageContext{
+		Context: Context{
+			Format: NewImageFormat("{{.Repository}} {{.Platform}} {{.ID}} {{.Containers}}", false, false),
+			Output: out,
+		},
+		Platforms: true,
+	}, images)
+	assert.NilError(t, err)
+	expected := `multi  sha256:1111 N/A
+multi linux/amd64 sha256:aaaa 1
+single  sha256:2222 N/A
+`
+	assert.Equal(t, out.String(), expected)
+}
+
+func TestImageContextWritePlatformsJSON(t *testing.T) {
+	amd64 := image.ManifestSummary{
+		ID:         "sha256:aaaa",
+		Descriptor: ocispec.Descriptor{Digest: "sha256:aaaa"},
+		Available:  true,
+		Kind:       image.ManifestKindImage,
+		ImageData:  &image.ImageProperties{Platform: ocispec.Platform{OS: "linux", Architecture: "amd64"}},
+	}
+	arm64 := image.ManifestSummary{
+		ID:         "sha256:bbbb",
+		Descriptor: ocispec.Descriptor{Digest: "sha256:bbbb"},
+		Kind:       image.ManifestKindImage,
+		ImageData:  &image.ImageProperties{Platform: ocispec.Platform{OS: "linux", Architecture: "arm64"}},
+	}
+	images := []image.Summary{
+		{ID: "sha256:1111", RepoTags: []string{"multi:latest"}, Containers: -1, Manifests: []image.ManifestSummary{arm64, amd64}},
+		{ID: "sha256:2222", RepoTags: []string{"single:latest"}, Containers: -1, Manifests: []image.ManifestSummary{amd64}},
+	}
+
+	for _, withPlatforms := range []bool{false, true} {
+		out := bytes.NewBufferString("")
+		err := ImageWrite(ImageContext{
+			Context: Context{
+				Format: NewImageFormat("{{json .}}", false, false),
+				Output: out,
+			},
+			Platforms: withPlatforms,
+		}, images)
+		assert.NilError(t, err)
+
+		var platforms []any
+		dec := json.NewDecoder(out)
+		for dec.More() {
+			var m map[string]any
+			assert.NilError(t, dec.Decode(&m))
+			if p, ok := m["Platform"]; ok {
+				platforms = append(platforms, p)
+			}
+		}
+		if withPlatforms {
+			assert.Check(t, is.DeepEqual(platforms, []any{"linux/amd64"}))
+		} else {
+			assert.Check(t, is.Len(platforms, 0))
+		}
+	}
+}
@@ -6,28 +6,42 @@
 	"strings"
 	"time"
 
+	"github.com/distribution/reference"
+	manifesttypes "github.com/docker/cli/cli/manifest/types"
+	registryclient "github.com/docker/cli/cli/registry/client"
+	"github.com/docker/distribution"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/system"
 	"github.com/docker/docker/client"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 type fakeClient struct {
 	client.Client
-	imageTagFunc     func(string, string) error
-	imageSaveFunc    func(images []string) (io.ReadCloser, error)
-	imageRemoveFunc  func(image string, options image.RemoveOptions) ([]image.DeleteResponse, error)
-	imagePushFunc    func(ref string, options image.PushOptions) (io.ReadCloser, error)
-	infoFunc         func() (system.Info, error)
-	imagePullFunc    func(ref string, options image.PullOptions) (io.ReadCloser, error)
-	imagesPruneFunc  func(pruneFilter filters.Args) (image.PruneReport, error)
-	imageLoadFunc    func(input io.Reader, quiet bool) (image.LoadResponse, error)
-	imageListFunc    func(options image.ListOptions) ([]image.Summary, error)
-	imageInspectFunc func(img string) (image.InspectResponse, []byte, error)
-	imageImportFunc  func(source image.ImportSource, ref string, options image.ImportOptions) (io.ReadCloser, error)
-	imageHistoryFunc func(img string) ([]image.HistoryResponseItem, error)
-	imageBuildFunc   func(context.Context, io.Reader, types.ImageBuildOptions) (types.ImageBuildResponse, error)
+	imageTagFunc      func(string, string) error
+	imageSaveFunc     func(images []string) (io.ReadCloser, error)
+	imageRemoveFunc   func(image string, options image.RemoveOptions) ([]image.DeleteResponse, error)
+	imagePushFunc     func(ref string, options image.PushOptions) (io.ReadCloser, error)
+	infoFunc          func() (system.Info, error)
+	imagePullFunc     func(ref string, options image.PullOptions) (io.ReadCloser, error)
+	imagesPruneFunc   func(pruneFilter filters.Args) (image.PruneReport, error)
+	imageLoadFunc     func(input io.Reader, quiet bool) (image.LoadResponse, error)
+	imageListFunc     func(options image.ListOptions) ([]image.Summary, error)
+	imageInspectFunc  func(img string) (image.InspectResponse, []byte, error)
+	imageImportFunc   func(source image.ImportSource, ref string, options image.ImportOptions) (io.ReadCloser, error)
+	imageHistoryFunc  func(img string) ([]image.HistoryResponseItem, error)
+	imageBuildFunc    func(context.Context, io.Reader, types.ImageBuildOptions) (types.ImageBuildResponse, error)
+	containerListFunc func(options container.ListOptions) ([]container.Summary, error)
+
+	containerInspectFunc func(containerID string) (container.InspectResponse, error)
+	containerCreateFunc  func(config *container.Config, platform *ocispec.Platform) (container.CreateResponse, error)
+	containerExportFunc  func(containerID string) (io.ReadCloser, error)
+	containerRemoveFunc  func(containerID string, options container.RemoveOptions) error
 }
 
 func (cli *fakeClient) ImageTag(_ context.Context, img, ref string) error {
@@ -118,9 +132,81 @@ func (cli *fakeClient) ImageHistory(_ context.Context, img string) ([]image.Hist
 	return []image.HistoryResponseItem{{ID: img, Created: time.Now().Unix()}}, nil
 }
 
+func (cli *fakeClient) ContainerList(_ context.Context, options container.ListOptions) ([]container.Summary, error) {
+	if cli.containerListFunc != nil {
+		return cli.containerListFunc(options)
+	}
+	return []container.Summary{}, nil
+}
+
+func (cli *fakeClient) ContainerInspect(_ context.Context, containerID string) (container.InspectResponse, error) {
+	if cli.containerInspectFunc != nil {
+		return cli.containerInspectFunc(containerID)
+	}
+	return container.InspectResponse{}, nil
+}
+
+func (cli *fakeClient) ContainerCreate(_ context.Context, config *container.Config, _ *container.HostConfig, _ *network.NetworkingConfig, platform *ocispec.Platform, _ string) (container.CreateResponse, error) {
+	if cli.containerCreateFunc != nil {
+		return cli.containerCreateFunc(config, platform)
+	}
+	return container.CreateResponse{}, nil
+}
+
+func (cli *fakeClient) ContainerExport(_ context.Context, containerID string) (io.ReadCloser, error) {
+	if cli.containerExportFunc != nil {
+		return cli.containerExportFunc(containerID)
+	}
+	return io.NopCloser(strings.NewReader("")), nil
+}
+
+func (cli *fakeClient) ContainerRemove(_ context.Context, containerID string, options container.RemoveOptions) error {
+	if cli.containerRemoveFunc != nil {
+		return cli.containerRemoveFunc(containerID, options)
+	}
+	return nil
+}
+
 func (cli *fakeClient) ImageBuild(ctx context.Context, buildContext io.Reader, options types.ImageBuildOptions) (types.ImageBuildResponse, error) {
 	if cli.imageBuildFunc != nil {
 		return cli.imageBuildFunc(ctx, buildContext, options)
 	}
 	return types.ImageBuildResponse{Body: io.NopCloser(strings.NewReader(""))}, nil
 }
+
+type fakeRegistryClient struct {
+	getManifestFunc     func(ctx context.Context, ref reference.Named) (manifesttypes.ImageManifest, error)
+	getManifestListFunc func(ctx context.Context, ref reference.Named) ([]manifesttypes.ImageManifest, error)
+	getBlobFunc         func(ctx context.Context, ref reference.Named, dgst digest.Digest) ([]byte, error)
+}
+
+func (c *fakeRegistryClient) GetManifest(ctx context.Context, ref reference.Named) (manifesttypes.ImageManifest, error) {
+	if c.getManifestFunc != nil {
+		return c.getManifestFunc(ctx, ref)
+	}
+	return manifesttypes.ImageManifest{}, nil
+}
+
+func (c *fakeRegistryClient) GetManifestList(ctx context.Context, ref reference.Named) ([]manifesttypes.ImageManifest, error) {
+	if c.getManifestListFunc != nil {
+		return c.getManifestListFunc(ctx, ref)
+	}
+	return nil, nil
+}
+
+func (c *fakeRegistryClient) GetBlob(ctx context.Context, ref reference.Named, dgst digest.Digest) ([]byte, error) {
+	if c.getBlobFunc != nil {
+		return c.getBlobFunc(ctx, ref, dgst)
+	}
+	return nil, nil
+}
+
+func (*fakeRegistryClient) MountBlob(context.Context, reference.Canonical, reference.Named) error {
+	return nil
+}
+
+func (*fakeRegistryClient) PutManifest(context.Context, reference.Named, distribution.Manifest) (digest.Digest, error) {
+	return "", nil
+}
+
+var _ registryclient.RegistryClient = &fakeRegistryClient{}
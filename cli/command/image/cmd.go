@@ -16,13 +16,20 @@ func NewImageCommand(dockerCli command.Cli) *cobra.Command {
 	}
 	cmd.AddCommand(
 		NewBuildCommand(dockerCli),
+		NewExportOCICommand(dockerCli),
+		NewFlattenCommand(dockerCli),
 		NewHistoryCommand(dockerCli),
 		NewImportCommand(dockerCli),
+		NewImportOCICommand(dockerCli),
 		NewLoadCommand(dockerCli),
 		NewPullCommand(dockerCli),
 		NewPushCommand(dockerCli),
+		NewRelabelCommand(dockerCli),
 		NewSaveCommand(dockerCli),
+		NewSBOMCommand(dockerCli),
 		NewTagCommand(dockerCli),
+		NewTreeCommand(dockerCli),
+		NewUsersCommand(dockerCli),
 		newListCommand(dockerCli),
 		newRemoveCommand(dockerCli),
 		newInspectCommand(dockerCli),
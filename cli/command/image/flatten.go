@@ -0,0 +1,237 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/containerd/platforms"
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/opts"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/jsonmessage"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+)
+
+type flattenOptions struct {
+	source  string
+	target  string
+	message string
+	changes opts.ListOpts
+}
+
+// NewFlattenCommand creates a new `docker image flatten` command
+func NewFlattenCommand(dockerCli command.Cli) *cobra.Command {
+	options := flattenOptions{changes: opts.NewListOpts(nil)}
+
+	cmd := &cobra.Command{
+		Use:   "flatten [OPTIONS] SOURCE TARGET_IMAGE[:TAG]",
+		Short: "Create a single-layer image from the filesystem of a container or an image",
+		Args:  cli.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.source = args[0]
+			options.target = args[1]
+			return runFlatten(cmd.Context(), dockerCli, options)
+		},
+		ValidArgsFunction: completion.ImageNames(dockerCli),
+	}
+
+	flags := cmd.Flags()
+	flags.VarP(&options.changes, "change", "c", "Apply Dockerfile instruction to the created image")
+	flags.StringVarP(&options.message, "message", "m", "", "Set commit message for the created image")
+
+	return cmd
+}
+
+func runFlatten(ctx context.Context, dockerCli command.Cli, options flattenOptions) error {
+	apiClient := dockerCli.Client()
+
+	// The source is a container, or an image, in the same order as "docker
+	// inspect" looks for objects.
+	var (
+		config      *container.Config
+		imageID     string
+		containerID string
+		isImage     bool
+	)
+	ctr, err := apiClient.ContainerInspect(ctx, options.source)
+	switch {
+	case err == nil:
+		config, imageID, containerID = ctr.Config, ctr.Image, ctr.ID
+	case errdefs.IsNotFound(err):
+		isImage = true
+	default:
+		return err
+	}
+
+	var img image.InspectResponse
+	if isImage {
+		img, _, err = apiClient.ImageInspectWithRaw(ctx, options.source)
+		if err != nil {
+			return err
+		}
+		config, imageID = img.Config, img.ID
+	} else if imageID != "" {
+		// The image of the container is only used to get its platform.
+		img, _, _ = apiClient.ImageInspectWithRaw(ctx, imageID)
+	}
+	var platform *ocispec.Platform
+	if img.Os != "" && img.Architecture != "" {
+		platform = &ocispec.Platform{OS: img.Os, Architecture: img.Architecture, Variant: img.Variant}
+	}
+
+	if isImage {
+		// Images can only be exported through a container. The container is
+		// never started, so its command doesn't matter, but it's required for
+		// images without a command.
+		created, err := apiClient.ContainerCreate(ctx, &container.Config{Image: imageID, Cmd: []string{"flatten"}}, nil, nil, platform, "")
+		if err != nil {
+			return err
+		}
+		containerID = created.ID
+		defer func() {
+			_ = apiClient.ContainerRemove(context.WithoutCancel(ctx), containerID, container.RemoveOptions{Force: true})
+		}()
+	}
+
+	exported, err := apiClient.ContainerExport(ctx, containerID)
+	if err != nil {
+		return err
+	}
+	defer exported.Close()
+
+	// Changes passed on the command-line are applied last, so that they
+	// take precedence over the configuration of the source.
+	importOptions := image.ImportOptions{
+		Message: options.message,
+		Changes: append(configChanges(config), options.changes.GetAll()...),
+	}
+	if platform != nil {
+		importOptions.Platform = platforms.Format(*platform)
+	}
+	responseBody, err := apiClient.ImageImport(ctx, image.ImportSource{Source: exported, SourceName: "-"}, options.target, importOptions)
+	if err != nil {
+		return err
+	}
+	defer responseBody.Close()
+
+	return jsonmessage.DisplayJSONMessagesToStream(responseBody, dockerCli.Out(), nil)
+}
+
+// configChanges returns the Dockerfile instructions that set the
+// configuration on an imported image. The instructions that can't be applied
+// to an imported image, such as SHELL, are not included.
+func configChanges(config *container.Config) []string {
+	if config == nil {
+		return nil
+	}
+
+	var changes []string
+	for _, env := range config.Env {
+		k, v, _ := strings.Cut(env, "=")
+		changes = append(changes, "ENV "+k+"="+dockerfileQuote(v))
+	}
+	if len(config.Entrypoint) > 0 {
+		changes = append(changes, "ENTRYPOINT "+jsonArray(config.Entrypoint))
+	}
+	if len(config.Cmd) > 0 {
+		changes = append(changes, "CMD "+jsonArray(config.Cmd))
+	}
+	if config.WorkingDir != "" {
+		changes = append(changes, "WORKDIR "+config.WorkingDir)
+	}
+	if config.User != "" {
+		changes = append(changes, "USER "+config.User)
+	}
+	if len(config.ExposedPorts) > 0 {
+		ports := make([]string, 0, len(config.ExposedPorts))
+		for p := range config.ExposedPorts {
+			ports = append(ports, string(p))
+		}
+		sort.Strings(ports)
+		changes = append(changes, "EXPOSE "+strings.Join(ports, " "))
+	}
+	if len(config.Volumes) > 0 {
+		volumes := make([]string, 0, len(config.Volumes))
+		for v := range config.Volumes {
+			volumes = append(volumes, v)
+		}
+		sort.Strings(volumes)
+		changes = append(changes, "VOLUME "+jsonArray(volumes))
+	}
+	if len(config.Labels) > 0 {
+		keys := make([]string, 0, len(config.Labels))
+		for k := range config.Labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			changes = append(changes, "LABEL "+dockerfileQuote(k)+"="+dockerfileQuote(config.Labels[k]))
+		}
+	}
+	if config.StopSignal != "" {
+		changes = append(changes, "STOPSIGNAL "+config.StopSignal)
+	}
+	if hc := healthcheckChange(config.Healthcheck); hc != "" {
+		changes = append(changes, hc)
+	}
+	for _, instruction := range config.OnBuild {
+		changes = append(changes, "ONBUILD "+instruction)
+	}
+	return changes
+}
+
+// healthcheckChange returns the HEALTHCHECK instruction of a healthcheck, or
+// an empty string if the healthcheck is inherited.
+func healthcheckChange(hc *container.HealthConfig) string {
+	if hc == nil || len(hc.Test) == 0 {
+		return ""
+	}
+	var cmd string
+	switch hc.Test[0] {
+	case "NONE":
+		return "HEALTHCHECK NONE"
+	case "CMD":
+		cmd = jsonArray(hc.Test[1:])
+	case "CMD-SHELL":
+		cmd = strings.Join(hc.Test[1:], " ")
+	default:
+		return ""
+	}
+
+	var flags []string
+	if hc.Interval > 0 {
+		flags = append(flags, "--interval="+hc.Interval.String())
+	}
+	if hc.Timeout > 0 {
+		flags = append(flags, "--timeout="+hc.Timeout.String())
+	}
+	if hc.StartPeriod > 0 {
+		flags = append(flags, "--start-period="+hc.StartPeriod.String())
+	}
+	if hc.StartInterval > 0 {
+		flags = append(flags, "--start-interval="+hc.StartInterval.String())
+	}
+	if hc.Retries > 0 {
+		flags = append(flags, "--retries="+strconv.Itoa(hc.Retries))
+	}
+	return strings.Join(append(append([]string{"HEALTHCHECK"}, flags...), "CMD", cmd), " ")
+}
+
+// dockerfileQuote returns s as a double-quoted Dockerfile string, in which
+// variables are not expanded.
+func dockerfileQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`).Replace(s) + `"`
+}
+
+func jsonArray(s []string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
@@ -0,0 +1,166 @@
+package image
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestFlattenImage(t *testing.T) {
+	var removed []string
+	cli := test.NewFakeCli(&fakeClient{
+		containerInspectFunc: func(string) (container.InspectResponse, error) {
+			return container.InspectResponse{}, errdefs.NotFound(errors.New("no such container"))
+		},
+		imageInspectFunc: func(img string) (image.InspectResponse, []byte, error) {
+			assert.Check(t, is.Equal(img, "myapp:1.0"))
+			return image.InspectResponse{
+				ID:           "sha256:5e0da2bb4a93",
+				Os:           "linux",
+				Architecture: "arm64",
+				Config: &container.Config{
+					Env:        []string{"PATH=/usr/bin:/bin", "GREETING=say \"hi\" to $USER"},
+					Entrypoint: []string{"/app"},
+					Cmd:        []string{"--port", "8080"},
+					WorkingDir: "/srv",
+					Labels:     map[string]string{"b": "2", "a": "1"},
+				},
+			}, nil, nil
+		},
+		containerCreateFunc: func(config *container.Config, platform *ocispec.Platform) (container.CreateResponse, error) {
+			assert.Check(t, is.Equal(config.Image, "sha256:5e0da2bb4a93"))
+			assert.Check(t, is.DeepEqual(platform, &ocispec.Platform{OS: "linux", Architecture: "arm64"}))
+			return container.CreateResponse{ID: "tmp-container"}, nil
+		},
+		containerExportFunc: func(containerID string) (io.ReadCloser, error) {
+			assert.Check(t, is.Equal(containerID, "tmp-container"))
+			return io.NopCloser(strings.NewReader("rootfs")), nil
+		},
+		imageImportFunc: func(source image.ImportSource, ref string, options image.ImportOptions) (io.ReadCloser, error) {
+			content, err := io.ReadAll(source.Source)
+			assert.NilError(t, err)
+			assert.Check(t, is.Equal(string(content), "rootfs"))
+			assert.Check(t, is.Equal(ref, "myapp:flat"))
+			assert.Check(t, is.Equal(options.Platform, "linux/arm64"))
+			assert.Check(t, is.DeepEqual(options.Changes, []string{
+				`ENV PATH="/usr/bin:/bin"`,
+				`ENV GREETING="say \"hi\" to \$USER"`,
+				`ENTRYPOINT ["/app"]`,
+				`CMD ["--port","8080"]`,
+				`WORKDIR /srv`,
+				`LABEL "a"="1"`,
+				`LABEL "b"="2"`,
+				`USER nobody`,
+			}))
+			return io.NopCloser(strings.NewReader(`{"status":"sha256:8f1e3a2b"}` + "\n")), nil
+		},
+		containerRemoveFunc: func(containerID string, options container.RemoveOptions) error {
+			removed = append(removed, containerID)
+			return nil
+		},
+	})
+	cmd := NewFlattenCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{"--change", "USER nobody", "myapp:1.0", "myapp:flat"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "sha256:8f1e3a2b\n"))
+	assert.Check(t, is.DeepEqual(removed, []string{"tmp-container"}))
+}
+
+func TestFlattenContainer(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		containerInspectFunc: func(string) (container.InspectResponse, error) {
+			return container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{ID: "c0ffee", Image: "sha256:5e0da2bb4a93"},
+				Config: &container.Config{
+					ExposedPorts: nat.PortSet{"443/tcp": {}, "80/tcp": {}},
+					Volumes:      map[string]struct{}{"/data": {}},
+					StopSignal:   "SIGQUIT",
+					Healthcheck: &container.HealthConfig{
+						Test:     []string{"CMD-SHELL", "curl -f http://localhost/"},
+						Interval: 30 * time.Second,
+						Retries:  3,
+					},
+				},
+			}, nil
+		},
+		imageInspectFunc: func(img string) (image.InspectResponse, []byte, error) {
+			assert.Check(t, is.Equal(img, "sha256:5e0da2bb4a93"))
+			return image.InspectResponse{Os: "linux", Architecture: "amd64"}, nil, nil
+		},
+		containerCreateFunc: func(*container.Config, *ocispec.Platform) (container.CreateResponse, error) {
+			t.Error("unexpected container creation")
+			return container.CreateResponse{}, nil
+		},
+		containerExportFunc: func(containerID string) (io.ReadCloser, error) {
+			assert.Check(t, is.Equal(containerID, "c0ffee"))
+			return io.NopCloser(strings.NewReader("rootfs")), nil
+		},
+		imageImportFunc: func(source image.ImportSource, ref string, options image.ImportOptions) (io.ReadCloser, error) {
+			assert.Check(t, is.Equal(options.Message, "flattened"))
+			assert.Check(t, is.Equal(options.Platform, "linux/amd64"))
+			assert.Check(t, is.DeepEqual(options.Changes, []string{
+				`EXPOSE 443/tcp 80/tcp`,
+				`VOLUME ["/data"]`,
+				`STOPSIGNAL SIGQUIT`,
+				`HEALTHCHECK --interval=30s --retries=3 CMD curl -f http://localhost/`,
+			}))
+			return io.NopCloser(strings.NewReader("")), nil
+		},
+		containerRemoveFunc: func(string, container.RemoveOptions) error {
+			t.Error("unexpected container removal")
+			return nil
+		},
+	})
+	cmd := NewFlattenCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{"--message", "flattened", "web", "web:flat"})
+	assert.NilError(t, cmd.Execute())
+}
+
+func TestFlattenErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		args          []string
+		expectedError string
+	}{
+		{
+			name:          "no-target",
+			args:          []string{"myapp:1.0"},
+			expectedError: "requires 2 arguments",
+		},
+		{
+			name:          "not-found",
+			args:          []string{"missing", "myapp:flat"},
+			expectedError: "no such image",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := NewFlattenCommand(test.NewFakeCli(&fakeClient{
+				containerInspectFunc: func(string) (container.InspectResponse, error) {
+					return container.InspectResponse{}, errdefs.NotFound(errors.New("no such container"))
+				},
+				imageInspectFunc: func(string) (image.InspectResponse, []byte, error) {
+					return image.InspectResponse{}, nil, errdefs.NotFound(errors.New("no such image"))
+				},
+			}))
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(tc.args)
+			assert.ErrorContains(t, cmd.Execute(), tc.expectedError)
+		})
+	}
+}
@@ -18,6 +18,7 @@
 	historyIDHeader = "IMAGE"
 	createdByHeader = "CREATED BY"
 	commentHeader   = "COMMENT"
+	layerHeader     = "LAYER"
 )
 
 // NewHistoryFormat returns a format for rendering an HistoryContext
@@ -38,9 +39,18 @@ func NewHistoryFormat(source string, quiet bool, human bool) formatter.Format {
 
 // HistoryWrite writes the context
 func HistoryWrite(ctx formatter.Context, human bool, histories []image.HistoryResponseItem) error {
+	return historyWrite(ctx, human, histories, nil)
+}
+
+// historyWrite writes the context. layers are the digests of the layers
+// created by the history entries, if known, in the same order as histories.
+func historyWrite(ctx formatter.Context, human bool, histories []image.HistoryResponseItem, layers []string) error {
 	render := func(format func(subContext formatter.SubContext) error) error {
-		for _, history := range histories {
+		for i, history := range histories {
 			historyCtx := &historyContext{trunc: ctx.Trunc, h: history, human: human}
+			if layers != nil {
+				historyCtx.layer = layers[i]
+			}
 			if err := format(historyCtx); err != nil {
 				return err
 			}
@@ -55,6 +65,7 @@ func HistoryWrite(ctx formatter.Context, human bool, histories []image.HistoryRe
 		"CreatedBy":    createdByHeader,
 		"Size":         formatter.SizeHeader,
 		"Comment":      commentHeader,
+		"Layer":        layerHeader,
 	}
 	return ctx.Write(historyCtx, render)
 }
@@ -64,6 +75,7 @@ type historyContext struct {
 	trunc bool
 	human bool
 	h     image.HistoryResponseItem
+	layer string
 }
 
 func (c *historyContext) MarshalJSON() ([]byte, error) {
@@ -113,3 +125,27 @@ func (c *historyContext) Size() string {
 func (c *historyContext) Comment() string {
 	return c.h.Comment
 }
+
+// Layer returns the digest of the layer created by the history entry, or an
+// empty string if the entry didn't create a layer, or if it's unknown.
+func (c *historyContext) Layer() string {
+	if c.trunc {
+		return stringid.TruncateID(c.layer)
+	}
+	return c.layer
+}
+
+// historyLayers returns the digests of the layers created by the entries of
+// the history of an image, using the layers of the image. It returns nil if
+// the layers can't be matched with the history.
+func historyLayers(history []image.HistoryResponseItem, diffIDs []string) []string {
+	indexes := layerHistory(history, len(diffIDs))
+	if indexes == nil {
+		return nil
+	}
+	layers := make([]string, len(history))
+	for i, idx := range indexes {
+		layers[idx] = diffIDs[i]
+	}
+	return layers
+}
@@ -265,3 +265,14 @@ func TestHistoryContext_Table(t *testing.T) {
 		})
 	}
 }
+
+func TestHistoryLayers(t *testing.T) {
+	history := []image.HistoryResponseItem{
+		{Size: 10},
+		{Size: 0},
+		{Size: 20},
+	}
+	assert.DeepEqual(t, historyLayers(history, []string{"sha256:a", "sha256:b"}), []string{"sha256:b", "", "sha256:a"})
+	assert.Check(t, historyLayers(history, []string{"sha256:a"}) == nil)
+	assert.Check(t, historyLayers(history, []string{"sha256:a", "sha256:b", "sha256:c"}) == nil)
+}
@@ -2,12 +2,14 @@
 
 import (
 	"context"
+	"strings"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
 	"github.com/docker/cli/cli/command/formatter"
 	flagsHelper "github.com/docker/cli/cli/flags"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -30,6 +32,9 @@ func NewHistoryCommand(dockerCli command.Cli) *cobra.Command {
 		Args:  cli.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.image = args[0]
+			if cmd.Flags().Changed("human") && opts.human && formatter.Format(opts.format).IsJSON() {
+				return errors.New("conflicting options: cannot specify both --human and --format json")
+			}
 			return runHistory(cmd.Context(), dockerCli, opts)
 		},
 		ValidArgsFunction: completion.ImageNames(dockerCli),
@@ -59,10 +64,26 @@ func runHistory(ctx context.Context, dockerCli command.Cli, opts historyOptions)
 		format = formatter.TableFormatKey
 	}
 
+	human, trunc := opts.human, !opts.noTrunc
+	if formatter.Format(format).IsJSON() {
+		// The JSON output is meant for tooling, so it includes the full
+		// commands, digests, and sizes in bytes.
+		human, trunc = false, false
+	}
+
+	var layers []string
+	if formatter.Format(format).IsJSON() || strings.Contains(format, ".Layer") {
+		img, _, err := dockerCli.Client().ImageInspectWithRaw(ctx, opts.image)
+		if err != nil {
+			return err
+		}
+		layers = historyLayers(history, img.RootFS.Layers)
+	}
+
 	historyCtx := formatter.Context{
 		Output: dockerCli.Out(),
-		Format: NewHistoryFormat(format, opts.quiet, opts.human),
-		Trunc:  !opts.noTrunc,
+		Format: NewHistoryFormat(format, opts.quiet, human),
+		Trunc:  trunc,
 	}
-	return HistoryWrite(historyCtx, opts.human, history)
+	return historyWrite(historyCtx, human, history, layers)
 }
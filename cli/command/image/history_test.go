@@ -33,6 +33,11 @@ func TestNewHistoryCommandErrors(t *testing.T) {
 				return []image.HistoryResponseItem{{}}, errors.Errorf("something went wrong")
 			},
 		},
+		{
+			name:          "human-and-json",
+			args:          []string{"--human", "--format", "json", "image:tag"},
+			expectedError: "conflicting options: cannot specify both --human and --format json",
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -107,3 +112,38 @@ func TestNewHistoryCommandSuccess(t *testing.T) {
 		})
 	}
 }
+
+func TestNewHistoryCommandJSON(t *testing.T) {
+	t.Setenv("TZ", "UTC")
+	cli := test.NewFakeCli(&fakeClient{
+		imageHistoryFunc: func(img string) ([]image.HistoryResponseItem, error) {
+			return []image.HistoryResponseItem{
+				{
+					ID:        "<missing>",
+					Created:   time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC).Unix(),
+					CreatedBy: `/bin/sh -c #(nop)  CMD ["/bin/sh" "-c" "echo hello world, this is a long command"]`,
+				},
+				{
+					ID:        "sha256:a606584aa9aa875552092ec9e1d62cb98d486f51f389609914039aabd9414687",
+					Created:   time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC).Unix(),
+					CreatedBy: "/bin/sh -c #(nop) ADD file:37a76ec18f9887751cd8473744917d08b7431fc4085097bb6a09d81b41775473 in /",
+					Size:      7797760,
+					Comment:   "imported",
+				},
+			}, nil
+		},
+		imageInspectFunc: func(img string) (image.InspectResponse, []byte, error) {
+			return image.InspectResponse{
+				RootFS: image.RootFS{
+					Type:   "layers",
+					Layers: []string{"sha256:d4fc045c9e3a848011de66f34b81f052d4f2c15a17bb196d637e526349601820"},
+				},
+			}, nil, nil
+		},
+	})
+	cmd := NewHistoryCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{"--format", "json", "image:tag"})
+	assert.NilError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "history-command-success.json.golden")
+}
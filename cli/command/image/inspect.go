@@ -11,12 +11,15 @@
 	"github.com/docker/cli/cli/command/completion"
 	"github.com/docker/cli/cli/command/inspect"
 	flagsHelper "github.com/docker/cli/cli/flags"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 type inspectOptions struct {
-	format string
-	refs   []string
+	format   string
+	refs     []string
+	remote   bool
+	platform string
 }
 
 // newInspectCommand creates a new cobra.Command for `docker image inspect`
@@ -36,10 +39,22 @@ func newInspectCommand(dockerCli command.Cli) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.StringVarP(&opts.format, "format", "f", "", flagsHelper.InspectFormatHelp)
+	flags.BoolVar(&opts.remote, "remote", false, "Inspect the image in the registry, without pulling it")
+	flags.StringVar(&opts.platform, "platform", "", "Inspect the image for this platform, for multi-platform images (with --remote)")
 	return cmd
 }
 
 func runInspect(ctx context.Context, dockerCli command.Cli, opts inspectOptions) error {
+	if opts.platform != "" && !opts.remote {
+		return errors.New("--platform can only be used with --remote")
+	}
+	if opts.remote {
+		return inspect.Inspect(dockerCli.Out(), opts.refs, opts.format, func(ref string) (any, []byte, error) {
+			resp, err := inspectRemote(ctx, dockerCli, ref, opts.platform)
+			return resp, nil, err
+		})
+	}
+
 	client := dockerCli.Client()
 	getRefFunc := func(ref string) (any, []byte, error) {
 		return client.ImageInspectWithRaw(ctx, ref)
@@ -0,0 +1,131 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/containerd/platforms"
+	"github.com/distribution/reference"
+	"github.com/docker/cli/cli/command"
+	manifesttypes "github.com/docker/cli/cli/manifest/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// remoteImageConfig is the part of the image configuration that is shown by
+// "docker image inspect --remote".
+type remoteImageConfig struct {
+	Created       *time.Time        `json:"created,omitempty"`
+	Author        string            `json:"author,omitempty"`
+	Architecture  string            `json:"architecture"`
+	Variant       string            `json:"variant,omitempty"`
+	OS            string            `json:"os"`
+	OSVersion     string            `json:"os.version,omitempty"`
+	Config        *container.Config `json:"config,omitempty"`
+	DockerVersion string            `json:"docker_version,omitempty"`
+	Comment       string            `json:"comment,omitempty"`
+	RootFS        struct {
+		Type    string   `json:"type"`
+		DiffIDs []string `json:"diff_ids"`
+	} `json:"rootfs"`
+}
+
+// inspectRemote returns the details of an image in a registry, in the same
+// format as the details of a local image, without pulling the image. For
+// multi-platform images, the image for the given platform, or for the platform
+// of the daemon if empty, is returned.
+func inspectRemote(ctx context.Context, dockerCli command.Cli, ref string, platform string) (image.InspectResponse, error) {
+	namedRef, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return image.InspectResponse{}, err
+	}
+	namedRef = reference.TagNameOnly(namedRef)
+
+	var p ocispec.Platform
+	if platform != "" {
+		if p, err = platforms.Parse(platform); err != nil {
+			return image.InspectResponse{}, err
+		}
+	} else {
+		p = platforms.DefaultSpec()
+		if osType := dockerCli.ServerInfo().OSType; osType != "" {
+			p.OS = osType
+		}
+	}
+
+	registryClient := dockerCli.RegistryClient(false)
+	var manifests []manifesttypes.ImageManifest
+	if m, err := registryClient.GetManifest(ctx, namedRef); err == nil {
+		manifests = []manifesttypes.ImageManifest{m}
+	} else if manifests, err = registryClient.GetManifestList(ctx, namedRef); err != nil {
+		return image.InspectResponse{}, err
+	}
+
+	matcher := platforms.Only(p)
+	available := make([]string, 0, len(manifests))
+	for _, m := range manifests {
+		if m.Descriptor.Platform == nil {
+			continue
+		}
+		if (len(manifests) == 1 && platform == "") || matcher.Match(*m.Descriptor.Platform) {
+			return remoteInspectResponse(namedRef, m)
+		}
+		available = append(available, platforms.Format(*m.Descriptor.Platform))
+	}
+	return image.InspectResponse{}, errors.Errorf("image %s is not available for platform %s (available: %s)", reference.FamiliarString(namedRef), platforms.Format(p), strings.Join(available, ", "))
+}
+
+func remoteInspectResponse(namedRef reference.Named, m manifesttypes.ImageManifest) (image.InspectResponse, error) {
+	var cfg remoteImageConfig
+	if err := json.Unmarshal(m.Config, &cfg); err != nil {
+		return image.InspectResponse{}, errors.Wrap(err, "invalid image config")
+	}
+
+	var (
+		configDigest digest.Digest
+		size         int64
+	)
+	switch {
+	case m.SchemaV2Manifest != nil:
+		configDigest = m.SchemaV2Manifest.Config.Digest
+		for _, l := range m.SchemaV2Manifest.Layers {
+			size += l.Size
+		}
+	case m.OCIManifest != nil:
+		configDigest = m.OCIManifest.Config.Digest
+		for _, l := range m.OCIManifest.Layers {
+			size += l.Size
+		}
+	}
+
+	resp := image.InspectResponse{
+		ID:            configDigest.String(),
+		RepoTags:      []string{},
+		RepoDigests:   []string{reference.FamiliarName(namedRef) + "@" + m.Descriptor.Digest.String()},
+		Comment:       cfg.Comment,
+		DockerVersion: cfg.DockerVersion,
+		Author:        cfg.Author,
+		Config:        cfg.Config,
+		Architecture:  cfg.Architecture,
+		Variant:       cfg.Variant,
+		Os:            cfg.OS,
+		OsVersion:     cfg.OSVersion,
+		Size:          size,
+		RootFS: image.RootFS{
+			Type:   cfg.RootFS.Type,
+			Layers: cfg.RootFS.DiffIDs,
+		},
+	}
+	if tagged, ok := namedRef.(reference.NamedTagged); ok {
+		resp.RepoTags = append(resp.RepoTags, reference.FamiliarString(tagged))
+	}
+	if cfg.Created != nil {
+		resp.Created = cfg.Created.Format(time.RFC3339Nano)
+	}
+	return resp, nil
+}
@@ -1,12 +1,20 @@
 package image
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"testing"
 
+	"github.com/distribution/reference"
+	manifesttypes "github.com/docker/cli/cli/manifest/types"
 	"github.com/docker/cli/internal/test"
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/schema2"
 	"github.com/docker/docker/api/types/image"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
 	"gotest.tools/v3/assert"
 	is "gotest.tools/v3/assert/cmp"
 	"gotest.tools/v3/golden"
@@ -93,3 +101,89 @@ func TestNewInspectCommandSuccess(t *testing.T) {
 		})
 	}
 }
+
+func remoteImageManifest(t *testing.T, ref reference.Named, arch string) manifesttypes.ImageManifest {
+	t.Helper()
+	config := []byte(`{"architecture":"` + arch + `","os":"linux","created":"2024-05-01T10:00:00Z","config":{"Cmd":["/bin/sh"]},"rootfs":{"type":"layers","diff_ids":["sha256:d4fc045c9e3a848011de66f34b81f052d4f2c15a17bb196d637e526349601820"]}}`)
+	man, err := schema2.FromStruct(schema2.Manifest{
+		Versioned: schema2.SchemaVersion,
+		Config: distribution.Descriptor{
+			Digest:    digest.FromBytes(config),
+			Size:      int64(len(config)),
+			MediaType: schema2.MediaTypeImageConfig,
+		},
+		Layers: []distribution.Descriptor{
+			{
+				MediaType: schema2.MediaTypeLayer,
+				Size:      3623807,
+				Digest:    "sha256:4abcf20661432fb2d719aaf90656f55c287f8ca915dc1c92ec14ff61e67fbaf8",
+			},
+		},
+	})
+	assert.NilError(t, err)
+	mt, raw, err := man.Payload()
+	assert.NilError(t, err)
+
+	m := manifesttypes.NewImageManifest(ref, ocispec.Descriptor{
+		Digest:    digest.FromBytes(raw),
+		Size:      int64(len(raw)),
+		MediaType: mt,
+		Platform:  &ocispec.Platform{Architecture: arch, OS: "linux"},
+	}, man)
+	m.Config = config
+	return m
+}
+
+func TestNewInspectCommandRemote(t *testing.T) {
+	testCases := []struct {
+		name          string
+		args          []string
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "platform",
+			args:     []string{"--remote", "--platform", "linux/arm64", "--format", "{{.Architecture}} {{.Size}} {{.RepoTags}} {{.RootFS.Layers}}", "alpine"},
+			expected: "arm64 3623807 [alpine:latest] [sha256:d4fc045c9e3a848011de66f34b81f052d4f2c15a17bb196d637e526349601820]\n",
+		},
+		{
+			name:          "platform-not-available",
+			args:          []string{"--remote", "--platform", "linux/s390x", "alpine"},
+			expectedError: "image alpine:latest is not available for platform linux/s390x (available: linux/amd64, linux/arm64)",
+		},
+		{
+			name:          "platform-without-remote",
+			args:          []string{"--platform", "linux/arm64", "alpine"},
+			expectedError: "--platform can only be used with --remote",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cli := test.NewFakeCli(&fakeClient{})
+			cli.SetRegistryClient(&fakeRegistryClient{
+				getManifestFunc: func(context.Context, reference.Named) (manifesttypes.ImageManifest, error) {
+					return manifesttypes.ImageManifest{}, errors.New("is a manifest list")
+				},
+				getManifestListFunc: func(_ context.Context, ref reference.Named) ([]manifesttypes.ImageManifest, error) {
+					assert.Check(t, is.Equal(ref.String(), "docker.io/library/alpine:latest"))
+					return []manifesttypes.ImageManifest{
+						remoteImageManifest(t, ref, "amd64"),
+						remoteImageManifest(t, ref, "arm64"),
+					}, nil
+				},
+			})
+			cmd := newInspectCommand(cli)
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(tc.args)
+			err := cmd.Execute()
+			if tc.expectedError != "" {
+				assert.Error(t, err, tc.expectedError)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Check(t, is.Equal(cli.OutBuffer().String(), tc.expected))
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/cli/command/formatter/tabwriter"
+	"github.com/docker/cli/opts"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+type layerTreeOptions struct {
+	filter opts.FilterOpt
+	layers bool
+}
+
+// layerNode is a layer in the tree of layers of the local images. The parent
+// of a layer is the layer below it in the images that have it.
+type layerNode struct {
+	DiffID string
+	// Size is the size of the layer, or -1 if it's unknown.
+	Size     int64
+	Images   []string
+	Children []*layerNode
+}
+
+// NewTreeCommand creates a new `docker image tree` command
+func NewTreeCommand(dockerCli command.Cli) *cobra.Command {
+	options := layerTreeOptions{filter: opts.NewFilterOpt()}
+
+	cmd := &cobra.Command{
+		Use:   "tree [OPTIONS]",
+		Short: "Show the layers shared by local images as a tree",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLayerTree(cmd.Context(), dockerCli, options)
+		},
+		ValidArgsFunction: completion.NoComplete,
+	}
+
+	flags := cmd.Flags()
+	flags.VarP(&options.filter, "filter", "f", "Only show the images matching the filter, and their layers")
+	flags.BoolVar(&options.layers, "layers", false, "Show every layer, instead of grouping layers that aren't shared")
+
+	return cmd
+}
+
+func runLayerTree(ctx context.Context, dockerCli command.Cli, options layerTreeOptions) error {
+	apiClient := dockerCli.Client()
+	images, err := apiClient.ImageList(ctx, image.ListOptions{Filters: options.filter.Value()})
+	if err != nil {
+		return err
+	}
+
+	root := &layerNode{}
+	var totalSize int64
+	for _, img := range images {
+		inspect, _, err := apiClient.ImageInspectWithRaw(ctx, img.ID)
+		if err != nil {
+			return err
+		}
+		history, err := apiClient.ImageHistory(ctx, img.ID)
+		if err != nil {
+			return err
+		}
+		root.add(inspect.RootFS.Layers, layerSizes(history, len(inspect.RootFS.Layers)), imageNames(img))
+		totalSize += img.Size
+	}
+
+	if err := printLayerTree(dockerCli.Out(), root, !options.layers); err != nil {
+		return err
+	}
+	if size := root.totalSize(); size >= 0 && len(images) > 0 {
+		_, _ = fmt.Fprintf(dockerCli.Out(), "\nTotal size of layers: %s (%s without sharing)\n", humanSize(size), humanSize(totalSize))
+	}
+	return nil
+}
+
+// layerHistory returns the index of the history entry that created each of
+// the layers of an image, from the base layer, or nil if the layers can't be
+// matched with the history. Only history entries with a non-zero size are
+// considered to create a layer, so images with empty layers can't be matched.
+func layerHistory(history []image.HistoryResponseItem, layers int) []int {
+	indexes := make([]int, 0, layers)
+	// The history is sorted from the most recent entry.
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Size > 0 {
+			indexes = append(indexes, i)
+		}
+	}
+	if len(indexes) != layers {
+		return nil
+	}
+	return indexes
+}
+
+// layerSizes returns the sizes of the layers of an image, using the history
+// of the image, or nil if the layers can't be matched with the history.
+func layerSizes(history []image.HistoryResponseItem, layers int) []int64 {
+	indexes := layerHistory(history, layers)
+	if indexes == nil {
+		return nil
+	}
+	sizes := make([]int64, len(indexes))
+	for i, idx := range indexes {
+		sizes[i] = history[idx].Size
+	}
+	return sizes
+}
+
+// imageNames returns the tags of an image, or its short ID if it has none.
+func imageNames(img image.Summary) []string {
+	var names []string
+	for _, tag := range img.RepoTags {
+		if tag != "<none>:<none>" {
+			names = append(names, tag)
+		}
+	}
+	if len(names) == 0 {
+		return []string{"<none> (" + stringid.TruncateID(img.ID) + ")"}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// add adds the layers of an image under n, and adds the names of the image to
+// its top layer.
+func (n *layerNode) add(diffIDs []string, sizes []int64, names []string) {
+	if len(diffIDs) == 0 {
+		return
+	}
+	node := n
+	for i, diffID := range diffIDs {
+		var child *layerNode
+		for _, c := range node.Children {
+			if c.DiffID == diffID {
+				child = c
+				break
+			}
+		}
+		if child == nil {
+			child = &layerNode{DiffID: diffID, Size: -1}
+			node.Children = append(node.Children, child)
+		}
+		if child.Size < 0 && sizes != nil {
+			child.Size = sizes[i]
+		}
+		node = child
+	}
+	node.Images = append(node.Images, names...)
+}
+
+// firstImage returns the name of the first image that uses n, which is used
+// to sort layers.
+func (n *layerNode) firstImage() string {
+	first := ""
+	if len(n.Images) > 0 {
+		first = n.Images[0]
+	}
+	for _, c := range n.Children {
+		if name := c.firstImage(); name != "" && (first == "" || name < first) {
+			first = name
+		}
+	}
+	return first
+}
+
+// totalSize returns the size of n and of all the layers above it, or -1 if
+// the size of any of the layers is unknown.
+func (n *layerNode) totalSize() int64 {
+	size := n.Size
+	if n.DiffID == "" {
+		size = 0
+	}
+	for _, c := range n.Children {
+		s := c.totalSize()
+		if size < 0 || s < 0 {
+			return -1
+		}
+		size += s
+	}
+	return size
+}
+
+// segment returns the layers from n up to the first layer that is the top
+// layer of an image, or that is shared by several images. If collapse is
+// false, only n is returned.
+func (n *layerNode) segment(collapse bool) []*layerNode {
+	layers := []*layerNode{n}
+	for collapse && len(n.Images) == 0 && len(n.Children) == 1 {
+		n = n.Children[0]
+		layers = append(layers, n)
+	}
+	return layers
+}
+
+func printLayerTree(out io.Writer, root *layerNode, collapse bool) error {
+	w := tabwriter.NewWriter(out, 10, 1, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "LAYER\tSIZE\tIMAGES")
+	printLayerChildren(w, root, "", collapse)
+	return w.Flush()
+}
+
+func printLayerChildren(w io.Writer, n *layerNode, indent string, collapse bool) {
+	children := make([]*layerNode, len(n.Children))
+	copy(children, n.Children)
+	sort.SliceStable(children, func(i, j int) bool {
+		return children[i].firstImage() < children[j].firstImage()
+	})
+
+	for i, c := range children {
+		branch, next := "├─ ", "│  "
+		if i == len(children)-1 {
+			branch, next = "└─ ", "   "
+		}
+		if n.DiffID == "" {
+			// Layers at the bottom of the tree are not indented.
+			branch, next = "", ""
+		}
+
+		layers := c.segment(collapse)
+		top := layers[len(layers)-1]
+		var size int64
+		for _, l := range layers {
+			if l.Size < 0 {
+				size = -1
+				break
+			}
+			size += l.Size
+		}
+		name := stringid.TruncateID(top.DiffID)
+		if len(layers) > 1 {
+			name += fmt.Sprintf(" (%d layers)", len(layers))
+		}
+		sizeStr := "-"
+		if size >= 0 {
+			sizeStr = humanSize(size)
+		}
+		_, _ = fmt.Fprintf(w, "%s%s%s\t%s\t%s\n", indent, branch, name, sizeStr, strings.Join(top.Images, ", "))
+		printLayerChildren(w, top, indent+next, collapse)
+	}
+}
+
+func humanSize(size int64) string {
+	return units.HumanSizeWithPrecision(float64(size), 3)
+}
@@ -0,0 +1,87 @@
+package image
+
+import (
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/image"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/golden"
+)
+
+func TestLayerTree(t *testing.T) {
+	type testImage struct {
+		tags    []string
+		layers  []string
+		history []int64
+	}
+	images := map[string]testImage{
+		"sha256:ubuntu": {
+			tags:    []string{"ubuntu:22.04", "ubuntu:latest"},
+			layers:  []string{"sha256:aaaaaaaaaaaaaaaa"},
+			history: []int64{0, 77_800_000},
+		},
+		"sha256:app": {
+			tags:    []string{"app:latest"},
+			layers:  []string{"sha256:aaaaaaaaaaaaaaaa", "sha256:bbbbbbbbbbbbbbbb", "sha256:cccccccccccccccc"},
+			history: []int64{0, 12_000_000, 0, 3_000_000, 77_800_000},
+		},
+		"sha256:app-dev": {
+			tags:    []string{"app:dev"},
+			layers:  []string{"sha256:aaaaaaaaaaaaaaaa", "sha256:bbbbbbbbbbbbbbbb", "sha256:dddddddddddddddd", "sha256:gggggggggggggggg"},
+			history: []int64{2_000_000, 0, 45_000_000, 3_000_000, 77_800_000},
+		},
+		"sha256:0123456789abcdef": {
+			layers:  []string{"sha256:aaaaaaaaaaaaaaaa", "sha256:bbbbbbbbbbbbbbbb", "sha256:cccccccccccccccc", "sha256:eeeeeeeeeeeeeeee"},
+			history: []int64{1_000, 12_000_000, 3_000_000, 77_800_000},
+		},
+		"sha256:alpine": {
+			tags:    []string{"alpine:latest"},
+			layers:  []string{"sha256:ffffffffffffffff"},
+			history: []int64{7_800_000},
+		},
+	}
+
+	cli := test.NewFakeCli(&fakeClient{
+		imageListFunc: func(image.ListOptions) ([]image.Summary, error) {
+			var list []image.Summary
+			for _, id := range []string{"sha256:alpine", "sha256:app", "sha256:app-dev", "sha256:ubuntu", "sha256:0123456789abcdef"} {
+				var size int64
+				for _, s := range images[id].history {
+					size += s
+				}
+				list = append(list, image.Summary{ID: id, RepoTags: images[id].tags, Size: size})
+			}
+			return list, nil
+		},
+		imageInspectFunc: func(img string) (image.InspectResponse, []byte, error) {
+			return image.InspectResponse{ID: img, RootFS: image.RootFS{Type: "layers", Layers: images[img].layers}}, nil, nil
+		},
+		imageHistoryFunc: func(img string) ([]image.HistoryResponseItem, error) {
+			var history []image.HistoryResponseItem
+			for _, s := range images[img].history {
+				history = append(history, image.HistoryResponseItem{Size: s})
+			}
+			return history, nil
+		},
+	})
+
+	cmd := NewTreeCommand(cli)
+	cmd.SetArgs([]string{})
+	assert.NilError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "image-tree.golden")
+
+	cli.OutBuffer().Reset()
+	cmd = NewTreeCommand(cli)
+	cmd.SetArgs([]string{"--layers"})
+	assert.NilError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "image-tree-layers.golden")
+}
+
+func TestLayerSizes(t *testing.T) {
+	history := []image.HistoryResponseItem{{Size: 10}, {Size: 0}, {Size: 20}}
+	assert.Check(t, is.DeepEqual(layerSizes(history, 2), []int64{20, 10}))
+	// The history of images with empty layers can't be matched with the layers.
+	assert.Check(t, is.Nil(layerSizes(history, 3)))
+}
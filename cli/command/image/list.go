@@ -5,6 +5,7 @@
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
@@ -15,6 +16,8 @@
 	"github.com/spf13/cobra"
 )
 
+const defaultImagePlatformsTableFormat = "table {{.Repository}}\t{{.Tag}}\t{{.Platform}}\t{{.Digest}}\t{{.ID}}\t{{if .CreatedSince }}{{.CreatedSince}}{{else}}N/A{{end}}\t{{.Size}}"
+
 type imagesOptions struct {
 	matchName string
 
@@ -22,10 +25,12 @@ type imagesOptions struct {
 	all         bool
 	noTrunc     bool
 	showDigests bool
+	platforms   bool
 	format      string
 	filter      opts.FilterOpt
 	calledAs    string
 	tree        bool
+	group       bool
 }
 
 // NewImagesCommand creates a new `docker images` command
@@ -58,9 +63,13 @@ func NewImagesCommand(dockerCLI command.Cli) *cobra.Command {
 	flags.BoolVarP(&options.all, "all", "a", false, "Show all images (default hides intermediate images)")
 	flags.BoolVar(&options.noTrunc, "no-trunc", false, "Don't truncate output")
 	flags.BoolVar(&options.showDigests, "digests", false, "Show digests")
+	flags.BoolVar(&options.platforms, "platforms", false, "Show the platforms of multi-platform images, with their digests and sizes")
+	flags.SetAnnotation("platforms", "version", []string{"1.47"})
 	flags.StringVar(&options.format, "format", "", flagsHelper.FormatHelp)
 	flags.VarP(&options.filter, "filter", "f", "Filter output based on conditions provided")
 
+	flags.BoolVar(&options.group, "group", false, "Group the images by repository (use \"--all\" to list the tags of each repository)")
+
 	flags.BoolVar(&options.tree, "tree", false, "List multi-platform images as a tree (EXPERIMENTAL)")
 	flags.SetAnnotation("tree", "version", []string{"1.47"})
 	flags.SetAnnotation("tree", "experimentalCLI", nil)
@@ -76,7 +85,10 @@ func newListCommand(dockerCLI command.Cli) *cobra.Command {
 }
 
 func runImages(ctx context.Context, dockerCLI command.Cli, options imagesOptions) error {
-	filters := options.filter.Value()
+	filters, clientFilters, err := splitFilters(options.filter.Value(), time.Now())
+	if err != nil {
+		return err
+	}
 	if options.matchName != "" {
 		filters.Add("reference", options.matchName)
 	}
@@ -94,20 +106,53 @@ func runImages(ctx context.Context, dockerCLI command.Cli, options imagesOptions
 		if options.format != "" {
 			return errors.New("--format is not yet supported with --tree")
 		}
+		if options.platforms {
+			return errors.New("--platforms is not supported with --tree")
+		}
+		if options.group {
+			return errors.New("--group is not supported with --tree")
+		}
 
 		return runTree(ctx, dockerCLI, treeOptions{
-			all:     options.all,
-			filters: filters,
+			all:           options.all,
+			filters:       filters,
+			clientFilters: clientFilters,
 		})
 	}
 
+	if options.group {
+		if options.quiet {
+			return errors.New("--quiet is not supported with --group")
+		}
+		if options.showDigests {
+			return errors.New("--digests is not supported with --group")
+		}
+		if options.platforms {
+			return errors.New("--platforms is not supported with --group")
+		}
+
+		return runGroup(ctx, dockerCLI, groupOptions{
+			all:           options.all,
+			noTrunc:       options.noTrunc,
+			format:        options.format,
+			filters:       filters,
+			clientFilters: clientFilters,
+		})
+	}
+
+	if options.platforms && options.quiet {
+		return errors.New("--quiet is not supported with --platforms")
+	}
+
 	images, err := dockerCLI.Client().ImageList(ctx, image.ListOptions{
-		All:     options.all,
-		Filters: filters,
+		All:       options.all,
+		Filters:   filters,
+		Manifests: options.platforms,
 	})
 	if err != nil {
 		return err
 	}
+	images = clientFilters.filter(images)
 
 	format := options.format
 	if len(format) == 0 {
@@ -117,14 +162,18 @@ func runImages(ctx context.Context, dockerCLI command.Cli, options imagesOptions
 			format = formatter.TableFormatKey
 		}
 	}
+	if options.platforms && format == formatter.TableFormatKey {
+		format = defaultImagePlatformsTableFormat
+	}
 
 	imageCtx := formatter.ImageContext{
 		Context: formatter.Context{
 			Output: dockerCLI.Out(),
-			Format: formatter.NewImageFormat(format, options.quiet, options.showDigests),
+			Format: formatter.NewImageFormat(format, options.quiet, options.showDigests || options.platforms),
 			Trunc:  !options.noTrunc,
 		},
-		Digest: options.showDigests,
+		Digest:    options.showDigests || options.platforms,
+		Platforms: options.platforms,
 	}
 	if err := formatter.ImageWrite(imageCtx, images); err != nil {
 		return err
@@ -0,0 +1,146 @@
+package image
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+)
+
+// labelPattern matches the value of a label with a regular expression.
+type labelPattern struct {
+	key   string
+	value *regexp.Regexp
+}
+
+// clientFilters are the image filters that are not supported by the daemon,
+// and that are applied to the images returned by the daemon:
+//
+//   - "reference!=<pattern>" excludes the tags matching the pattern, and the
+//     images that have no tags left.
+//   - "label~=<key>=<regexp>" only keeps the images that have the label, with
+//     a value matching the regular expression.
+//   - "since=<duration>" and "until=<duration>" only keep the images created
+//     after, or before, the given duration ago.
+type clientFilters struct {
+	excludeRefs []string
+	labels      []labelPattern
+	since       time.Time
+	until       time.Time
+}
+
+// splitFilters returns the filters to send to the daemon, and the filters to
+// apply to the images it returns.
+func splitFilters(args filters.Args, now time.Time) (filters.Args, clientFilters, error) {
+	var cf clientFilters
+	server := args.Clone()
+
+	for _, pattern := range server.Get("reference!") {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return server, cf, errdefs.InvalidParameter(errors.Wrapf(err, "invalid reference pattern: %s", pattern))
+		}
+		cf.excludeRefs = append(cf.excludeRefs, pattern)
+		server.Del("reference!", pattern)
+	}
+
+	for _, value := range server.Get("label~") {
+		key, expr, ok := strings.Cut(value, "=")
+		if !ok || key == "" {
+			return server, cf, errdefs.InvalidParameter(errors.Errorf("invalid label~ filter: %s (expected <key>=<regexp>)", value))
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return server, cf, errdefs.InvalidParameter(errors.Wrapf(err, "invalid label~ filter: %s", value))
+		}
+		cf.labels = append(cf.labels, labelPattern{key: key, value: re})
+		server.Del("label~", value)
+	}
+
+	for _, key := range []string{"since", "until"} {
+		for _, value := range server.Get(key) {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				// Not a duration; leave it to the daemon.
+				continue
+			}
+			if key == "since" {
+				cf.since = now.Add(-d)
+			} else {
+				cf.until = now.Add(-d)
+			}
+			server.Del(key, value)
+		}
+	}
+	return server, cf, nil
+}
+
+// filter returns the images matching the filters. The tags of the images are
+// updated to exclude the tags matching a "reference!" filter.
+func (cf clientFilters) filter(images []image.Summary) []image.Summary {
+	if len(cf.excludeRefs) == 0 && len(cf.labels) == 0 && cf.since.IsZero() && cf.until.IsZero() {
+		return images
+	}
+	result := make([]image.Summary, 0, len(images))
+	for _, img := range images {
+		created := time.Unix(img.Created, 0)
+		if !cf.since.IsZero() && created.Before(cf.since) {
+			continue
+		}
+		if !cf.until.IsZero() && created.After(cf.until) {
+			continue
+		}
+		if !cf.matchLabels(img.Labels) {
+			continue
+		}
+		if len(cf.excludeRefs) > 0 && len(img.RepoTags) > 0 {
+			tags := cf.excludeTags(img.RepoTags)
+			if len(tags) == 0 {
+				continue
+			}
+			img.RepoTags = tags
+		}
+		result = append(result, img)
+	}
+	return result
+}
+
+func (cf clientFilters) matchLabels(labels map[string]string) bool {
+	for _, l := range cf.labels {
+		value, ok := labels[l.key]
+		if !ok || !l.value.MatchString(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// excludeTags returns the tags that don't match any of the "reference!"
+// patterns.
+func (cf clientFilters) excludeTags(tags []string) []string {
+	var result []string
+	for _, tag := range tags {
+		ref, err := reference.ParseNormalizedNamed(tag)
+		if err != nil {
+			// Keep "<none>:<none>" and other tags that can't be parsed.
+			result = append(result, tag)
+			continue
+		}
+		excluded := false
+		for _, pattern := range cf.excludeRefs {
+			if ok, _ := reference.FamiliarMatch(pattern, ref); ok {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
@@ -0,0 +1,283 @@
+package image
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/distribution/reference"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/formatter"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/pkg/stringid"
+	units "github.com/docker/go-units"
+)
+
+const (
+	defaultImageGroupTableFormat  = "table {{.Repository}}\t{{.TagCount}}\t{{.Size}}\t{{.UniqueSize}}"
+	expandedImageGroupTableFormat = "table {{.Repository}}\t{{.ID}}\t{{.TagCount}}\t{{.Size}}\t{{.UniqueSize}}"
+
+	noneName = "<none>"
+
+	groupIDHeader    = "IMAGE ID"
+	repositoryHeader = "REPOSITORY"
+	tagsHeader       = "TAGS"
+	imagesHeader     = "IMAGES"
+	uniqueSizeHeader = "UNIQUE SIZE"
+)
+
+type groupOptions struct {
+	all           bool
+	noTrunc       bool
+	format        string
+	filters       filters.Args
+	clientFilters clientFilters
+}
+
+// imageGroup is a repository, and the tags of the images in the repository.
+type imageGroup struct {
+	repository string
+	tags       []imageTag
+}
+
+// imageTag is a tag of an image in an imageGroup. Untagged images have the
+// "<none>" tag.
+type imageTag struct {
+	tag string
+	img image.Summary
+}
+
+func runGroup(ctx context.Context, dockerCLI command.Cli, opts groupOptions) error {
+	images, err := dockerCLI.Client().ImageList(ctx, image.ListOptions{
+		All:        opts.all,
+		Filters:    opts.filters,
+		SharedSize: true,
+	})
+	if err != nil {
+		return err
+	}
+	images = opts.clientFilters.filter(images)
+
+	// The tags are only listed below their repository in the default format.
+	expand := false
+	format := opts.format
+	if format == "" || format == formatter.TableFormatKey {
+		format = defaultImageGroupTableFormat
+		if opts.all {
+			format = expandedImageGroupTableFormat
+			expand = true
+		}
+	}
+
+	groupCtx := formatter.Context{
+		Output: dockerCLI.Out(),
+		Format: formatter.Format(format),
+		Trunc:  !opts.noTrunc,
+	}
+	return imageGroupWrite(groupCtx, groupImages(images), expand)
+}
+
+// groupImages groups the images by repository. Images without tags are
+// grouped with the repository of their digests, if any, or in the "<none>"
+// repository. Groups are sorted by repository, with the "<none>" repository
+// last, and the tags of each group are sorted.
+func groupImages(images []image.Summary) []imageGroup {
+	byRepo := map[string]*imageGroup{}
+	add := func(repo, tag string, img image.Summary) {
+		g, ok := byRepo[repo]
+		if !ok {
+			g = &imageGroup{repository: repo}
+			byRepo[repo] = g
+		}
+		g.tags = append(g.tags, imageTag{tag: tag, img: img})
+	}
+
+	for _, img := range images {
+		tagged := false
+		for _, t := range img.RepoTags {
+			ref, err := reference.ParseNormalizedNamed(t)
+			if err != nil {
+				// "<none>:<none>"
+				continue
+			}
+			if nt, ok := ref.(reference.NamedTagged); ok {
+				add(reference.FamiliarName(nt), nt.Tag(), img)
+				tagged = true
+			}
+		}
+		if tagged {
+			continue
+		}
+
+		repos := map[string]struct{}{}
+		for _, d := range img.RepoDigests {
+			ref, err := reference.ParseNormalizedNamed(d)
+			if err != nil {
+				continue
+			}
+			repo := reference.FamiliarName(ref)
+			if _, ok := repos[repo]; !ok {
+				repos[repo] = struct{}{}
+				add(repo, noneName, img)
+			}
+		}
+		if len(repos) == 0 {
+			add(noneName, noneName, img)
+		}
+	}
+
+	groups := make([]imageGroup, 0, len(byRepo))
+	for _, g := range byRepo {
+		sort.SliceStable(g.tags, func(i, j int) bool {
+			return g.tags[i].tag < g.tags[j].tag
+		})
+		groups = append(groups, *g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if (groups[i].repository == noneName) != (groups[j].repository == noneName) {
+			return groups[j].repository == noneName
+		}
+		return groups[i].repository < groups[j].repository
+	})
+	return groups
+}
+
+// imageGroupWrite writes the context. If expand is true, the tags of each
+// group are written below the group.
+func imageGroupWrite(ctx formatter.Context, groups []imageGroup, expand bool) error {
+	render := func(format func(subContext formatter.SubContext) error) error {
+		for _, g := range groups {
+			if err := format(&imageGroupContext{trunc: ctx.Trunc, g: g}); err != nil {
+				return err
+			}
+			if !expand {
+				continue
+			}
+			for i, t := range g.tags {
+				t := t
+				if err := format(&imageGroupContext{trunc: ctx.Trunc, g: g, tag: &t, last: i == len(g.tags)-1}); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	groupCtx := &imageGroupContext{}
+	groupCtx.Header = formatter.SubHeaderContext{
+		"Repository": repositoryHeader,
+		"ID":         groupIDHeader,
+		"TagCount":   tagsHeader,
+		"Tags":       tagsHeader,
+		"Images":     imagesHeader,
+		"Size":       formatter.SizeHeader,
+		"UniqueSize": uniqueSizeHeader,
+	}
+	return ctx.Write(groupCtx, render)
+}
+
+// imageGroupContext is the context of a row of an image group, or of a row
+// of one of its tags when the group is expanded.
+type imageGroupContext struct {
+	formatter.HeaderContext
+	trunc bool
+	g     imageGroup
+	tag   *imageTag
+	last  bool
+}
+
+func (c *imageGroupContext) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(c)
+}
+
+func (c *imageGroupContext) Repository() string {
+	if c.tag == nil {
+		return c.g.repository
+	}
+	if c.last {
+		return "└─ " + c.tag.tag
+	}
+	return "├─ " + c.tag.tag
+}
+
+// ID returns the ID of the image of a tag, or an empty string for a group.
+func (c *imageGroupContext) ID() string {
+	if c.tag == nil {
+		return ""
+	}
+	if c.trunc {
+		return stringid.TruncateID(c.tag.img.ID)
+	}
+	return c.tag.img.ID
+}
+
+// TagCount returns the number of tags of a group, not counting the untagged
+// images.
+func (c *imageGroupContext) TagCount() string {
+	if c.tag != nil {
+		return ""
+	}
+	n := 0
+	for _, t := range c.g.tags {
+		if t.tag != noneName {
+			n++
+		}
+	}
+	return strconv.Itoa(n)
+}
+
+func (c *imageGroupContext) Tags() string {
+	if c.tag != nil {
+		return c.tag.tag
+	}
+	tags := make([]string, 0, len(c.g.tags))
+	for _, t := range c.g.tags {
+		tags = append(tags, t.tag)
+	}
+	return strings.Join(tags, ", ")
+}
+
+// Images returns the number of distinct images of a group.
+func (c *imageGroupContext) Images() string {
+	return strconv.Itoa(len(c.images()))
+}
+
+// Size returns the size of the images, counting each image once, even if it
+// has several tags.
+func (c *imageGroupContext) Size() string {
+	var size int64
+	for _, img := range c.images() {
+		size += img.Size
+	}
+	return units.HumanSizeWithPrecision(float64(size), 3)
+}
+
+// UniqueSize returns the size of the data of the images that isn't shared
+// with other images, counting each image once.
+func (c *imageGroupContext) UniqueSize() string {
+	var size int64
+	for _, img := range c.images() {
+		if img.Size == -1 || img.SharedSize == -1 {
+			return "N/A"
+		}
+		size += img.Size - img.SharedSize
+	}
+	return units.HumanSizeWithPrecision(float64(size), 3)
+}
+
+// images returns the distinct images of the row.
+func (c *imageGroupContext) images() []image.Summary {
+	if c.tag != nil {
+		return []image.Summary{c.tag.img}
+	}
+	seen := map[string]struct{}{}
+	images := make([]image.Summary, 0, len(c.g.tags))
+	for _, t := range c.g.tags {
+		if _, ok := seen[t.img.ID]; !ok {
+			seen[t.img.ID] = struct{}{}
+			images = append(images, t.img)
+		}
+	}
+	return images
+}
@@ -3,11 +3,16 @@
 import (
 	"fmt"
 	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/cli/cli/config/configfile"
 	"github.com/docker/cli/internal/test"
+	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types/image"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"gotest.tools/v3/assert"
 	is "gotest.tools/v3/assert/cmp"
@@ -33,6 +38,26 @@ func TestNewImagesCommandErrors(t *testing.T) {
 				return []image.Summary{}, errors.Errorf("something went wrong")
 			},
 		},
+		{
+			name:          "group-quiet",
+			args:          []string{"--group", "--quiet"},
+			expectedError: "--quiet is not supported with --group",
+		},
+		{
+			name:          "group-digests",
+			args:          []string{"--group", "--digests"},
+			expectedError: "--digests is not supported with --group",
+		},
+		{
+			name:          "group-platforms",
+			args:          []string{"--group", "--platforms"},
+			expectedError: "--platforms is not supported with --group",
+		},
+		{
+			name:          "platforms-quiet",
+			args:          []string{"--platforms", "--quiet"},
+			expectedError: "--quiet is not supported with --platforms",
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -98,6 +123,53 @@ func TestNewImagesCommandSuccess(t *testing.T) {
 	}
 }
 
+func TestNewImagesCommandPlatforms(t *testing.T) {
+	manifest := func(id, platform string, size int64, available bool) image.ManifestSummary {
+		os, arch, _ := strings.Cut(platform, "/")
+		m := image.ManifestSummary{
+			ID:         id,
+			Descriptor: ocispec.Descriptor{Digest: digest.Digest(id)},
+			Available:  available,
+			Kind:       image.ManifestKindImage,
+			ImageData:  &image.ImageProperties{Platform: ocispec.Platform{OS: os, Architecture: arch}},
+		}
+		m.Size.Total = size
+		return m
+	}
+	cli := test.NewFakeCli(&fakeClient{
+		imageListFunc: func(options image.ListOptions) ([]image.Summary, error) {
+			assert.Check(t, options.Manifests)
+			return []image.Summary{
+				{
+					ID:          "sha256:1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b",
+					RepoTags:    []string{"alpine:latest"},
+					RepoDigests: []string{"alpine@sha256:1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b"},
+					Size:        12000000,
+					Manifests: []image.ManifestSummary{
+						manifest("sha256:bbbbbb7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e", "linux/arm64", 4000000, true),
+						manifest("sha256:aaaaaa7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e", "linux/amd64", 8000000, true),
+						manifest("sha256:cccccc7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e", "linux/s390x", 0, false),
+						{Kind: image.ManifestKindAttestation, Available: true},
+					},
+				},
+				{
+					ID:       "sha256:2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c",
+					RepoTags: []string{"myapp:dev"},
+					Size:     30000000,
+					Manifests: []image.ManifestSummary{
+						manifest("sha256:dddddd7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e", "linux/amd64", 30000000, true),
+					},
+				},
+			}, nil
+		},
+	})
+	cmd := NewImagesCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{"--platforms", "--format", "table {{.Repository}}\t{{.Tag}}\t{{.Platform}}\t{{.Digest}}\t{{.ID}}\t{{.Size}}"})
+	assert.NilError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "list-command-platforms.golden")
+}
+
 func TestNewListCommandAlias(t *testing.T) {
 	cmd := newListCommand(test.NewFakeCli(&fakeClient{}))
 	assert.Check(t, cmd.HasAlias("list"))
@@ -117,3 +189,91 @@ func TestNewListCommandAmbiguous(t *testing.T) {
 	assert.NilError(t, err)
 	golden.Assert(t, cli.ErrBuffer().String(), "list-command-ambiguous.golden")
 }
+
+func TestNewImagesCommandClientFilters(t *testing.T) {
+	now := time.Now()
+	cli := test.NewFakeCli(&fakeClient{
+		imageListFunc: func(options image.ListOptions) ([]image.Summary, error) {
+			assert.Check(t, is.DeepEqual(options.Filters.Get("dangling"), []string{"false"}))
+			assert.Check(t, is.Len(options.Filters.Get("reference!"), 0))
+			assert.Check(t, is.Len(options.Filters.Get("label~"), 0))
+			assert.Check(t, is.Len(options.Filters.Get("since"), 0))
+			return []image.Summary{
+				{ID: "sha256:app", RepoTags: []string{"app:1.2", "app:dev"}, Created: now.Add(-time.Hour).Unix(), Labels: map[string]string{"version": "1.2.0"}},
+				{ID: "sha256:dev", RepoTags: []string{"app:dev-debug"}, Created: now.Add(-time.Hour).Unix(), Labels: map[string]string{"version": "1.3.0"}},
+				{ID: "sha256:old", RepoTags: []string{"app:1.0"}, Created: now.Add(-72 * time.Hour).Unix(), Labels: map[string]string{"version": "1.0.0"}},
+				{ID: "sha256:nolabel", RepoTags: []string{"other:latest"}, Created: now.Add(-time.Hour).Unix()},
+				{ID: "sha256:v2", RepoTags: []string{"app:2.0"}, Created: now.Add(-time.Hour).Unix(), Labels: map[string]string{"version": "2.0.0"}},
+			}, nil
+		},
+	})
+	cmd := NewImagesCommand(cli)
+	cmd.SetArgs([]string{
+		"--format", "{{.Repository}}:{{.Tag}}",
+		"--filter", "dangling=false",
+		"--filter", "reference!=*:dev*",
+		"--filter", `label~=version=^1\.`,
+		"--filter", "since=24h",
+	})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "app:1.2\n"))
+}
+
+func TestSplitFiltersErrors(t *testing.T) {
+	for _, tc := range []struct {
+		filter        string
+		expectedError string
+	}{
+		{filter: "reference!=[", expectedError: "invalid reference pattern: [: syntax error in pattern"},
+		{filter: "label~=version", expectedError: "invalid label~ filter: version (expected <key>=<regexp>)"},
+		{filter: "label~=version=(", expectedError: "invalid label~ filter: version=(: error parsing regexp: missing closing ): `(`"},
+	} {
+		f := opts.NewFilterOpt()
+		assert.NilError(t, f.Set(tc.filter))
+		_, _, err := splitFilters(f.Value(), time.Now())
+		assert.Check(t, is.Error(err, tc.expectedError))
+	}
+}
+
+func TestNewImagesCommandGroup(t *testing.T) {
+	images := []image.Summary{
+		{ID: "sha256:1111111111111111111111111111111111111111111111111111111111111111", RepoTags: []string{"alpine:3.19", "alpine:latest"}, Size: 7_000_000, SharedSize: 0},
+		{ID: "sha256:2222222222222222222222222222222222222222222222222222222222222222", RepoTags: []string{"alpine:3.18"}, Size: 7_500_000, SharedSize: 0},
+		{ID: "sha256:3333333333333333333333333333333333333333333333333333333333333333", RepoTags: []string{"example.com/app:1.0", "example.com/app:1.1"}, Size: 20_000_000, SharedSize: 7_000_000},
+		{ID: "sha256:4444444444444444444444444444444444444444444444444444444444444444", RepoDigests: []string{"example.com/app@sha256:5555555555555555555555555555555555555555555555555555555555555555"}, Size: 19_000_000, SharedSize: 7_000_000},
+		{ID: "sha256:6666666666666666666666666666666666666666666666666666666666666666", RepoTags: []string{"<none>:<none>"}, RepoDigests: []string{"<none>@<none>"}, Size: 1_000_000, SharedSize: -1},
+	}
+	testCases := []struct {
+		name string
+		args []string
+	}{
+		{
+			name: "default",
+			args: []string{"--group"},
+		},
+		{
+			name: "all",
+			args: []string{"--group", "--all"},
+		},
+		{
+			name: "format",
+			args: []string{"--group", "--format", "{{.Repository}}: {{.Tags}} ({{.Images}} images)"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cli := test.NewFakeCli(&fakeClient{
+				imageListFunc: func(options image.ListOptions) ([]image.Summary, error) {
+					assert.Check(t, options.SharedSize)
+					return images, nil
+				},
+			})
+			cmd := NewImagesCommand(cli)
+			cmd.SetOut(io.Discard)
+			cmd.SetArgs(tc.args)
+			assert.NilError(t, cmd.Execute())
+			golden.Assert(t, cli.OutBuffer().String(), fmt.Sprintf("list-command-group.%s.golden", tc.name))
+		})
+	}
+}
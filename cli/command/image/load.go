@@ -1,12 +1,20 @@
 package image
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/cli/internal/transfer"
+	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/moby/sys/sequential"
 	"github.com/pkg/errors"
@@ -38,13 +46,16 @@ func NewLoadCommand(dockerCli command.Cli) *cobra.Command {
 	flags := cmd.Flags()
 
 	flags.StringVarP(&opts.input, "input", "i", "", "Read from tar archive file, instead of STDIN")
-	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Suppress the load output")
+	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only print the loaded image references and IDs")
 
 	return cmd
 }
 
 func runLoad(ctx context.Context, dockerCli command.Cli, opts loadOptions) error {
-	var input io.Reader = dockerCli.In()
+	var (
+		input      io.Reader = dockerCli.In()
+		sent, size int64
+	)
 	if opts.input != "" {
 		// We use sequential.Open to use sequential file access on Windows, avoiding
 		// depleting the standby list un-necessarily. On Linux, this equates to a regular os.Open.
@@ -54,6 +65,9 @@ func runLoad(ctx context.Context, dockerCli command.Cli, opts loadOptions) error
 		}
 		defer file.Close()
 		input = file
+		if fi, err := file.Stat(); err == nil {
+			size = fi.Size()
+		}
 	}
 
 	// To avoid getting stuck, verify that a tar file is given either in
@@ -62,19 +76,90 @@ func runLoad(ctx context.Context, dockerCli command.Cli, opts loadOptions) error
 		return errors.Errorf("requested load from stdin, but stdin is empty")
 	}
 
-	if !dockerCli.Out().IsTerminal() {
-		opts.quiet = true
+	// The daemon only sends the progress of loading the layers once the whole
+	// archive is received, so show the progress of sending the archive until
+	// then.
+	progressCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var progressOut io.Writer = dockerCli.Err()
+	if opts.quiet {
+		progressOut = io.Discard
 	}
-	response, err := dockerCli.Client().ImageLoad(ctx, input, opts.quiet)
+	clearProgress, done := transfer.ShowProgress(progressCtx, progressOut, loadHeader, func(elapsed time.Duration) string {
+		n := atomic.LoadInt64(&sent)
+		if size > 0 {
+			return fmt.Sprintf("%s / %s (%d%%)", transfer.Rate(n, elapsed), transfer.HumanSize(size), min(n*100/size, 100))
+		}
+		return transfer.Rate(n, elapsed)
+	})
+
+	quiet := opts.quiet || !dockerCli.Out().IsTerminal()
+	response, err := dockerCli.Client().ImageLoad(ctx, &transfer.CountingReader{Reader: input, N: &sent}, quiet)
+	cancel()
+	<-done
+	clearProgress()
 	if err != nil {
 		return err
 	}
+	return printLoadResponse(dockerCli, response, opts.quiet)
+}
+
+// printLoadResponse prints the output of the daemon when loading images, or
+// only the loaded images if quiet is true.
+func printLoadResponse(dockerCli command.Cli, response image.LoadResponse, quiet bool) error {
 	defer response.Body.Close()
 
+	if quiet {
+		return printLoadedImages(dockerCli.Out(), response.Body, response.JSON)
+	}
+
 	if response.Body != nil && response.JSON {
 		return jsonmessage.DisplayJSONMessagesToStream(response.Body, dockerCli.Out(), nil)
 	}
 
-	_, err = io.Copy(dockerCli.Out(), response.Body)
+	_, err := io.Copy(dockerCli.Out(), response.Body)
 	return err
 }
+
+const loadHeader = "Sending archive - "
+
+// loadedPrefixes are the prefixes of the messages in which the daemon reports
+// the images that were loaded.
+var loadedPrefixes = []string{"Loaded image: ", "Loaded image ID: "}
+
+// printLoadedImages prints the references and IDs of the images that were
+// loaded, as reported by the daemon in the load output, one per line.
+func printLoadedImages(out io.Writer, body io.Reader, isJSON bool) error {
+	printLoaded := func(msg string) {
+		for _, line := range strings.Split(msg, "\n") {
+			for _, prefix := range loadedPrefixes {
+				if ref, ok := strings.CutPrefix(line, prefix); ok {
+					_, _ = fmt.Fprintln(out, strings.TrimSpace(ref))
+				}
+			}
+		}
+	}
+
+	if !isJSON {
+		scanner := bufio.NewScanner(body)
+		for scanner.Scan() {
+			printLoaded(scanner.Text())
+		}
+		return scanner.Err()
+	}
+
+	dec := json.NewDecoder(body)
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := dec.Decode(&msg); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if msg.Error != nil {
+			return msg.Error
+		}
+		printLoaded(msg.Stream)
+	}
+}
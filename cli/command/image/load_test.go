@@ -10,6 +10,7 @@
 	"github.com/docker/docker/api/types/image"
 	"github.com/pkg/errors"
 	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
 	"gotest.tools/v3/golden"
 )
 
@@ -106,3 +107,59 @@ func TestNewLoadCommandSuccess(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadQuiet(t *testing.T) {
+	testCases := []struct {
+		name     string
+		response image.LoadResponse
+		expected string
+	}{
+		{
+			name: "json",
+			response: image.LoadResponse{
+				Body: io.NopCloser(strings.NewReader(`{"stream":"Loaded image: busybox:latest\n"}` +
+					`{"stream":"Loaded image: busybox:musl\n"}` +
+					`{"stream":"Loaded image ID: sha256:0123456789abcdef\n"}`)),
+				JSON: true,
+			},
+			expected: "busybox:latest\nbusybox:musl\nsha256:0123456789abcdef\n",
+		},
+		{
+			name: "text",
+			response: image.LoadResponse{
+				Body: io.NopCloser(strings.NewReader("Loaded image: busybox:latest\n")),
+			},
+			expected: "busybox:latest\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cli := test.NewFakeCli(&fakeClient{
+				imageLoadFunc: func(input io.Reader, quiet bool) (image.LoadResponse, error) {
+					assert.Check(t, quiet)
+					return tc.response, nil
+				},
+			})
+			cmd := NewLoadCommand(cli)
+			cmd.SetArgs([]string{"--quiet", "--input", "testdata/load-command-success.input.txt"})
+			assert.NilError(t, cmd.Execute())
+			assert.Check(t, is.Equal(cli.OutBuffer().String(), tc.expected))
+		})
+	}
+}
+
+func TestLoadQuietError(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		imageLoadFunc: func(io.Reader, bool) (image.LoadResponse, error) {
+			return image.LoadResponse{
+				Body: io.NopCloser(strings.NewReader(`{"errorDetail":{"message":"invalid archive"},"error":"invalid archive"}`)),
+				JSON: true,
+			}, nil
+		},
+	})
+	cmd := NewLoadCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"-q", "--input", "testdata/load-command-success.input.txt"})
+	assert.Check(t, is.Error(cmd.Execute(), "invalid archive"))
+}
@@ -0,0 +1,354 @@
+package image
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/containerd/platforms"
+	"github.com/distribution/reference"
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/docker/api/types/versions"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// dockerManifestMediaType is the media type of the Docker image manifests,
+// which are stored in OCI layouts as OCI image manifests.
+const dockerManifestMediaType = "application/vnd.docker.distribution.manifest.v2+json"
+
+type exportOCIOptions struct {
+	dir    string
+	images []string
+}
+
+type importOCIOptions struct {
+	dir   string
+	quiet bool
+}
+
+// archiveManifest is an entry of the manifest.json file of the archives
+// created by "docker save", which is required to load images with the
+// daemons that don't use the containerd image store.
+type archiveManifest struct {
+	Config   string
+	RepoTags []string
+	Layers   []string
+}
+
+// NewExportOCICommand creates a new `docker image export-oci` command
+func NewExportOCICommand(dockerCli command.Cli) *cobra.Command {
+	var opts exportOCIOptions
+
+	cmd := &cobra.Command{
+		Use:   "export-oci DIR IMAGE [IMAGE...]",
+		Short: "Save one or more images to a directory, as an OCI image layout",
+		Args:  cli.RequiresMinArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.dir = args[0]
+			opts.images = args[1:]
+			return runExportOCI(cmd.Context(), dockerCli, opts)
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return nil, cobra.ShellCompDirectiveFilterDirs
+			}
+			return completion.ImageNames(dockerCli)(cmd, args, toComplete)
+		},
+	}
+	return cmd
+}
+
+// NewImportOCICommand creates a new `docker image import-oci` command
+func NewImportOCICommand(dockerCli command.Cli) *cobra.Command {
+	var opts importOCIOptions
+
+	cmd := &cobra.Command{
+		Use:   "import-oci [OPTIONS] DIR",
+		Short: "Load the images of an OCI image layout directory",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.dir = args[0]
+			return runImportOCI(cmd.Context(), dockerCli, opts)
+		},
+		ValidArgsFunction: func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveFilterDirs
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only print the loaded image references and IDs")
+
+	return cmd
+}
+
+func runExportOCI(ctx context.Context, dockerCli command.Cli, opts exportOCIOptions) error {
+	// Archives created by "docker save" are OCI image layouts since API v1.44.
+	if versions.LessThan(dockerCli.CurrentVersion(), "1.44") {
+		return errors.New("exporting images as an OCI image layout requires API version 1.44 or later")
+	}
+	if entries, err := os.ReadDir(opts.dir); err == nil && len(entries) > 0 {
+		return errors.Errorf("%s is not empty", opts.dir)
+	}
+	if err := os.MkdirAll(opts.dir, 0o755); err != nil {
+		return err
+	}
+
+	responseBody, err := dockerCli.Client().ImageSave(ctx, opts.images)
+	if err != nil {
+		return err
+	}
+	defer responseBody.Close()
+
+	if err := extractTar(responseBody, opts.dir); err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(opts.dir, ocispec.ImageIndexFile)); err != nil {
+		return errors.Errorf("the daemon didn't save the images as an OCI image layout")
+	}
+	return nil
+}
+
+func runImportOCI(ctx context.Context, dockerCli command.Cli, opts importOCIOptions) error {
+	for _, name := range []string{ocispec.ImageLayoutFile, ocispec.ImageIndexFile} {
+		if _, err := os.Stat(filepath.Join(opts.dir, name)); err != nil {
+			return errors.Errorf("%s is not an OCI image layout: %s not found", opts.dir, name)
+		}
+	}
+
+	var extra map[string][]byte
+	if _, err := os.Stat(filepath.Join(opts.dir, "manifest.json")); errors.Is(err, fs.ErrNotExist) {
+		manifests, err := archiveManifests(opts.dir)
+		if err != nil {
+			return err
+		}
+		manifestJSON, err := json.Marshal(manifests)
+		if err != nil {
+			return err
+		}
+		extra = map[string][]byte{"manifest.json": manifestJSON}
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeTar(pw, opts.dir, extra))
+	}()
+	defer pr.Close()
+
+	quiet := opts.quiet || !dockerCli.Out().IsTerminal()
+	response, err := dockerCli.Client().ImageLoad(ctx, pr, quiet)
+	if err != nil {
+		return err
+	}
+	return printLoadResponse(dockerCli, response, opts.quiet)
+}
+
+// extractTar extracts a tar archive to dir. Only directories, regular files,
+// and symbolic links pointing inside dir are extracted.
+func extractTar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := path.Clean(hdr.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return errors.Errorf("invalid path in archive: %s", hdr.Name)
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			linked := path.Join(path.Dir(name), hdr.Linkname)
+			if path.IsAbs(hdr.Linkname) || linked == ".." || strings.HasPrefix(linked, "../") {
+				return errors.Errorf("invalid link in archive: %s -> %s", hdr.Name, hdr.Linkname)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			if err := os.Symlink(filepath.FromSlash(hdr.Linkname), target); err != nil {
+				return err
+			}
+		default:
+			return errors.Errorf("unsupported file type in archive: %s", hdr.Name)
+		}
+	}
+}
+
+// writeTar writes the content of dir as a tar archive to w, adding the extra
+// files at the root of the archive.
+func writeTar(w io.Writer, dir string, extra map[string][]byte) error {
+	tw := tar.NewWriter(w)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		link := ""
+		if info.Mode()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, filepath.ToSlash(link))
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	for name, content := range extra {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(content); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// archiveManifests returns the manifest.json entries for the images of an
+// OCI image layout. For multi-platform images, only the image for the
+// platform of the client is included.
+func archiveManifests(dir string) ([]archiveManifest, error) {
+	var index ocispec.Index
+	if err := readLayoutJSON(filepath.Join(dir, ocispec.ImageIndexFile), &index); err != nil {
+		return nil, err
+	}
+
+	manifests := []archiveManifest{}
+	for _, desc := range index.Manifests {
+		name := layoutImageName(desc.Annotations)
+		if desc.MediaType == ocispec.MediaTypeImageIndex {
+			var nested ocispec.Index
+			if err := readBlobJSON(dir, desc.Digest, &nested); err != nil {
+				return nil, err
+			}
+			found := false
+			for _, d := range nested.Manifests {
+				if d.Platform != nil && platforms.Default().Match(*d.Platform) {
+					desc, found = d, true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		if desc.MediaType != ocispec.MediaTypeImageManifest && desc.MediaType != dockerManifestMediaType {
+			continue
+		}
+
+		var manifest ocispec.Manifest
+		if err := readBlobJSON(dir, desc.Digest, &manifest); err != nil {
+			return nil, err
+		}
+		m := archiveManifest{Config: blobName(manifest.Config.Digest)}
+		for _, l := range manifest.Layers {
+			m.Layers = append(m.Layers, blobName(l.Digest))
+		}
+		if name != "" {
+			m.RepoTags = []string{name}
+		}
+		manifests = append(manifests, m)
+	}
+	if len(manifests) == 0 {
+		return nil, errors.Errorf("no image found in %s for platform %s", dir, platforms.DefaultString())
+	}
+	return manifests, nil
+}
+
+// layoutImageName returns the name of an image of an OCI image layout, or an
+// empty string if the image has no name, or if the name is not a tagged
+// reference.
+func layoutImageName(annotations map[string]string) string {
+	for _, key := range []string{"io.containerd.image.name", ocispec.AnnotationRefName} {
+		ref, err := reference.ParseNormalizedNamed(annotations[key])
+		if err != nil {
+			continue
+		}
+		if tagged, ok := ref.(reference.NamedTagged); ok {
+			return reference.FamiliarString(tagged)
+		}
+	}
+	return ""
+}
+
+func readLayoutJSON(p string, v any) error {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return errors.Wrapf(err, "invalid %s", filepath.Base(p))
+	}
+	return nil
+}
+
+func blobName(dgst digest.Digest) string {
+	return path.Join(ocispec.ImageBlobsDir, dgst.Algorithm().String(), dgst.Encoded())
+}
+
+// readBlobJSON reads a JSON blob of an OCI image layout.
+func readBlobJSON(dir string, dgst digest.Digest, v any) error {
+	if err := dgst.Validate(); err != nil {
+		return err
+	}
+	return readLayoutJSON(filepath.Join(dir, filepath.FromSlash(blobName(dgst))), v)
+}
@@ -0,0 +1,185 @@
+package image
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/image"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/fs"
+)
+
+func tarFiles(t *testing.T, files map[string]string) io.ReadCloser {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range files {
+		assert.NilError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		assert.NilError(t, err)
+	}
+	assert.NilError(t, tw.Close())
+	return io.NopCloser(&buf)
+}
+
+func TestExportOCI(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "layout")
+	cli := test.NewFakeCli(&fakeClient{
+		imageSaveFunc: func(images []string) (io.ReadCloser, error) {
+			assert.Check(t, is.DeepEqual(images, []string{"alpine:latest"}))
+			return tarFiles(t, map[string]string{
+				"oci-layout":          `{"imageLayoutVersion":"1.0.0"}`,
+				"index.json":          `{"schemaVersion":2,"manifests":[]}`,
+				"blobs/sha256/abcdef": "blob",
+			}), nil
+		},
+	})
+	cmd := NewExportOCICommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{dir, "alpine:latest"})
+	assert.NilError(t, cmd.Execute())
+
+	expected := fs.Expected(t,
+		fs.MatchAnyFileMode,
+		fs.WithFile("oci-layout", `{"imageLayoutVersion":"1.0.0"}`, fs.MatchAnyFileMode),
+		fs.WithFile("index.json", `{"schemaVersion":2,"manifests":[]}`, fs.MatchAnyFileMode),
+		fs.WithDir("blobs", fs.MatchAnyFileMode,
+			fs.WithDir("sha256", fs.MatchAnyFileMode,
+				fs.WithFile("abcdef", "blob", fs.MatchAnyFileMode),
+			),
+		),
+	)
+	assert.Assert(t, fs.Equal(dir, expected))
+}
+
+func TestExportOCIErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		files         map[string]string
+		notEmpty      bool
+		expectedError string
+	}{
+		{
+			name:          "not-empty",
+			notEmpty:      true,
+			expectedError: "is not empty",
+		},
+		{
+			name:          "path-outside-dir",
+			files:         map[string]string{"../evil": "evil"},
+			expectedError: "invalid path in archive: ../evil",
+		},
+		{
+			name:          "not-oci-layout",
+			files:         map[string]string{"manifest.json": "[]", "repositories": "{}"},
+			expectedError: "the daemon didn't save the images as an OCI image layout",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "layout")
+			if tc.notEmpty {
+				assert.NilError(t, os.MkdirAll(dir, 0o755))
+				assert.NilError(t, os.WriteFile(filepath.Join(dir, "file"), nil, 0o644))
+			}
+			cli := test.NewFakeCli(&fakeClient{
+				imageSaveFunc: func(images []string) (io.ReadCloser, error) {
+					return tarFiles(t, tc.files), nil
+				},
+			})
+			cmd := NewExportOCICommand(cli)
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs([]string{dir, "alpine:latest"})
+			assert.ErrorContains(t, cmd.Execute(), tc.expectedError)
+			_, err := os.Stat(filepath.Join(filepath.Dir(dir), "evil"))
+			assert.Check(t, os.IsNotExist(err))
+		})
+	}
+}
+
+func TestImportOCI(t *testing.T) {
+	config := []byte(`{"architecture":"amd64","os":"linux"}`)
+	layer := []byte("layer")
+	manifest, err := json.Marshal(ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    ocispec.Descriptor{MediaType: ocispec.MediaTypeImageConfig, Digest: digest.FromBytes(config), Size: int64(len(config))},
+		Layers:    []ocispec.Descriptor{{MediaType: ocispec.MediaTypeImageLayerGzip, Digest: digest.FromBytes(layer), Size: int64(len(layer))}},
+	})
+	assert.NilError(t, err)
+	index, err := json.Marshal(ocispec.Index{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{{
+			MediaType:   ocispec.MediaTypeImageManifest,
+			Digest:      digest.FromBytes(manifest),
+			Size:        int64(len(manifest)),
+			Annotations: map[string]string{ocispec.AnnotationRefName: "example.com/app:1.0"},
+		}},
+	})
+	assert.NilError(t, err)
+
+	dir := fs.NewDir(t, "layout",
+		fs.WithFile("oci-layout", `{"imageLayoutVersion":"1.0.0"}`),
+		fs.WithFile("index.json", string(index)),
+		fs.WithDir("blobs",
+			fs.WithDir("sha256",
+				fs.WithFile(digest.FromBytes(config).Encoded(), string(config)),
+				fs.WithFile(digest.FromBytes(layer).Encoded(), string(layer)),
+				fs.WithFile(digest.FromBytes(manifest).Encoded(), string(manifest)),
+			),
+		),
+	)
+
+	cli := test.NewFakeCli(&fakeClient{
+		imageLoadFunc: func(input io.Reader, quiet bool) (image.LoadResponse, error) {
+			files := map[string]string{}
+			tr := tar.NewReader(input)
+			for {
+				hdr, err := tr.Next()
+				if err == io.EOF {
+					break
+				}
+				assert.NilError(t, err)
+				content, err := io.ReadAll(tr)
+				assert.NilError(t, err)
+				files[hdr.Name] = string(content)
+			}
+			assert.Check(t, is.Equal(files["index.json"], string(index)))
+			assert.Check(t, is.Equal(files["blobs/sha256/"+digest.FromBytes(layer).Encoded()], "layer"))
+
+			var manifests []archiveManifest
+			assert.NilError(t, json.Unmarshal([]byte(files["manifest.json"]), &manifests))
+			assert.Check(t, is.DeepEqual(manifests, []archiveManifest{{
+				Config:   "blobs/sha256/" + digest.FromBytes(config).Encoded(),
+				RepoTags: []string{"example.com/app:1.0"},
+				Layers:   []string{"blobs/sha256/" + digest.FromBytes(layer).Encoded()},
+			}}))
+			return image.LoadResponse{Body: io.NopCloser(strings.NewReader("Loaded image: example.com/app:1.0\n"))}, nil
+		},
+	})
+	cmd := NewImportOCICommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{dir.Path()})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "Loaded image: example.com/app:1.0\n"))
+}
+
+func TestImportOCINotLayout(t *testing.T) {
+	dir := fs.NewDir(t, "layout", fs.WithFile("index.json", "{}"))
+	cmd := NewImportOCICommand(test.NewFakeCli(&fakeClient{}))
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{dir.Path()})
+	assert.ErrorContains(t, cmd.Execute(), "is not an OCI image layout: oci-layout not found")
+}
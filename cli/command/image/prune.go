@@ -5,11 +5,15 @@
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
 	"github.com/docker/cli/opts"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	timetypes "github.com/docker/docker/api/types/time"
 	"github.com/docker/docker/errdefs"
 	units "github.com/docker/go-units"
 	"github.com/pkg/errors"
@@ -19,7 +23,9 @@
 type pruneOptions struct {
 	force  bool
 	all    bool
+	dryRun bool
 	filter opts.FilterOpt
+	policy retentionPolicy
 }
 
 // NewPruneCommand returns a new cobra prune command for images
@@ -38,7 +44,11 @@ func NewPruneCommand(dockerCli command.Cli) *cobra.Command {
 			if output != "" {
 				fmt.Fprintln(dockerCli.Out(), output)
 			}
-			fmt.Fprintln(dockerCli.Out(), "Total reclaimed space:", units.HumanSize(float64(spaceReclaimed)))
+			if options.dryRun {
+				fmt.Fprintln(dockerCli.Out(), "Total reclaimable space:", units.HumanSize(float64(spaceReclaimed)))
+			} else {
+				fmt.Fprintln(dockerCli.Out(), "Total reclaimed space:", units.HumanSize(float64(spaceReclaimed)))
+			}
 			return nil
 		},
 		Annotations:       map[string]string{"version": "1.25"},
@@ -49,6 +59,10 @@ func NewPruneCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVarP(&options.force, "force", "f", false, "Do not prompt for confirmation")
 	flags.BoolVarP(&options.all, "all", "a", false, "Remove all unused images, not just dangling ones")
 	flags.Var(&options.filter, "filter", `Provide filter values (e.g. "until=<timestamp>")`)
+	flags.BoolVar(&options.dryRun, "dry-run", false, "Show the images that would be removed, without removing them")
+	flags.IntVar(&options.policy.keepLast, "keep-last", 0, "Keep the images of the N most recent tags of each repository")
+	flags.DurationVar(&options.policy.keepRecent, "keep-recent", 0, "Keep the images pulled or tagged within the duration (e.g. \"72h\")")
+	flags.StringArrayVar(&options.policy.keep, "keep", nil, "Keep the images with a reference matching the pattern (e.g. \"myorg/*\")")
 
 	return cmd
 }
@@ -64,6 +78,17 @@ func runPrune(ctx context.Context, dockerCli command.Cli, options pruneOptions)
 	pruneFilters := options.filter.Value().Clone()
 	pruneFilters.Add("dangling", strconv.FormatBool(!options.all))
 	pruneFilters = command.PruneFilters(dockerCli, pruneFilters)
+	if err := options.policy.validate(); err != nil {
+		return 0, "", err
+	}
+
+	if options.dryRun {
+		images, err := pruneCandidates(ctx, dockerCli, pruneFilters, options.all, options.policy)
+		if err != nil {
+			return 0, "", err
+		}
+		return reclaimableSpace(images), formatPruneCandidates("Would delete Images:\n", images), nil
+	}
 
 	warning := danglingWarning
 	if options.all {
@@ -79,6 +104,10 @@ func runPrune(ctx context.Context, dockerCli command.Cli, options pruneOptions)
 		}
 	}
 
+	if options.policy.isSet() {
+		return pruneWithPolicy(ctx, dockerCli, pruneFilters, options)
+	}
+
 	report, err := dockerCli.Client().ImagesPrune(ctx, pruneFilters)
 	if err != nil {
 		return 0, "", err
@@ -105,6 +134,105 @@ func runPrune(ctx context.Context, dockerCli command.Cli, options pruneOptions)
 	return spaceReclaimed, output, nil
 }
 
+// pruneCandidates returns the images that would be removed by a prune with
+// the given filters, and that are not kept by the retention policy. The
+// selection mirrors the one of the daemon: dangling images, or all images that
+// are not used by a container if all is set, created before the "until"
+// filter, and matching the "label" and "label!" filters.
+func pruneCandidates(ctx context.Context, dockerCli command.Cli, pruneFilters filters.Args, all bool, policy retentionPolicy) ([]image.Summary, error) {
+	if err := pruneFilters.Validate(map[string]bool{"dangling": true, "until": true, "label": true, "label!": true}); err != nil {
+		return nil, err
+	}
+	var until time.Time
+	if u := pruneFilters.Get("until"); len(u) > 0 {
+		if len(u) > 1 {
+			return nil, errdefs.InvalidParameter(errors.New("more than one until filter specified"))
+		}
+		ts, err := timetypes.GetTimestamp(u[0], time.Now())
+		if err != nil {
+			return nil, errdefs.InvalidParameter(err)
+		}
+		seconds, nanoseconds, err := timetypes.ParseTimestamps(ts, 0)
+		if err != nil {
+			return nil, errdefs.InvalidParameter(err)
+		}
+		until = time.Unix(seconds, nanoseconds)
+	}
+
+	listFilters := filters.NewArgs()
+	if !all {
+		listFilters.Add("dangling", "true")
+	}
+	images, err := dockerCli.Client().ImageList(ctx, image.ListOptions{
+		Filters:        listFilters,
+		SharedSize:     true,
+		ContainerCount: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]image.Summary, 0, len(images))
+	for _, img := range images {
+		if img.Containers > 0 {
+			continue
+		}
+		if !until.IsZero() && time.Unix(img.Created, 0).After(until) {
+			continue
+		}
+		if !pruneFilters.MatchKVList("label", img.Labels) {
+			continue
+		}
+		if pruneFilters.Contains("label!") && pruneFilters.MatchKVList("label!", img.Labels) {
+			continue
+		}
+		candidates = append(candidates, img)
+	}
+	if !policy.isSet() {
+		return candidates, nil
+	}
+	return policy.apply(ctx, dockerCli.Client(), candidates, time.Now())
+}
+
+// formatPruneCandidates formats the tags, digests, and IDs of the images that
+// would be removed, after the header.
+func formatPruneCandidates(header string, images []image.Summary) string {
+	if len(images) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(header)
+	for _, img := range images {
+		for _, tag := range img.RepoTags {
+			if tag != "<none>:<none>" {
+				sb.WriteString("untagged: " + tag + "\n")
+			}
+		}
+		for _, digest := range img.RepoDigests {
+			if digest != "<none>@<none>" {
+				sb.WriteString("untagged: " + digest + "\n")
+			}
+		}
+		sb.WriteString("deleted: " + img.ID + "\n")
+	}
+	return sb.String()
+}
+
+// reclaimableSpace returns an estimate of the space reclaimed by removing the
+// images: layers that are shared with other images are not counted, even if
+// all these images are removed.
+func reclaimableSpace(images []image.Summary) uint64 {
+	var space uint64
+	for _, img := range images {
+		size := img.Size
+		if img.SharedSize > 0 {
+			size -= img.SharedSize
+		}
+		space += uint64(size)
+	}
+	return space
+}
+
 // RunPrune calls the Image Prune API
 // This returns the amount of space reclaimed and a detailed output string
 func RunPrune(ctx context.Context, dockerCli command.Cli, all bool, filter opts.FilterOpt) (uint64, string, error) {
@@ -0,0 +1,176 @@
+package image
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/distribution/reference"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+)
+
+// retentionPolicy are the rules of "docker image prune" that keep images that
+// would otherwise be removed. They are applied by the CLI, as the daemon
+// doesn't support them:
+//
+//   - keepLast keeps the images of the N most recent tags of each repository,
+//     ordered by the creation date of their image.
+//   - keepRecent keeps the images that were pulled or tagged within the
+//     duration.
+//   - keep keeps the images with a tag matching one of the patterns.
+type retentionPolicy struct {
+	keepLast   int
+	keepRecent time.Duration
+	keep       []string
+}
+
+func (p retentionPolicy) isSet() bool {
+	return p.keepLast > 0 || p.keepRecent > 0 || len(p.keep) > 0
+}
+
+func (p retentionPolicy) validate() error {
+	if p.keepLast < 0 {
+		return errdefs.InvalidParameter(errors.New("--keep-last must be positive"))
+	}
+	if p.keepRecent < 0 {
+		return errdefs.InvalidParameter(errors.New("--keep-recent must be positive"))
+	}
+	for _, pattern := range p.keep {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errdefs.InvalidParameter(errors.Wrapf(err, "invalid --keep pattern: %s", pattern))
+		}
+	}
+	return nil
+}
+
+// apply returns the images that are not kept by the policy.
+func (p retentionPolicy) apply(ctx context.Context, apiClient client.APIClient, images []image.Summary, now time.Time) ([]image.Summary, error) {
+	kept := map[string]bool{}
+	if p.keepLast > 0 {
+		all, err := apiClient.ImageList(ctx, image.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range lastTaggedImages(all, p.keepLast) {
+			kept[id] = true
+		}
+	}
+
+	result := make([]image.Summary, 0, len(images))
+	for _, img := range images {
+		if kept[img.ID] || p.matchKeep(img.RepoTags) {
+			continue
+		}
+		if p.keepRecent > 0 {
+			inspect, _, err := apiClient.ImageInspectWithRaw(ctx, img.ID)
+			if err != nil {
+				return nil, err
+			}
+			// Images that were never tagged have no last tag time.
+			last := inspect.Metadata.LastTagTime
+			if last.IsZero() {
+				last = time.Unix(img.Created, 0)
+			}
+			if now.Sub(last) < p.keepRecent {
+				continue
+			}
+		}
+		result = append(result, img)
+	}
+	return result, nil
+}
+
+// matchKeep returns whether one of the tags matches a keep pattern.
+func (p retentionPolicy) matchKeep(tags []string) bool {
+	for _, tag := range tags {
+		ref, err := reference.ParseNormalizedNamed(tag)
+		if err != nil {
+			continue
+		}
+		for _, pattern := range p.keep {
+			if ok, _ := reference.FamiliarMatch(pattern, ref); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lastTaggedImages returns the IDs of the images of the n most recent tags
+// of each repository.
+func lastTaggedImages(images []image.Summary, n int) []string {
+	type tagged struct {
+		tag     string
+		id      string
+		created int64
+	}
+	byRepo := map[string][]tagged{}
+	for _, img := range images {
+		for _, t := range img.RepoTags {
+			ref, err := reference.ParseNormalizedNamed(t)
+			if err != nil {
+				continue
+			}
+			repo := reference.FamiliarName(ref)
+			byRepo[repo] = append(byRepo[repo], tagged{tag: t, id: img.ID, created: img.Created})
+		}
+	}
+
+	var ids []string
+	for _, tags := range byRepo {
+		sort.Slice(tags, func(i, j int) bool {
+			if tags[i].created != tags[j].created {
+				return tags[i].created > tags[j].created
+			}
+			return tags[i].tag < tags[j].tag
+		})
+		for i := 0; i < n && i < len(tags); i++ {
+			ids = append(ids, tags[i].id)
+		}
+	}
+	return ids
+}
+
+// pruneWithPolicy removes the images that would be removed by a prune with
+// the given filters, and that are not kept by the retention policy of the
+// options. The images are removed one by one, as the daemon doesn't support
+// retention policies.
+func pruneWithPolicy(ctx context.Context, dockerCli command.Cli, pruneFilters filters.Args, options pruneOptions) (spaceReclaimed uint64, output string, err error) {
+	images, err := pruneCandidates(ctx, dockerCli, pruneFilters, options.all, options.policy)
+	if err != nil {
+		return 0, "", err
+	}
+
+	var sb strings.Builder
+	for _, img := range images {
+		// Force the removal of images with several tags. Images used by
+		// containers are not candidates.
+		deleted, err := dockerCli.Client().ImageRemove(ctx, img.ID, image.RemoveOptions{Force: true, PruneChildren: true})
+		if errdefs.IsNotFound(err) {
+			// Already removed with the image it was the parent of.
+			continue
+		}
+		if err != nil {
+			return spaceReclaimed, sb.String(), errors.Wrapf(err, "failed to remove image %s", img.ID)
+		}
+		if sb.Len() == 0 {
+			sb.WriteString("Deleted Images:\n")
+		}
+		for _, d := range deleted {
+			if d.Untagged != "" {
+				sb.WriteString("untagged: " + d.Untagged + "\n")
+			} else {
+				sb.WriteString("deleted: " + d.Deleted + "\n")
+			}
+		}
+		spaceReclaimed += reclaimableSpace([]image.Summary{img})
+	}
+	return spaceReclaimed, sb.String(), nil
+}
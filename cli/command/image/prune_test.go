@@ -6,6 +6,7 @@
 	"io"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/cli/cli/streams"
 	"github.com/docker/cli/internal/test"
@@ -37,6 +38,16 @@ func TestNewPruneCommandErrors(t *testing.T) {
 				return image.PruneReport{}, errors.Errorf("something went wrong")
 			},
 		},
+		{
+			name:          "negative-keep-last",
+			args:          []string{"--keep-last", "-1"},
+			expectedError: "--keep-last must be positive",
+		},
+		{
+			name:          "invalid-keep-pattern",
+			args:          []string{"--keep", "[a-"},
+			expectedError: "invalid --keep pattern: [a-: syntax error in pattern",
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -129,3 +140,85 @@ func TestPrunePromptTermination(t *testing.T) {
 	cmd.SetErr(io.Discard)
 	test.TerminatePrompt(ctx, t, cmd, cli)
 }
+
+func TestPruneDryRun(t *testing.T) {
+	now := time.Now()
+	images := []image.Summary{
+		{ID: "sha256:unused", RepoTags: []string{"foo:latest"}, RepoDigests: []string{"foo@sha256:1234"}, Created: now.Add(-48 * time.Hour).Unix(), Size: 3000, SharedSize: 1000},
+		{ID: "sha256:dangling", RepoTags: []string{"<none>:<none>"}, Created: now.Add(-48 * time.Hour).Unix(), Size: 2000},
+		{ID: "sha256:recent", Created: now.Unix(), Size: 4000},
+		{ID: "sha256:used", RepoTags: []string{"bar:latest"}, Created: now.Add(-48 * time.Hour).Unix(), Size: 8000, Containers: 1},
+		{ID: "sha256:kept", Created: now.Add(-48 * time.Hour).Unix(), Size: 16000, Labels: map[string]string{"keep": "true"}},
+	}
+	cli := test.NewFakeCli(&fakeClient{
+		imagesPruneFunc: func(filters.Args) (image.PruneReport, error) {
+			return image.PruneReport{}, errors.New("fakeClient imagesPruneFunc should not be called")
+		},
+		imageListFunc: func(options image.ListOptions) ([]image.Summary, error) {
+			assert.Check(t, options.SharedSize)
+			assert.Check(t, options.ContainerCount)
+			assert.Check(t, is.DeepEqual(options.Filters.Get("dangling"), []string{}))
+			return images, nil
+		},
+	})
+	cmd := NewPruneCommand(cli)
+	cmd.SetArgs([]string{"--dry-run", "--all", "--filter", "until=24h", "--filter", "label!=keep"})
+	assert.NilError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "prune-command-dry-run.golden")
+}
+
+func TestPruneRetentionPolicy(t *testing.T) {
+	now := time.Now()
+	images := []image.Summary{
+		{ID: "sha256:app3", RepoTags: []string{"example.com/app:3"}, Created: now.Add(-24 * time.Hour).Unix(), Size: 1000},
+		{ID: "sha256:app2", RepoTags: []string{"example.com/app:2"}, Created: now.Add(-48 * time.Hour).Unix(), Size: 1000},
+		{ID: "sha256:app1", RepoTags: []string{"example.com/app:1"}, Created: now.Add(-72 * time.Hour).Unix(), Size: 1000},
+		{ID: "sha256:base", RepoTags: []string{"myorg/base:1", "old:latest"}, Created: now.Add(-96 * time.Hour).Unix(), Size: 2000},
+		{ID: "sha256:newest", RepoTags: []string{"alpine:3.19"}, Created: now.Add(-500 * time.Hour).Unix(), Size: 4000},
+		{ID: "sha256:pulled", RepoTags: []string{"alpine:3.18"}, Created: now.Add(-1000 * time.Hour).Unix(), Size: 4000},
+		{ID: "sha256:old", RepoTags: []string{"alpine:3.17"}, Created: now.Add(-2000 * time.Hour).Unix(), Size: 8000},
+	}
+	newClient := func(removed *[]string) *fakeClient {
+		return &fakeClient{
+			imagesPruneFunc: func(filters.Args) (image.PruneReport, error) {
+				return image.PruneReport{}, errors.New("fakeClient imagesPruneFunc should not be called")
+			},
+			imageListFunc: func(options image.ListOptions) ([]image.Summary, error) {
+				return images, nil
+			},
+			imageInspectFunc: func(img string) (image.InspectResponse, []byte, error) {
+				var lastTagTime time.Time
+				if img == "sha256:pulled" {
+					lastTagTime = now.Add(-time.Hour)
+				}
+				return image.InspectResponse{ID: img, Metadata: image.Metadata{LastTagTime: lastTagTime}}, nil, nil
+			},
+			imageRemoveFunc: func(img string, options image.RemoveOptions) ([]image.DeleteResponse, error) {
+				assert.Check(t, options.Force)
+				*removed = append(*removed, img)
+				return []image.DeleteResponse{{Deleted: img}}, nil
+			},
+		}
+	}
+	args := []string{"--all", "--keep-last", "1", "--keep-recent", "24h", "--keep", "myorg/*"}
+
+	t.Run("dry-run", func(t *testing.T) {
+		var removed []string
+		cli := test.NewFakeCli(newClient(&removed))
+		cmd := NewPruneCommand(cli)
+		cmd.SetArgs(append([]string{"--dry-run"}, args...))
+		assert.NilError(t, cmd.Execute())
+		assert.Check(t, is.Len(removed, 0))
+		golden.Assert(t, cli.OutBuffer().String(), "prune-command-retention-policy-dry-run.golden")
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		var removed []string
+		cli := test.NewFakeCli(newClient(&removed))
+		cmd := NewPruneCommand(cli)
+		cmd.SetArgs(append([]string{"--force"}, args...))
+		assert.NilError(t, cmd.Execute())
+		assert.Check(t, is.DeepEqual(removed, []string{"sha256:app2", "sha256:app1", "sha256:old"}))
+		golden.Assert(t, cli.OutBuffer().String(), "prune-command-retention-policy.golden")
+	})
+}
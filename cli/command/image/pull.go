@@ -4,25 +4,37 @@
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/containerd/platforms"
 	"github.com/distribution/reference"
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
 	"github.com/docker/cli/cli/trust"
+	"github.com/docker/docker/api/types/system"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // PullOptions defines what and how to pull
 type PullOptions struct {
-	remote    string
-	all       bool
-	platform  string
-	quiet     bool
-	untrusted bool
+	remote       string
+	all          bool
+	platform     string
+	allPlatforms bool
+	quiet        bool
+	progress     string
+	untrusted    bool
+	retries      int
+	retryBackoff time.Duration
 }
 
+// defaultPullRetryBackoff is the time to wait before retrying a failed pull
+// for the first time. The time is doubled for each following retry.
+const defaultPullRetryBackoff = time.Second
+
 // NewPullCommand creates a new `docker pull` command
 func NewPullCommand(dockerCli command.Cli) *cobra.Command {
 	var opts PullOptions
@@ -33,6 +45,9 @@ func NewPullCommand(dockerCli command.Cli) *cobra.Command {
 		Args:  cli.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.remote = args[0]
+			if err := pullRetryDefaults(dockerCli, cmd.Flags(), &opts); err != nil {
+				return err
+			}
 			return RunPull(cmd.Context(), dockerCli, opts)
 		},
 		Annotations: map[string]string{
@@ -46,24 +61,41 @@ func NewPullCommand(dockerCli command.Cli) *cobra.Command {
 
 	flags.BoolVarP(&opts.all, "all-tags", "a", false, "Download all tagged images in the repository")
 	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Suppress verbose output")
+	flags.StringVar(&opts.progress, "progress", command.ProgressAuto, `Set type of progress output ("auto", "json"). Use "json" to print the progress as JSON`)
+	flags.BoolVar(&opts.allPlatforms, "all-platforms", false, "Download all the platforms of a multi-platform image (requires the containerd image store)")
+	flags.IntVar(&opts.retries, "retries", 0, "Number of times to retry a pull that failed because of a transient error")
+	flags.DurationVar(&opts.retryBackoff, "retry-backoff", defaultPullRetryBackoff, "Time to wait before the first retry, doubled for each following retry")
 
 	command.AddPlatformFlag(flags, &opts.platform)
 	command.AddTrustVerificationFlags(flags, &opts.untrusted, dockerCli.ContentTrustEnabled())
 
+	_ = cmd.RegisterFlagCompletionFunc("progress", completion.FromList(command.ProgressAuto, command.ProgressJSON))
+
 	return cmd
 }
 
 // RunPull performs a pull against the engine based on the specified options
 func RunPull(ctx context.Context, dockerCLI command.Cli, opts PullOptions) error {
+	if err := command.ValidateProgressOpt(opts.progress); err != nil {
+		return err
+	}
+	jsonProgress := opts.progress == command.ProgressJSON
+
 	distributionRef, err := reference.ParseNormalizedNamed(opts.remote)
 	switch {
 	case err != nil:
 		return err
+	case opts.quiet && jsonProgress:
+		return errors.New("--quiet can't be used with --progress json")
 	case opts.all && !reference.IsNameOnly(distributionRef):
 		return errors.New("tag can't be used with --all-tags/-a")
+	case opts.allPlatforms && opts.all:
+		return errors.New("--all-platforms can't be used with --all-tags/-a")
+	case opts.allPlatforms && opts.platform != "":
+		return errors.New("--all-platforms can't be used with --platform")
 	case !opts.all && reference.IsNameOnly(distributionRef):
 		distributionRef = reference.TagNameOnly(distributionRef)
-		if tagged, ok := distributionRef.(reference.Tagged); ok && !opts.quiet {
+		if tagged, ok := distributionRef.(reference.Tagged); ok && !opts.quiet && !jsonProgress {
 			fmt.Fprintf(dockerCLI.Out(), "Using default tag: %s\n", tagged.Tag())
 		}
 	}
@@ -75,10 +107,14 @@ func RunPull(ctx context.Context, dockerCLI command.Cli, opts PullOptions) error
 
 	// Check if reference has a digest
 	_, isCanonical := distributionRef.(reference.Canonical)
+	pull := imagePullPrivileged
 	if !opts.untrusted && !isCanonical {
-		err = trustedPull(ctx, dockerCLI, imgRefAndAuth, opts)
+		pull = trustedPull
+	}
+	if opts.allPlatforms {
+		err = pullAllPlatforms(ctx, dockerCLI, imgRefAndAuth, opts, pull)
 	} else {
-		err = imagePullPrivileged(ctx, dockerCLI, imgRefAndAuth, opts)
+		err = pull(ctx, dockerCLI, imgRefAndAuth, opts)
 	}
 	if err != nil {
 		if strings.Contains(err.Error(), "when fetching 'plugin'") {
@@ -86,6 +122,103 @@ func RunPull(ctx context.Context, dockerCLI command.Cli, opts PullOptions) error
 		}
 		return err
 	}
-	fmt.Fprintln(dockerCLI.Out(), imgRefAndAuth.Reference().String())
+	if !jsonProgress {
+		fmt.Fprintln(dockerCLI.Out(), imgRefAndAuth.Reference().String())
+	}
+	return nil
+}
+
+// pullAllPlatforms pulls every platform of a multi-platform image, one after
+// the other. The image is pulled as is if it's not a multi-platform image.
+func pullAllPlatforms(ctx context.Context, dockerCLI command.Cli, imgRefAndAuth trust.ImageRefAndAuth, opts PullOptions, pull func(context.Context, command.Cli, trust.ImageRefAndAuth, PullOptions) error) error {
+	info, err := dockerCLI.Client().Info(ctx)
+	if err != nil {
+		return err
+	}
+	if !usesContainerdStore(info) {
+		return errors.New("--all-platforms requires the containerd image store, as the other image stores can only store one platform of an image")
+	}
+
+	registryClient := dockerCLI.RegistryClient(false)
+	if _, err := registryClient.GetManifest(ctx, imgRefAndAuth.Reference()); err == nil {
+		// Not a multi-platform image.
+		return pull(ctx, dockerCLI, imgRefAndAuth, opts)
+	}
+	manifests, err := registryClient.GetManifestList(ctx, imgRefAndAuth.Reference())
+	if err != nil {
+		return err
+	}
+	var pullPlatforms []string
+	for _, m := range manifests {
+		// Skip the attestations, and the other manifests that are not images.
+		if p := m.Descriptor.Platform; p != nil && p.OS != "unknown" {
+			pullPlatforms = append(pullPlatforms, platforms.Format(*p))
+		}
+	}
+	if len(pullPlatforms) == 0 {
+		return pull(ctx, dockerCLI, imgRefAndAuth, opts)
+	}
+
+	for _, p := range pullPlatforms {
+		if !opts.quiet && opts.progress != command.ProgressJSON {
+			_, _ = fmt.Fprintf(dockerCLI.Out(), "Pulling %s\n", p)
+		}
+		opts.platform = p
+		if err := pull(ctx, dockerCLI, imgRefAndAuth, opts); err != nil {
+			return errors.Wrapf(err, "failed to pull %s", p)
+		}
+	}
 	return nil
 }
+
+// usesContainerdStore returns whether the daemon uses the containerd image
+// store.
+func usesContainerdStore(info system.Info) bool {
+	for _, status := range info.DriverStatus {
+		if status[0] == "driver-type" && status[1] == "io.containerd.snapshotter.v1" {
+			return true
+		}
+	}
+	return false
+}
+
+// pullRetryDefaults sets the number of retries and the backoff of a pull to
+// the values of the configuration file, if they are not set on the command
+// line.
+func pullRetryDefaults(dockerCLI command.Cli, flags *pflag.FlagSet, opts *PullOptions) error {
+	configFile := dockerCLI.ConfigFile()
+	if !flags.Changed("retries") && configFile.PullRetries > 0 {
+		opts.retries = configFile.PullRetries
+	}
+	if !flags.Changed("retry-backoff") && configFile.PullRetryBackoff != "" {
+		backoff, err := time.ParseDuration(configFile.PullRetryBackoff)
+		if err != nil {
+			return errors.Wrap(err, "invalid pullRetryBackoff in the configuration file")
+		}
+		opts.retryBackoff = backoff
+	}
+	if opts.retries < 0 {
+		return errors.New("--retries must be positive")
+	}
+	return nil
+}
+
+// retryPull calls pull, and calls it again if it fails with a transient
+// error, up to the number of retries of opts. Layers downloaded by a failed
+// attempt are not downloaded again by the daemon.
+func retryPull(ctx context.Context, dockerCLI command.Cli, opts PullOptions, pull func() error) error {
+	backoff := opts.retryBackoff
+	for attempt := 1; ; attempt++ {
+		err := pull()
+		if err == nil || attempt > opts.retries || !command.IsTransientError(err) {
+			return err
+		}
+		command.PrintRetry(dockerCLI.Err(), "Pull", err, backoff, attempt, opts.retries)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,76 @@
+package image
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+)
+
+// Events emitted by "docker pull --progress json".
+const (
+	pullEventLayer  = "layer"
+	pullEventPulled = "pulled"
+)
+
+// pullEvent is an event of a pull, printed by "docker pull --progress json".
+// Layer events report the progress of a layer, and a pulled event is emitted
+// when an image is pulled, with the digest of the image.
+type pullEvent struct {
+	Time     time.Time `json:"time"`
+	Event    string    `json:"event"`
+	Image    string    `json:"image,omitempty"`
+	Platform string    `json:"platform,omitempty"`
+	Layer    string    `json:"layer,omitempty"`
+	Status   string    `json:"status,omitempty"`
+	Current  int64     `json:"current,omitempty"`
+	Total    int64     `json:"total,omitempty"`
+	Digest   string    `json:"digest,omitempty"`
+}
+
+// writePullEvents reads the progress messages of a pull from in, and writes
+// them to out as newline-delimited JSON pull events. It returns the error of
+// the pull, if any.
+func writePullEvents(in io.Reader, out io.Writer, image, platform string) error {
+	enc := json.NewEncoder(out)
+	dec := json.NewDecoder(in)
+	var digest string
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := dec.Decode(&msg); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if msg.Error != nil {
+			return msg.Error
+		}
+
+		ev := pullEvent{Time: time.Now().UTC(), Image: image, Platform: platform}
+		switch {
+		case strings.HasPrefix(msg.Status, "Digest: "):
+			digest = strings.TrimPrefix(msg.Status, "Digest: ")
+			continue
+		case strings.HasPrefix(msg.Status, "Status: "):
+			ev.Event = pullEventPulled
+			ev.Status = strings.TrimPrefix(msg.Status, "Status: ")
+			ev.Digest = digest
+		case msg.ID != "" && !strings.HasPrefix(msg.Status, "Pulling from "):
+			ev.Event = pullEventLayer
+			ev.Layer = msg.ID
+			ev.Status = msg.Status
+			if msg.Progress != nil {
+				ev.Current = msg.Progress.Current
+				ev.Total = msg.Progress.Total
+			}
+		default:
+			continue
+		}
+		if err := enc.Encode(ev); err != nil {
+			return err
+		}
+	}
+}
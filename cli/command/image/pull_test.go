@@ -1,15 +1,25 @@
 package image
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/distribution/reference"
+	manifesttypes "github.com/docker/cli/cli/manifest/types"
 	"github.com/docker/cli/internal/test"
 	"github.com/docker/cli/internal/test/notary"
 	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/system"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/jsonmessage"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"gotest.tools/v3/assert"
 	is "gotest.tools/v3/assert/cmp"
 	"gotest.tools/v3/golden"
@@ -36,6 +46,16 @@ func TestNewPullCommandErrors(t *testing.T) {
 			expectedError: "tag can't be used with --all-tags/-a",
 			args:          []string{"--all-tags", "image:tag"},
 		},
+		{
+			name:          "invalid-progress",
+			expectedError: `invalid progress type "plain": must be "auto" or "json"`,
+			args:          []string{"--progress", "plain", "image:tag"},
+		},
+		{
+			name:          "quiet-with-json-progress",
+			expectedError: "--quiet can't be used with --progress json",
+			args:          []string{"--quiet", "--progress", "json", "image:tag"},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -136,3 +156,210 @@ func TestNewPullCommandWithContentTrustErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestNewPullCommandAllPlatforms(t *testing.T) {
+	var pulled []string
+	cli := test.NewFakeCli(&fakeClient{
+		infoFunc: func() (system.Info, error) {
+			return system.Info{DriverStatus: [][2]string{{"driver-type", "io.containerd.snapshotter.v1"}}}, nil
+		},
+		imagePullFunc: func(ref string, options image.PullOptions) (io.ReadCloser, error) {
+			assert.Check(t, is.Equal(ref, "image:tag"))
+			pulled = append(pulled, options.Platform)
+			return io.NopCloser(strings.NewReader("")), nil
+		},
+	})
+	cli.SetRegistryClient(&fakeRegistryClient{
+		getManifestFunc: func(context.Context, reference.Named) (manifesttypes.ImageManifest, error) {
+			return manifesttypes.ImageManifest{}, errors.New("is a manifest list")
+		},
+		getManifestListFunc: func(context.Context, reference.Named) ([]manifesttypes.ImageManifest, error) {
+			return []manifesttypes.ImageManifest{
+				{Descriptor: ocispec.Descriptor{Platform: &ocispec.Platform{OS: "linux", Architecture: "amd64"}}},
+				{Descriptor: ocispec.Descriptor{Platform: &ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}}},
+				{Descriptor: ocispec.Descriptor{Platform: &ocispec.Platform{OS: "unknown", Architecture: "unknown"}}},
+			}, nil
+		},
+	})
+	cmd := NewPullCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--all-platforms", "--disable-content-trust", "image:tag"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.DeepEqual(pulled, []string{"linux/amd64", "linux/arm/v7"}))
+	golden.Assert(t, cli.OutBuffer().String(), "pull-command-success.all-platforms.golden")
+}
+
+func TestNewPullCommandAllPlatformsErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		args          []string
+		driverStatus  [][2]string
+		expectedError string
+	}{
+		{
+			name:          "with-platform",
+			args:          []string{"--all-platforms", "--platform", "linux/amd64", "image:tag"},
+			expectedError: "--all-platforms can't be used with --platform",
+		},
+		{
+			name:          "with-all-tags",
+			args:          []string{"--all-platforms", "--all-tags", "image"},
+			expectedError: "--all-platforms can't be used with --all-tags/-a",
+		},
+		{
+			name:          "graphdriver-store",
+			args:          []string{"--all-platforms", "image:tag"},
+			driverStatus:  [][2]string{{"Backing Filesystem", "extfs"}},
+			expectedError: "--all-platforms requires the containerd image store",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cli := test.NewFakeCli(&fakeClient{
+				infoFunc: func() (system.Info, error) {
+					return system.Info{DriverStatus: tc.driverStatus}, nil
+				},
+				imagePullFunc: func(ref string, options image.PullOptions) (io.ReadCloser, error) {
+					return nil, errors.New("shouldn't try to pull image")
+				},
+			})
+			cmd := NewPullCommand(cli)
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(tc.args)
+			assert.ErrorContains(t, cmd.Execute(), tc.expectedError)
+		})
+	}
+}
+
+func TestNewPullCommandRetries(t *testing.T) {
+	testCases := []struct {
+		name          string
+		args          []string
+		configRetries int
+		errs          []error
+		expectedPulls int
+		expectedError string
+	}{
+		{
+			name:          "success-after-retry",
+			args:          []string{"--retries", "2", "--retry-backoff", "1ms", "image:tag"},
+			errs:          []error{errors.New("connection reset by peer")},
+			expectedPulls: 2,
+		},
+		{
+			name:          "too-many-failures",
+			args:          []string{"--retries", "2", "--retry-backoff", "1ms", "image:tag"},
+			errs:          []error{errors.New("connection reset by peer"), errors.New("unexpected EOF"), errors.New("unexpected EOF")},
+			expectedPulls: 3,
+			expectedError: "unexpected EOF",
+		},
+		{
+			name:          "not-found",
+			args:          []string{"--retries", "2", "--retry-backoff", "1ms", "image:tag"},
+			errs:          []error{errdefs.NotFound(errors.New("manifest unknown"))},
+			expectedPulls: 1,
+			expectedError: "manifest unknown",
+		},
+		{
+			name:          "manifest-unknown",
+			args:          []string{"--retries", "2", "--retry-backoff", "1ms", "image:tag"},
+			errs:          []error{&jsonmessage.JSONError{Message: "manifest unknown"}},
+			expectedPulls: 1,
+			expectedError: "manifest unknown",
+		},
+		{
+			name:          "no-retries",
+			args:          []string{"image:tag"},
+			errs:          []error{errors.New("connection reset by peer")},
+			expectedPulls: 1,
+			expectedError: "connection reset by peer",
+		},
+		{
+			name:          "config-retries",
+			args:          []string{"--retry-backoff", "1ms", "image:tag"},
+			configRetries: 1,
+			errs:          []error{errors.New("connection reset by peer")},
+			expectedPulls: 2,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			pulls := 0
+			cli := test.NewFakeCli(&fakeClient{
+				imagePullFunc: func(ref string, options image.PullOptions) (io.ReadCloser, error) {
+					pulls++
+					if pulls <= len(tc.errs) {
+						return nil, tc.errs[pulls-1]
+					}
+					return io.NopCloser(strings.NewReader("")), nil
+				},
+			})
+			cli.ConfigFile().PullRetries = tc.configRetries
+			cmd := NewPullCommand(cli)
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(append([]string{"--disable-content-trust"}, tc.args...))
+			err := cmd.Execute()
+			if tc.expectedError != "" {
+				assert.Error(t, err, tc.expectedError)
+			} else {
+				assert.NilError(t, err)
+			}
+			assert.Check(t, is.Equal(pulls, tc.expectedPulls))
+		})
+	}
+}
+
+func TestNewPullCommandProgressJSON(t *testing.T) {
+	const stream = `{"status":"Pulling from library/image","id":"tag"}
+{"status":"Pulling fs layer","progressDetail":{},"id":"4abcf2066143"}
+{"status":"Downloading","progressDetail":{"current":1024,"total":3409},"progress":"[====>     ]","id":"4abcf2066143"}
+{"status":"Pull complete","progressDetail":{},"id":"4abcf2066143"}
+{"status":"Digest: sha256:b89d9c93e9ed3597455c90a0b88a8bbb5cb7188438f70953fede212a0c4394e0"}
+{"status":"Status: Downloaded newer image for image:tag"}
+`
+	cli := test.NewFakeCli(&fakeClient{
+		imagePullFunc: func(ref string, options image.PullOptions) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(stream)), nil
+		},
+	})
+	cmd := NewPullCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{"--disable-content-trust", "--progress", "json", "image:tag"})
+	assert.NilError(t, cmd.Execute())
+
+	var events []pullEvent
+	dec := json.NewDecoder(bytes.NewReader(cli.OutBuffer().Bytes()))
+	for dec.More() {
+		var ev pullEvent
+		assert.NilError(t, dec.Decode(&ev))
+		assert.Check(t, !ev.Time.IsZero())
+		ev.Time = time.Time{}
+		events = append(events, ev)
+	}
+	const ref = "docker.io/library/image:tag"
+	assert.Check(t, is.DeepEqual(events, []pullEvent{
+		{Event: pullEventLayer, Image: ref, Layer: "4abcf2066143", Status: "Pulling fs layer"},
+		{Event: pullEventLayer, Image: ref, Layer: "4abcf2066143", Status: "Downloading", Current: 1024, Total: 3409},
+		{Event: pullEventLayer, Image: ref, Layer: "4abcf2066143", Status: "Pull complete"},
+		{Event: pullEventPulled, Image: ref, Status: "Downloaded newer image for image:tag", Digest: "sha256:b89d9c93e9ed3597455c90a0b88a8bbb5cb7188438f70953fede212a0c4394e0"},
+	}))
+}
+
+func TestNewPullCommandProgressJSONError(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		imagePullFunc: func(ref string, options image.PullOptions) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(`{"errorDetail":{"message":"manifest unknown"},"error":"manifest unknown"}` + "\n")), nil
+		},
+	})
+	cmd := NewPullCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--disable-content-trust", "--progress", "json", "image:tag"})
+	assert.Error(t, cmd.Execute(), "manifest unknown")
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), ""))
+}
@@ -0,0 +1,332 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/distribution/reference"
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/opts"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type relabelOptions struct {
+	image    string
+	target   string
+	labels   opts.ListOpts
+	rmLabels []string
+}
+
+// NewRelabelCommand creates a new `docker image relabel` command
+func NewRelabelCommand(dockerCli command.Cli) *cobra.Command {
+	options := relabelOptions{labels: opts.NewListOpts(opts.ValidateLabel)}
+
+	cmd := &cobra.Command{
+		Use:   "relabel [OPTIONS] IMAGE [TARGET_IMAGE[:TAG]]",
+		Short: "Create an image with the layers of an image, and different labels",
+		Args:  cli.RequiresRangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			if len(args) > 1 {
+				options.target = args[1]
+			}
+			return runRelabel(cmd.Context(), dockerCli, options)
+		},
+		ValidArgsFunction: completion.ImageNames(dockerCli),
+	}
+
+	flags := cmd.Flags()
+	flags.Var(&options.labels, "label", "Set a label on the image")
+	flags.StringSliceVar(&options.rmLabels, "rm-label", nil, "Remove a label from the image")
+
+	return cmd
+}
+
+func runRelabel(ctx context.Context, dockerCli command.Cli, options relabelOptions) error {
+	setLabels := opts.ConvertKVStringsToMap(options.labels.GetAll())
+	if len(setLabels) == 0 && len(options.rmLabels) == 0 {
+		return errors.New("no labels to set or remove: use --label or --rm-label")
+	}
+
+	img, _, err := dockerCli.Client().ImageInspectWithRaw(ctx, options.image)
+	if err != nil {
+		return err
+	}
+	var repoTags []string
+	if options.target != "" {
+		ref, err := reference.ParseNormalizedNamed(options.target)
+		if err != nil {
+			return err
+		}
+		if _, ok := ref.(reference.Digested); ok {
+			return errors.New("refusing to create a tag with a digest reference")
+		}
+		repoTags = []string{reference.FamiliarString(reference.TagNameOnly(ref))}
+	} else if ref, err := reference.ParseNormalizedNamed(options.image); err == nil {
+		// Move the tag of the image to the new image, if the image was
+		// referenced by its tag.
+		tag := reference.FamiliarString(reference.TagNameOnly(ref))
+		for _, t := range img.RepoTags {
+			if t == tag {
+				repoTags = []string{tag}
+			}
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "docker-relabel-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	responseBody, err := dockerCli.Client().ImageSave(ctx, []string{options.image})
+	if err != nil {
+		return err
+	}
+	err = extractTar(responseBody, dir)
+	responseBody.Close()
+	if err != nil {
+		return err
+	}
+
+	if err := relabelArchive(dir, repoTags, setLabels, options.rmLabels); err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeTar(pw, dir, nil))
+	}()
+	defer pr.Close()
+
+	response, err := dockerCli.Client().ImageLoad(ctx, pr, true)
+	if err != nil {
+		return err
+	}
+	return printLoadResponse(dockerCli, response, true)
+}
+
+// relabelArchive updates the labels of the image of an archive created by
+// "docker save", extracted to dir, and tags the image with repoTags. The
+// configuration of the image is written to a new file, and the manifests
+// are updated to refer to it, so that the archive can be loaded as a new
+// image that has the same layers.
+func relabelArchive(dir string, repoTags []string, setLabels map[string]string, rmLabels []string) error {
+	var manifests []archiveManifest
+	if err := readLayoutJSON(filepath.Join(dir, "manifest.json"), &manifests); err != nil {
+		return errors.Wrap(err, "failed to read the image archive")
+	}
+	if len(manifests) != 1 {
+		return errors.Errorf("expected a single image in the image archive, found %d", len(manifests))
+	}
+	m := manifests[0]
+
+	configFile, err := archivePath(dir, m.Config)
+	if err != nil {
+		return err
+	}
+	config, err := os.ReadFile(configFile)
+	if err != nil {
+		return err
+	}
+	config, err = relabelConfig(config, setLabels, rmLabels)
+	if err != nil {
+		return err
+	}
+	configDigest := digest.FromBytes(config)
+
+	if _, err := os.Stat(filepath.Join(dir, ocispec.ImageIndexFile)); err == nil {
+		// OCI image layout, created by daemons with API v1.44 and up.
+		oldConfigDigest := digest.NewDigestFromEncoded(digest.Algorithm(path.Base(path.Dir(m.Config))), path.Base(m.Config))
+		m.Config = blobName(configDigest)
+		if err := relabelIndex(dir, oldConfigDigest, configDigest, int64(len(config)), repoTags); err != nil {
+			return err
+		}
+	} else if errors.Is(err, fs.ErrNotExist) {
+		m.Config = configDigest.Encoded() + ".json"
+	} else {
+		return err
+	}
+	if err := writeArchiveFile(dir, m.Config, config); err != nil {
+		return err
+	}
+
+	m.RepoTags = repoTags
+	manifestJSON, err := json.Marshal([]archiveManifest{m})
+	if err != nil {
+		return err
+	}
+	return writeArchiveFile(dir, "manifest.json", manifestJSON)
+}
+
+// relabelConfig returns the image configuration with the labels set and
+// removed. The other fields of the configuration are kept as is.
+func relabelConfig(config []byte, setLabels map[string]string, rmLabels []string) ([]byte, error) {
+	var img map[string]json.RawMessage
+	if err := json.Unmarshal(config, &img); err != nil {
+		return nil, errors.Wrap(err, "invalid image config")
+	}
+	var containerConfig map[string]json.RawMessage
+	if raw, ok := img["config"]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &containerConfig); err != nil {
+			return nil, errors.Wrap(err, "invalid image config")
+		}
+	}
+	if containerConfig == nil {
+		containerConfig = map[string]json.RawMessage{}
+	}
+	var labels map[string]string
+	if raw, ok := containerConfig["Labels"]; ok {
+		if err := json.Unmarshal(raw, &labels); err != nil {
+			return nil, errors.Wrap(err, "invalid image labels")
+		}
+	}
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	for _, key := range rmLabels {
+		delete(labels, key)
+	}
+	for key, value := range setLabels {
+		labels[key] = value
+	}
+
+	var err error
+	if containerConfig["Labels"], err = json.Marshal(labels); err != nil {
+		return nil, err
+	}
+	if img["config"], err = json.Marshal(containerConfig); err != nil {
+		return nil, err
+	}
+	return json.Marshal(img)
+}
+
+// relabelIndex writes a new manifest for the image with the oldConfig config
+// digest, referring to the new config, and replaces the index of the OCI
+// image layout in dir with an index of the new manifest, tagged with repoTags.
+func relabelIndex(dir string, oldConfig, newConfig digest.Digest, newConfigSize int64, repoTags []string) error {
+	var index ocispec.Index
+	if err := readLayoutJSON(filepath.Join(dir, ocispec.ImageIndexFile), &index); err != nil {
+		return err
+	}
+	desc, err := findManifest(dir, index.Manifests, oldConfig)
+	if err != nil {
+		return err
+	}
+
+	// Only update the config of the manifest, to keep the other fields as is.
+	var manifest map[string]json.RawMessage
+	if err := readBlobJSON(dir, desc.Digest, &manifest); err != nil {
+		return err
+	}
+	var config ocispec.Descriptor
+	if err := json.Unmarshal(manifest["config"], &config); err != nil {
+		return errors.Wrap(err, "invalid image manifest")
+	}
+	config.Digest, config.Size = newConfig, newConfigSize
+	if manifest["config"], err = json.Marshal(config); err != nil {
+		return err
+	}
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	desc.Digest = digest.FromBytes(manifestJSON)
+	desc.Size = int64(len(manifestJSON))
+	desc.Annotations = nil
+	if err := writeArchiveFile(dir, blobName(desc.Digest), manifestJSON); err != nil {
+		return err
+	}
+
+	var descs []ocispec.Descriptor
+	for _, tag := range repoTags {
+		ref, err := reference.ParseNormalizedNamed(tag)
+		if err != nil {
+			return err
+		}
+		d := desc
+		d.Annotations = map[string]string{
+			"io.containerd.image.name": ref.String(),
+			ocispec.AnnotationRefName:  ref.(reference.NamedTagged).Tag(),
+		}
+		descs = append(descs, d)
+	}
+	if len(descs) == 0 {
+		descs = []ocispec.Descriptor{desc}
+	}
+
+	indexJSON, err := json.Marshal(ocispec.Index{
+		Versioned: index.Versioned,
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: descs,
+	})
+	if err != nil {
+		return err
+	}
+	return writeArchiveFile(dir, ocispec.ImageIndexFile, indexJSON)
+}
+
+// findManifest returns the descriptor of the manifest of the image with the
+// given config digest, looking into the nested indexes of descs.
+func findManifest(dir string, descs []ocispec.Descriptor, config digest.Digest) (ocispec.Descriptor, error) {
+	for _, desc := range descs {
+		switch desc.MediaType {
+		case ocispec.MediaTypeImageIndex:
+			var nested ocispec.Index
+			if err := readBlobJSON(dir, desc.Digest, &nested); err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					continue
+				}
+				return ocispec.Descriptor{}, err
+			}
+			if d, err := findManifest(dir, nested.Manifests, config); err == nil {
+				return d, nil
+			}
+		case ocispec.MediaTypeImageManifest, dockerManifestMediaType:
+			var manifest ocispec.Manifest
+			if err := readBlobJSON(dir, desc.Digest, &manifest); err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					continue
+				}
+				return ocispec.Descriptor{}, err
+			}
+			if manifest.Config.Digest == config {
+				return desc, nil
+			}
+		}
+	}
+	return ocispec.Descriptor{}, errors.Errorf("no manifest found for the image config %s", config)
+}
+
+// archivePath returns the path of a file of an archive extracted to dir,
+// from its name in the archive.
+func archivePath(dir, name string) (string, error) {
+	name = path.Clean(name)
+	if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+		return "", errors.Errorf("invalid path in archive: %s", name)
+	}
+	return filepath.Join(dir, filepath.FromSlash(name)), nil
+}
+
+func writeArchiveFile(dir, name string, content []byte) error {
+	p, err := archivePath(dir, name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(p, content, 0o644)
+}
@@ -0,0 +1,162 @@
+package image
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/image"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func readTarFiles(t *testing.T, r io.Reader) map[string]string {
+	t.Helper()
+	files := map[string]string{}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		assert.NilError(t, err)
+		content, err := io.ReadAll(tr)
+		assert.NilError(t, err)
+		files[hdr.Name] = string(content)
+	}
+}
+
+func TestRelabelOCILayout(t *testing.T) {
+	config := `{"architecture":"amd64","os":"linux","config":{"Cmd":["sh"],"Labels":{"keep":"1","old":"1"}},"rootfs":{"type":"layers","diff_ids":["sha256:aaaa"]}}`
+	layer := "layer"
+	manifest, err := json.Marshal(ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    ocispec.Descriptor{MediaType: ocispec.MediaTypeImageConfig, Digest: digest.FromString(config), Size: int64(len(config))},
+		Layers:    []ocispec.Descriptor{{MediaType: ocispec.MediaTypeImageLayer, Digest: digest.FromString(layer), Size: int64(len(layer))}},
+	})
+	assert.NilError(t, err)
+	index, err := json.Marshal(ocispec.Index{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromBytes(manifest), Size: int64(len(manifest))}},
+	})
+	assert.NilError(t, err)
+	saved := map[string]string{
+		"oci-layout":                         `{"imageLayoutVersion":"1.0.0"}`,
+		"index.json":                         string(index),
+		"manifest.json":                      `[{"Config":"` + blobName(digest.FromString(config)) + `","RepoTags":["alpine:latest"],"Layers":["` + blobName(digest.FromString(layer)) + `"]}]`,
+		blobName(digest.FromString(config)):  config,
+		blobName(digest.FromString(layer)):   layer,
+		blobName(digest.FromBytes(manifest)): string(manifest),
+	}
+
+	var loaded map[string]string
+	cli := test.NewFakeCli(&fakeClient{
+		imageInspectFunc: func(img string) (image.InspectResponse, []byte, error) {
+			return image.InspectResponse{ID: "sha256:1234", RepoTags: []string{"alpine:latest"}}, nil, nil
+		},
+		imageSaveFunc: func(images []string) (io.ReadCloser, error) {
+			assert.Check(t, is.DeepEqual(images, []string{"alpine"}))
+			return tarFiles(t, saved), nil
+		},
+		imageLoadFunc: func(input io.Reader, quiet bool) (image.LoadResponse, error) {
+			loaded = readTarFiles(t, input)
+			return image.LoadResponse{Body: io.NopCloser(strings.NewReader("Loaded image: alpine:latest\n"))}, nil
+		},
+	})
+	cmd := NewRelabelCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{"--label", "new=2", "--rm-label", "old", "alpine"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "alpine:latest\n"))
+
+	var manifests []archiveManifest
+	assert.NilError(t, json.Unmarshal([]byte(loaded["manifest.json"]), &manifests))
+	assert.Assert(t, is.Len(manifests, 1))
+	assert.Check(t, is.DeepEqual(manifests[0].RepoTags, []string{"alpine:latest"}))
+	assert.Check(t, is.DeepEqual(manifests[0].Layers, []string{blobName(digest.FromString(layer))}))
+
+	newConfig := loaded[manifests[0].Config]
+	assert.Check(t, is.Equal(manifests[0].Config, blobName(digest.FromString(newConfig))))
+	assert.Check(t, is.Equal(newConfig, `{"architecture":"amd64","config":{"Cmd":["sh"],"Labels":{"keep":"1","new":"2"}},"os":"linux","rootfs":{"type":"layers","diff_ids":["sha256:aaaa"]}}`))
+
+	var newIndex ocispec.Index
+	assert.NilError(t, json.Unmarshal([]byte(loaded["index.json"]), &newIndex))
+	assert.Assert(t, is.Len(newIndex.Manifests, 1))
+	assert.Check(t, is.DeepEqual(newIndex.Manifests[0].Annotations, map[string]string{
+		"io.containerd.image.name": "docker.io/library/alpine:latest",
+		ocispec.AnnotationRefName:  "latest",
+	}))
+	var newManifest ocispec.Manifest
+	assert.NilError(t, json.Unmarshal([]byte(loaded[blobName(newIndex.Manifests[0].Digest)]), &newManifest))
+	assert.Check(t, is.Equal(newManifest.Config.Digest, digest.FromString(newConfig)))
+	assert.Check(t, is.Equal(newManifest.Config.Size, int64(len(newConfig))))
+	assert.Check(t, is.DeepEqual(newManifest.Layers, []ocispec.Descriptor{{MediaType: ocispec.MediaTypeImageLayer, Digest: digest.FromString(layer), Size: int64(len(layer))}}))
+}
+
+func TestRelabelLegacyArchive(t *testing.T) {
+	const config = `{"config":{"Labels":null}}`
+	var loaded map[string]string
+	cli := test.NewFakeCli(&fakeClient{
+		imageInspectFunc: func(img string) (image.InspectResponse, []byte, error) {
+			return image.InspectResponse{ID: "sha256:1234"}, nil, nil
+		},
+		imageSaveFunc: func(images []string) (io.ReadCloser, error) {
+			return tarFiles(t, map[string]string{
+				"manifest.json":  `[{"Config":"1234.json","RepoTags":null,"Layers":["abcd/layer.tar"]}]`,
+				"1234.json":      config,
+				"abcd/layer.tar": "layer",
+			}), nil
+		},
+		imageLoadFunc: func(input io.Reader, quiet bool) (image.LoadResponse, error) {
+			loaded = readTarFiles(t, input)
+			return image.LoadResponse{Body: io.NopCloser(strings.NewReader("Loaded image: example.com/app:relabeled\n"))}, nil
+		},
+	})
+	cmd := NewRelabelCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{"--label", "com.example.team=web", "1234", "example.com/app:relabeled"})
+	assert.NilError(t, cmd.Execute())
+
+	const newConfig = `{"config":{"Labels":{"com.example.team":"web"}}}`
+	assert.Check(t, is.Equal(loaded[digest.FromString(newConfig).Encoded()+".json"], newConfig))
+	assert.Check(t, is.Equal(loaded["manifest.json"], `[{"Config":"`+digest.FromString(newConfig).Encoded()+`.json","RepoTags":["example.com/app:relabeled"],"Layers":["abcd/layer.tar"]}]`))
+}
+
+func TestRelabelErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		args          []string
+		expectedError string
+	}{
+		{
+			name:          "no-labels",
+			args:          []string{"alpine"},
+			expectedError: "no labels to set or remove: use --label or --rm-label",
+		},
+		{
+			name:          "digest-target",
+			args:          []string{"--label", "a=b", "alpine", "alpine@sha256:b89d9c93e9ed3597455c90a0b88a8bbb5cb7188438f70953fede212a0c4394e0"},
+			expectedError: "refusing to create a tag with a digest reference",
+		},
+		{
+			name:          "too-many-args",
+			args:          []string{"a", "b", "c"},
+			expectedError: "requires at least 1 and at most 2 arguments",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := NewRelabelCommand(test.NewFakeCli(&fakeClient{}))
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(tc.args)
+			assert.ErrorContains(t, cmd.Execute(), tc.expectedError)
+		})
+	}
+}
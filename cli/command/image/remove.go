@@ -8,6 +8,9 @@
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/cli/command/formatter/tabwriter"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/errdefs"
 	"github.com/pkg/errors"
@@ -17,6 +20,7 @@
 type removeOptions struct {
 	force   bool
 	noPrune bool
+	yes     bool
 }
 
 // NewRemoveCommand creates a new `docker remove` command
@@ -40,6 +44,7 @@ func NewRemoveCommand(dockerCli command.Cli) *cobra.Command {
 
 	flags.BoolVarP(&opts.force, "force", "f", false, "Force removal of the image")
 	flags.BoolVar(&opts.noPrune, "no-prune", false, "Do not delete untagged parents")
+	flags.BoolVarP(&opts.yes, "yes", "y", false, "Do not prompt for confirmation when the image is used by stopped containers")
 
 	return cmd
 }
@@ -63,6 +68,15 @@ func runRemove(ctx context.Context, dockerCli command.Cli, opts removeOptions, i
 	fatalErr := false
 	for _, img := range images {
 		dels, err := client.ImageRemove(ctx, img, options)
+		if err != nil && !opts.force && errdefs.IsConflict(err) {
+			var confirmed bool
+			confirmed, err = confirmRemoveUsedImage(ctx, dockerCli, img, opts.yes, err)
+			if confirmed {
+				forceOptions := options
+				forceOptions.Force = true
+				dels, err = client.ImageRemove(ctx, img, forceOptions)
+			}
+		}
 		if err != nil {
 			if !errdefs.IsNotFound(err) {
 				fatalErr = true
@@ -88,3 +102,63 @@ func runRemove(ctx context.Context, dockerCli command.Cli, opts removeOptions, i
 	}
 	return nil
 }
+
+// confirmRemoveUsedImage asks the user to confirm the removal of an image
+// that can't be removed because it's used by stopped containers, listing
+// these containers. It returns false and the conflict error if the image
+// is also used by running containers, or by no containers, as forcing the
+// removal would not help, or if the user can't be prompted.
+func confirmRemoveUsedImage(ctx context.Context, dockerCli command.Cli, img string, yes bool, conflict error) (bool, error) {
+	if !yes && !dockerCli.In().IsTerminal() {
+		return false, conflict
+	}
+	apiClient := dockerCli.Client()
+	inspect, _, err := apiClient.ImageInspectWithRaw(ctx, img)
+	if err != nil {
+		return false, conflict
+	}
+	containers, err := apiClient.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("ancestor", inspect.ID)),
+	})
+	if err != nil {
+		return false, conflict
+	}
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 3, ' ', 0)
+	var stopped int
+	for _, c := range containers {
+		if c.ImageID != inspect.ID {
+			// Containers created from an image built on top of img.
+			continue
+		}
+		if c.State == "running" || c.State == "paused" || c.State == "restarting" {
+			return false, conflict
+		}
+		stopped++
+		name := c.ID
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		_, _ = fmt.Fprintf(w, "  %s\t(%s)\n", name, c.Status)
+	}
+	if stopped == 0 {
+		return false, conflict
+	}
+	if yes {
+		return true, nil
+	}
+	_ = w.Flush()
+
+	_, _ = fmt.Fprintf(dockerCli.Out(), "WARNING! The image %s is used by the following stopped container(s):\n", img)
+	_, _ = fmt.Fprint(dockerCli.Out(), b.String())
+	r, err := command.PromptForConfirmation(ctx, dockerCli.In(), dockerCli.Out(), "The containers will keep working, but will no longer refer to the image by name. Are you sure you want to remove it?")
+	if err != nil {
+		return false, err
+	}
+	if !r {
+		return false, errdefs.Cancelled(errors.Errorf("removal of image %s has been cancelled", img))
+	}
+	return true, nil
+}
@@ -3,10 +3,14 @@
 import (
 	"fmt"
 	"io"
+	"strings"
 	"testing"
 
+	"github.com/docker/cli/cli/streams"
 	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/errdefs"
 	"github.com/pkg/errors"
 	"gotest.tools/v3/assert"
 	is "gotest.tools/v3/assert/cmp"
@@ -134,3 +138,87 @@ func TestNewRemoveCommandSuccess(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoveImageUsedByStoppedContainers(t *testing.T) {
+	newClient := func(forced *bool, state string) *fakeClient {
+		return &fakeClient{
+			imageRemoveFunc: func(img string, options image.RemoveOptions) ([]image.DeleteResponse, error) {
+				if !options.Force {
+					return nil, errdefs.Conflict(errors.New("conflict: unable to remove repository reference \"image1\" (must force) - container 0123456789ab is using its referenced image 5e0da2bb4a93"))
+				}
+				*forced = true
+				return []image.DeleteResponse{{Untagged: img}}, nil
+			},
+			imageInspectFunc: func(img string) (image.InspectResponse, []byte, error) {
+				return image.InspectResponse{ID: "sha256:5e0da2bb4a93"}, nil, nil
+			},
+			containerListFunc: func(options container.ListOptions) ([]container.Summary, error) {
+				assert.Check(t, options.All)
+				assert.Check(t, is.DeepEqual(options.Filters.Get("ancestor"), []string{"sha256:5e0da2bb4a93"}))
+				return []container.Summary{
+					{ID: "0123456789ab", Names: []string{"/web"}, ImageID: "sha256:5e0da2bb4a93", State: state, Status: "Exited (0) 2 days ago"},
+					{ID: "ba9876543210", Names: []string{"/child"}, ImageID: "sha256:0d4b9a6a8b2c", State: "running", Status: "Up 2 hours"},
+				}, nil
+			},
+		}
+	}
+	newInput := func(answer string) *streams.In {
+		in := streams.NewIn(io.NopCloser(strings.NewReader(answer)))
+		in.SetIsTerminal(true)
+		return in
+	}
+
+	t.Run("confirmed", func(t *testing.T) {
+		var forced bool
+		cli := test.NewFakeCli(newClient(&forced, "exited"))
+		cli.SetIn(newInput("y\n"))
+		cmd := NewRemoveCommand(cli)
+		cmd.SetArgs([]string{"image1"})
+		assert.NilError(t, cmd.Execute())
+		assert.Check(t, forced)
+		assert.Check(t, is.Contains(cli.OutBuffer().String(), "WARNING! The image image1 is used by the following stopped container(s):\n  web   (Exited (0) 2 days ago)\n"))
+		assert.Check(t, is.Contains(cli.OutBuffer().String(), "Untagged: image1\n"))
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		var forced bool
+		cli := test.NewFakeCli(newClient(&forced, "exited"))
+		cli.SetIn(newInput("n\n"))
+		cmd := NewRemoveCommand(cli)
+		cmd.SetErr(io.Discard)
+		cmd.SetArgs([]string{"image1"})
+		assert.Error(t, cmd.Execute(), "removal of image image1 has been cancelled")
+		assert.Check(t, !forced)
+	})
+
+	t.Run("yes", func(t *testing.T) {
+		var forced bool
+		cli := test.NewFakeCli(newClient(&forced, "exited"))
+		cmd := NewRemoveCommand(cli)
+		cmd.SetArgs([]string{"--yes", "image1"})
+		assert.NilError(t, cmd.Execute())
+		assert.Check(t, forced)
+		assert.Check(t, !strings.Contains(cli.OutBuffer().String(), "WARNING!"))
+	})
+
+	t.Run("not a terminal", func(t *testing.T) {
+		var forced bool
+		cli := test.NewFakeCli(newClient(&forced, "exited"))
+		cmd := NewRemoveCommand(cli)
+		cmd.SetErr(io.Discard)
+		cmd.SetArgs([]string{"image1"})
+		assert.ErrorContains(t, cmd.Execute(), "conflict: unable to remove repository reference")
+		assert.Check(t, !forced)
+	})
+
+	t.Run("running container", func(t *testing.T) {
+		var forced bool
+		cli := test.NewFakeCli(newClient(&forced, "running"))
+		cli.SetIn(newInput("y\n"))
+		cmd := NewRemoveCommand(cli)
+		cmd.SetErr(io.Discard)
+		cmd.SetArgs([]string{"--yes", "image1"})
+		assert.ErrorContains(t, cmd.Execute(), "conflict: unable to remove repository reference")
+		assert.Check(t, !forced)
+	})
+}
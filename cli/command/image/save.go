@@ -2,20 +2,28 @@
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"sync/atomic"
+	"time"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/cli/internal/transfer"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 type saveOptions struct {
-	images []string
-	output string
+	images   []string
+	output   string
+	compress string
+	quiet    bool
 }
 
+const saveHeader = "Saving - "
+
 // NewSaveCommand creates a new `docker save` command
 func NewSaveCommand(dockerCli command.Cli) *cobra.Command {
 	var opts saveOptions
@@ -37,6 +45,10 @@ func NewSaveCommand(dockerCli command.Cli) *cobra.Command {
 	flags := cmd.Flags()
 
 	flags.StringVarP(&opts.output, "output", "o", "", "Write to a file, instead of STDOUT")
+	flags.StringVar(&opts.compress, "compress", "", `Compress the archive ("gzip" or "zstd", with an optional level, e.g. "zstd:19")`)
+	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Suppress progress output. Progress output is automatically suppressed if no terminal is attached")
+
+	_ = cmd.RegisterFlagCompletionFunc("compress", completion.FromList("gzip", "zstd"))
 
 	return cmd
 }
@@ -51,16 +63,58 @@ func RunSave(ctx context.Context, dockerCli command.Cli, opts saveOptions) error
 		return errors.Wrap(err, "failed to save image")
 	}
 
+	compress, err := transfer.ParseCompression(opts.compress)
+	if err != nil {
+		return err
+	}
+
 	responseBody, err := dockerCli.Client().ImageSave(ctx, opts.images)
 	if err != nil {
 		return err
 	}
 	defer responseBody.Close()
 
-	if opts.output == "" {
-		_, err := io.Copy(dockerCli.Out(), responseBody)
+	var size, written int64
+	content := io.Reader(&transfer.CountingReader{Reader: responseBody, N: &size})
+	if compress != nil {
+		compressed := compress(content)
+		defer compressed.Close()
+		content = &transfer.CountingReader{Reader: compressed, N: &written}
+	}
+
+	if opts.quiet {
+		return writeSave(dockerCli, opts.output, content)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	clearProgress, done := transfer.ShowProgress(ctx, dockerCli.Err(), saveHeader, func(elapsed time.Duration) string {
+		p := transfer.Rate(atomic.LoadInt64(&size), elapsed)
+		if compress != nil {
+			p += fmt.Sprintf(", %s compressed", transfer.HumanSize(atomic.LoadInt64(&written)))
+		}
+		return p
+	})
+	err = writeSave(dockerCli, opts.output, content)
+	cancel()
+	<-done
+	clearProgress()
+	if err != nil || opts.output == "" {
 		return err
 	}
+	if compress != nil {
+		_, _ = fmt.Fprintf(dockerCli.Err(), "Successfully saved %s (%s compressed) to %s\n", transfer.HumanSize(size), transfer.HumanSize(written), opts.output)
+	} else {
+		_, _ = fmt.Fprintf(dockerCli.Err(), "Successfully saved %s to %s\n", transfer.HumanSize(size), opts.output)
+	}
+	return nil
+}
 
-	return command.CopyToFile(opts.output, responseBody)
+func writeSave(dockerCli command.Cli, output string, content io.Reader) error {
+	if output == "" {
+		_, err := io.Copy(dockerCli.Out(), content)
+		return err
+	}
+	return command.CopyToFile(output, content)
 }
@@ -1,12 +1,15 @@
 package image
 
 import (
+	"compress/gzip"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/docker/cli/internal/test"
+	"github.com/klauspost/compress/zstd"
 	"github.com/pkg/errors"
 	"gotest.tools/v3/assert"
 	is "gotest.tools/v3/assert/cmp"
@@ -45,6 +48,16 @@ func TestNewSaveCommandErrors(t *testing.T) {
 			args:          []string{"-o", "fakedir/out.tar", "arg1"},
 			expectedError: "failed to save image: invalid output path: directory \"fakedir\" does not exist",
 		},
+		{
+			name:          "invalid compression",
+			args:          []string{"--compress", "xz", "arg1"},
+			expectedError: "invalid compression: xz (must be gzip or zstd)",
+		},
+		{
+			name:          "invalid compression level",
+			args:          []string{"--compress", "gzip:10", "arg1"},
+			expectedError: "invalid compression level for gzip: 10 (must be between 1 and 9)",
+		},
 		{
 			name:          "output file is irregular",
 			args:          []string{"-o", "/dev/null", "arg1"},
@@ -113,3 +126,46 @@ func TestNewSaveCommandSuccess(t *testing.T) {
 		})
 	}
 }
+
+func TestSaveCompress(t *testing.T) {
+	const content = "image archive content"
+	for _, tc := range []struct {
+		compress   string
+		decompress func(io.Reader) (io.Reader, error)
+	}{
+		{
+			compress: "gzip",
+			decompress: func(r io.Reader) (io.Reader, error) {
+				return gzip.NewReader(r)
+			},
+		},
+		{
+			compress: "zstd:19",
+			decompress: func(r io.Reader) (io.Reader, error) {
+				return zstd.NewReader(r)
+			},
+		},
+	} {
+		t.Run(tc.compress, func(t *testing.T) {
+			cli := test.NewFakeCli(&fakeClient{
+				imageSaveFunc: func(images []string) (io.ReadCloser, error) {
+					return io.NopCloser(strings.NewReader(content)), nil
+				},
+			})
+			output := filepath.Join(t.TempDir(), "image.tar")
+			cmd := NewSaveCommand(cli)
+			cmd.SetArgs([]string{"--compress", tc.compress, "-o", output, "arg1"})
+			assert.NilError(t, cmd.Execute())
+			assert.Check(t, is.Contains(cli.ErrBuffer().String(), "Successfully saved 21B"))
+
+			f, err := os.Open(output)
+			assert.NilError(t, err)
+			defer f.Close()
+			r, err := tc.decompress(f)
+			assert.NilError(t, err)
+			actual, err := io.ReadAll(r)
+			assert.NilError(t, err)
+			assert.Check(t, is.Equal(string(actual), content))
+		})
+	}
+}
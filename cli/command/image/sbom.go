@@ -0,0 +1,307 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/containerd/platforms"
+	"github.com/distribution/reference"
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/cli/command/formatter"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultSBOMTableFormat = "table {{.Name}}\t{{.Version}}\t{{.Type}}"
+
+	sbomNameHeader    = "NAME"
+	sbomVersionHeader = "VERSION"
+	sbomTypeHeader    = "TYPE"
+	sbomPURLHeader    = "PURL"
+
+	// Annotations of the attestation manifests of an image index, and of
+	// their layers.
+	attestationReferenceType   = "vnd.docker.reference.type"
+	attestationReferenceDigest = "vnd.docker.reference.digest"
+	attestationManifestType    = "attestation-manifest"
+	inTotoPredicateType        = "in-toto.io/predicate-type"
+	spdxPredicateTypePrefix    = "https://spdx.dev/Document"
+)
+
+const sbomFormatHelp = `Format output using a custom template:
+'table':            Print the packages in table format with column headers (default)
+'table TEMPLATE':   Print the packages in table format using the given Go template
+'json':             Print the SPDX documents in JSON format
+'TEMPLATE':         Print the packages using the given Go template.
+Refer to https://docs.docker.com/go/formatting/ for more information about formatting output with templates`
+
+type sbomOptions struct {
+	image    string
+	platform string
+	local    bool
+	format   string
+}
+
+// spdxDocument is an SPDX document. Only the fields that are shown by
+// "docker image sbom", or that are required in a generated document, are
+// decoded.
+type spdxDocument struct {
+	SPDXVersion       string           `json:"spdxVersion"`
+	DataLicense       string           `json:"dataLicense"`
+	SPDXID            string           `json:"SPDXID"`
+	Name              string           `json:"name"`
+	DocumentNamespace string           `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo `json:"creationInfo"`
+	Packages          []spdxPackage    `json:"packages"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name             string            `json:"name"`
+	SPDXID           string            `json:"SPDXID"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+// purl returns the package URL of the package, if any.
+func (p spdxPackage) purl() string {
+	for _, r := range p.ExternalRefs {
+		if r.ReferenceType == "purl" {
+			return r.ReferenceLocator
+		}
+	}
+	return ""
+}
+
+// NewSBOMCommand creates a new `docker image sbom` command
+func NewSBOMCommand(dockerCli command.Cli) *cobra.Command {
+	var opts sbomOptions
+
+	cmd := &cobra.Command{
+		Use:   "sbom [OPTIONS] IMAGE",
+		Short: "Show the software bill of materials (SBOM) of an image",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.image = args[0]
+			return runSBOM(cmd.Context(), dockerCli, opts)
+		},
+		ValidArgsFunction: completion.ImageNames(dockerCli),
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.platform, "platform", "", "Show the SBOM of the image for this platform (os/arch[/variant])")
+	flags.BoolVar(&opts.local, "local", false, "Generate the SBOM from the contents of the local image, without looking for an SBOM attestation in the registry")
+	flags.StringVar(&opts.format, "format", "", sbomFormatHelp)
+
+	return cmd
+}
+
+func runSBOM(ctx context.Context, dockerCli command.Cli, opts sbomOptions) error {
+	var p *ocispec.Platform
+	if opts.platform != "" {
+		parsed, err := platforms.Parse(opts.platform)
+		if err != nil {
+			return err
+		}
+		p = &parsed
+	}
+
+	var docs []json.RawMessage
+	if !opts.local {
+		var err error
+		docs, err = registrySBOM(ctx, dockerCli, opts.image, p)
+		if err != nil {
+			// Only generate an SBOM if the local image is the image that was
+			// asked for.
+			img, _, inspectErr := dockerCli.Client().ImageInspectWithRaw(ctx, opts.image)
+			if inspectErr != nil {
+				return err
+			}
+			if p != nil && !platforms.Only(*p).Match(ocispec.Platform{OS: img.Os, Architecture: img.Architecture, Variant: img.Variant}) {
+				return err
+			}
+			_, _ = fmt.Fprintf(dockerCli.Err(), "%v: generating an SBOM from the contents of the local image\n", err)
+		}
+	}
+	if docs == nil {
+		doc, err := generateSBOM(ctx, dockerCli, opts.image)
+		if err != nil {
+			return err
+		}
+		docs = []json.RawMessage{doc}
+	}
+
+	if opts.format == formatter.JSONFormatKey {
+		for _, doc := range docs {
+			if _, err := fmt.Fprintln(dockerCli.Out(), string(doc)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var packages []spdxPackage
+	for _, doc := range docs {
+		var d spdxDocument
+		if err := json.Unmarshal(doc, &d); err != nil {
+			return errors.Wrap(err, "invalid SPDX document")
+		}
+		packages = append(packages, d.Packages...)
+	}
+	sort.SliceStable(packages, func(i, j int) bool {
+		if packages[i].Name != packages[j].Name {
+			return packages[i].Name < packages[j].Name
+		}
+		return packages[i].VersionInfo < packages[j].VersionInfo
+	})
+
+	format := opts.format
+	if format == "" || format == formatter.TableFormatKey {
+		format = defaultSBOMTableFormat
+	}
+	sbomCtx := formatter.Context{
+		Output: dockerCli.Out(),
+		Format: formatter.Format(format),
+	}
+	return sbomWrite(sbomCtx, packages)
+}
+
+// registrySBOM returns the SPDX documents of the SBOM attestations attached
+// to the image in the registry, for the given platform, or for the platform
+// of the daemon if nil.
+func registrySBOM(ctx context.Context, dockerCli command.Cli, ref string, platform *ocispec.Platform) ([]json.RawMessage, error) {
+	namedRef, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return nil, err
+	}
+	namedRef = reference.TagNameOnly(namedRef)
+
+	p := platforms.DefaultSpec()
+	if platform != nil {
+		p = *platform
+	} else if osType := dockerCli.ServerInfo().OSType; osType != "" {
+		p.OS = osType
+	}
+
+	// Attestations are only attached to the images of an image index.
+	registryClient := dockerCli.RegistryClient(false)
+	manifests, err := registryClient.GetManifestList(ctx, namedRef)
+	if err != nil {
+		return nil, errors.Wrapf(err, "no SBOM attestation found for %s", reference.FamiliarString(namedRef))
+	}
+
+	matcher := platforms.Only(p)
+	var imageDigest digest.Digest
+	for _, m := range manifests {
+		if m.Descriptor.Platform == nil || m.Descriptor.Annotations[attestationReferenceType] == attestationManifestType {
+			continue
+		}
+		if matcher.Match(*m.Descriptor.Platform) {
+			imageDigest = m.Descriptor.Digest
+			break
+		}
+	}
+	if imageDigest == "" {
+		return nil, errors.Errorf("image %s is not available for platform %s", reference.FamiliarString(namedRef), platforms.Format(p))
+	}
+
+	var docs []json.RawMessage
+	for _, m := range manifests {
+		if m.Descriptor.Annotations[attestationReferenceType] != attestationManifestType ||
+			m.Descriptor.Annotations[attestationReferenceDigest] != imageDigest.String() ||
+			m.OCIManifest == nil {
+			continue
+		}
+		for _, layer := range m.OCIManifest.Layers {
+			if !strings.HasPrefix(layer.Annotations[inTotoPredicateType], spdxPredicateTypePrefix) {
+				continue
+			}
+			blob, err := registryClient.GetBlob(ctx, namedRef, layer.Digest)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to fetch the SBOM attestation %s", layer.Digest)
+			}
+			var statement struct {
+				Predicate json.RawMessage `json:"predicate"`
+			}
+			if err := json.Unmarshal(blob, &statement); err != nil || len(statement.Predicate) == 0 {
+				return nil, errors.Errorf("invalid in-toto statement %s", layer.Digest)
+			}
+			docs = append(docs, statement.Predicate)
+		}
+	}
+	if len(docs) == 0 {
+		return nil, errors.Errorf("no SBOM attestation found for %s (%s)", reference.FamiliarString(namedRef), platforms.Format(p))
+	}
+	return docs, nil
+}
+
+func sbomWrite(ctx formatter.Context, packages []spdxPackage) error {
+	render := func(format func(subContext formatter.SubContext) error) error {
+		for _, p := range packages {
+			if err := format(&sbomContext{p: p}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	sbomCtx := &sbomContext{}
+	sbomCtx.Header = formatter.SubHeaderContext{
+		"Name":    sbomNameHeader,
+		"Version": sbomVersionHeader,
+		"Type":    sbomTypeHeader,
+		"PURL":    sbomPURLHeader,
+	}
+	return ctx.Write(sbomCtx, render)
+}
+
+// sbomContext is the context of a package of an SBOM.
+type sbomContext struct {
+	formatter.HeaderContext
+	p spdxPackage
+}
+
+func (c *sbomContext) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(c)
+}
+
+func (c *sbomContext) Name() string {
+	return c.p.Name
+}
+
+func (c *sbomContext) Version() string {
+	return c.p.VersionInfo
+}
+
+// Type returns the type of the package URL of the package, such as "apk" or
+// "deb".
+func (c *sbomContext) Type() string {
+	purl := strings.TrimPrefix(c.p.purl(), "pkg:")
+	if purl == c.p.purl() {
+		return ""
+	}
+	t, _, _ := strings.Cut(purl, "/")
+	return t
+}
+
+func (c *sbomContext) PURL() string {
+	return c.p.purl()
+}
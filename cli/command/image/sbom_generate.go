@@ -0,0 +1,287 @@
+package image
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/version"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/pkg/errors"
+)
+
+// Package databases read to generate an SBOM.
+const (
+	apkInstalledFile = "lib/apk/db/installed"
+	dpkgStatusFile   = "var/lib/dpkg/status"
+	dpkgStatusDir    = "var/lib/dpkg/status.d/"
+)
+
+// generateSBOM generates a basic SPDX document for a local image, from the
+// package databases found in the layers of the image. Only the packages
+// installed with apk (Alpine) and dpkg (Debian, Ubuntu) are listed; the
+// files that aren't managed by a package manager are not.
+func generateSBOM(ctx context.Context, dockerCli command.Cli, ref string) (json.RawMessage, error) {
+	img, _, err := dockerCli.Client().ImageInspectWithRaw(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "docker-sbom-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	responseBody, err := dockerCli.Client().ImageSave(ctx, []string{ref})
+	if err != nil {
+		return nil, err
+	}
+	err = extractTar(responseBody, dir)
+	responseBody.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var manifests []archiveManifest
+	if err := readLayoutJSON(filepath.Join(dir, "manifest.json"), &manifests); err != nil {
+		return nil, errors.Wrap(err, "failed to read the image archive")
+	}
+	if len(manifests) != 1 {
+		return nil, errors.Errorf("expected a single image in the image archive, found %d", len(manifests))
+	}
+
+	files, err := readPackageDatabases(dir, manifests[0].Layers)
+	if err != nil {
+		return nil, err
+	}
+	packages := installedPackages(files)
+	if len(packages) == 0 {
+		_, _ = fmt.Fprintln(dockerCli.Err(), "No package database found in the image: only the packages installed with apk or dpkg are listed")
+	}
+
+	name := ref
+	if len(img.RepoTags) > 0 {
+		name = img.RepoTags[0]
+	}
+	return json.Marshal(spdxDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              name,
+		DocumentNamespace: "urn:docker:sbom:" + img.ID,
+		CreationInfo: spdxCreationInfo{
+			Created:  time.Now().UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: docker-" + version.Version},
+		},
+		Packages: packages,
+	})
+}
+
+// readPackageDatabases returns the content of the package databases, and of
+// the os-release file, of the filesystem of the layers of an image archive
+// extracted to dir. Later layers override earlier ones, and whiteouts remove
+// the files of earlier layers.
+func readPackageDatabases(dir string, layers []string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	for _, layer := range layers {
+		p, err := archivePath(dir, layer)
+		if err != nil {
+			return nil, err
+		}
+		if err := readLayerPackageDatabases(p, files); err != nil {
+			return nil, errors.Wrapf(err, "failed to read layer %s", layer)
+		}
+	}
+	return files, nil
+}
+
+func readLayerPackageDatabases(layer string, files map[string][]byte) error {
+	f, err := os.Open(layer)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r, err := archive.DecompressStream(f)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := strings.TrimPrefix(path.Clean("/"+hdr.Name), "/")
+		base := path.Base(name)
+		switch {
+		case base == archive.WhiteoutOpaqueDir:
+			prefix := path.Dir(name) + "/"
+			for p := range files {
+				if strings.HasPrefix(p, prefix) {
+					delete(files, p)
+				}
+			}
+		case strings.HasPrefix(base, archive.WhiteoutPrefix):
+			removed := path.Join(path.Dir(name), strings.TrimPrefix(base, archive.WhiteoutPrefix))
+			for p := range files {
+				if p == removed || strings.HasPrefix(p, removed+"/") {
+					delete(files, p)
+				}
+			}
+		case hdr.Typeflag == tar.TypeReg && isPackageDatabase(name):
+			content, err := io.ReadAll(tr)
+			if err != nil {
+				return err
+			}
+			files[name] = content
+		}
+	}
+}
+
+func isPackageDatabase(name string) bool {
+	switch name {
+	case apkInstalledFile, dpkgStatusFile, "etc/os-release", "usr/lib/os-release":
+		return true
+	}
+	return strings.HasPrefix(name, dpkgStatusDir) && !strings.HasSuffix(name, ".md5sums")
+}
+
+// installedPackages returns the packages of the package databases, sorted by
+// name.
+func installedPackages(files map[string][]byte) []spdxPackage {
+	osRelease := files["etc/os-release"]
+	if osRelease == nil {
+		osRelease = files["usr/lib/os-release"]
+	}
+	distro, distroVersion := parseOSRelease(osRelease)
+
+	var packages []spdxPackage
+	add := func(pkgType, defaultDistro, name, ver, arch string) {
+		if name == "" {
+			return
+		}
+		ns := distro
+		if ns == "" {
+			ns = defaultDistro
+		}
+		q := url.Values{}
+		if arch != "" {
+			q.Set("arch", arch)
+		}
+		if distro != "" && distroVersion != "" {
+			q.Set("distro", distro+"-"+distroVersion)
+		}
+		purl := "pkg:" + pkgType + "/" + ns + "/" + url.PathEscape(name)
+		if ver != "" {
+			purl += "@" + url.PathEscape(ver)
+		}
+		if len(q) > 0 {
+			purl += "?" + q.Encode()
+		}
+		packages = append(packages, spdxPackage{
+			Name:             name,
+			VersionInfo:      ver,
+			DownloadLocation: "NOASSERTION",
+			ExternalRefs: []spdxExternalRef{{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  purl,
+			}},
+		})
+	}
+
+	for _, fields := range parseStanzas(files[apkInstalledFile]) {
+		add("apk", "alpine", fields["P"], fields["V"], fields["A"])
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		if name == dpkgStatusFile || strings.HasPrefix(name, dpkgStatusDir) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, fields := range parseStanzas(files[name]) {
+			// Packages that were removed, but not purged, are still listed in
+			// the status file.
+			if status, ok := fields["Status"]; ok && !strings.HasSuffix(status, " installed") {
+				continue
+			}
+			add("deb", "debian", fields["Package"], fields["Version"], fields["Architecture"])
+		}
+	}
+
+	sort.SliceStable(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+	for i := range packages {
+		packages[i].SPDXID = fmt.Sprintf("SPDXRef-Package-%d", i+1)
+	}
+	return packages
+}
+
+// parseStanzas parses the "key:value" lines of a package database, in
+// stanzas separated by empty lines. Continuation lines, which start with a
+// space, are ignored.
+func parseStanzas(content []byte) []map[string]string {
+	var stanzas []map[string]string
+	fields := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			if len(fields) > 0 {
+				stanzas = append(stanzas, fields)
+				fields = map[string]string{}
+			}
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+		if k, v, ok := strings.Cut(line, ":"); ok {
+			fields[k] = strings.TrimSpace(v)
+		}
+	}
+	if len(fields) > 0 {
+		stanzas = append(stanzas, fields)
+	}
+	return stanzas
+}
+
+// parseOSRelease returns the ID and VERSION_ID of an os-release file.
+func parseOSRelease(content []byte) (id, versionID string) {
+	for _, line := range strings.Split(string(content), "\n") {
+		k, v, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok {
+			continue
+		}
+		v = strings.Trim(v, `"'`)
+		switch k {
+		case "ID":
+			id = v
+		case "VERSION_ID":
+			versionID = v
+		}
+	}
+	return id, versionID
+}
@@ -0,0 +1,231 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/distribution/reference"
+	manifesttypes "github.com/docker/cli/cli/manifest/types"
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/ocischema"
+	"github.com/docker/docker/api/types/image"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/golden"
+)
+
+const testSPDXDocument = `{"spdxVersion":"SPDX-2.3","SPDXID":"SPDXRef-DOCUMENT","packages":[` +
+	`{"name":"musl","SPDXID":"SPDXRef-Package-2","versionInfo":"1.2.4-r2","externalRefs":[{"referenceCategory":"PACKAGE-MANAGER","referenceType":"purl","referenceLocator":"pkg:apk/alpine/musl@1.2.4-r2?arch=x86_64"}]},` +
+	`{"name":"busybox","SPDXID":"SPDXRef-Package-1","versionInfo":"1.36.1-r5","externalRefs":[{"referenceCategory":"PACKAGE-MANAGER","referenceType":"purl","referenceLocator":"pkg:apk/alpine/busybox@1.36.1-r5?arch=x86_64"}]},` +
+	`{"name":"github.com/docker/cli","SPDXID":"SPDXRef-Package-3","versionInfo":"v27.0.0"}]}`
+
+// sbomRegistryClient returns a registry client with a multi-platform image,
+// and an SBOM attestation attached to its linux/amd64 image.
+func sbomRegistryClient(t *testing.T) *fakeRegistryClient {
+	t.Helper()
+	statement := []byte(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"https://spdx.dev/Document","predicate":` + testSPDXDocument + `}`)
+	attestation, err := ocischema.FromStruct(ocischema.Manifest{
+		Layers: []distribution.Descriptor{
+			{MediaType: "application/vnd.in-toto+json", Digest: "sha256:bbbb", Annotations: map[string]string{inTotoPredicateType: "https://slsa.dev/provenance/v0.2"}},
+			{MediaType: "application/vnd.in-toto+json", Digest: digest.FromBytes(statement), Annotations: map[string]string{inTotoPredicateType: "https://spdx.dev/Document"}},
+		},
+	})
+	assert.NilError(t, err)
+
+	return &fakeRegistryClient{
+		getManifestListFunc: func(_ context.Context, ref reference.Named) ([]manifesttypes.ImageManifest, error) {
+			assert.Check(t, is.Equal(ref.String(), "docker.io/library/alpine:latest"))
+			return []manifesttypes.ImageManifest{
+				{Descriptor: ocispec.Descriptor{Digest: "sha256:aaaa", Platform: &ocispec.Platform{OS: "linux", Architecture: "amd64"}}},
+				{Descriptor: ocispec.Descriptor{Digest: "sha256:cccc", Platform: &ocispec.Platform{OS: "linux", Architecture: "arm64"}}},
+				{
+					Descriptor: ocispec.Descriptor{
+						Digest:   "sha256:dddd",
+						Platform: &ocispec.Platform{OS: "unknown", Architecture: "unknown"},
+						Annotations: map[string]string{
+							attestationReferenceType:   attestationManifestType,
+							attestationReferenceDigest: "sha256:aaaa",
+						},
+					},
+					OCIManifest: attestation,
+				},
+			}, nil
+		},
+		getBlobFunc: func(_ context.Context, _ reference.Named, dgst digest.Digest) ([]byte, error) {
+			assert.Check(t, is.Equal(dgst, digest.FromBytes(statement)))
+			return statement, nil
+		},
+	}
+}
+
+func TestNewSBOMCommandRegistry(t *testing.T) {
+	testCases := []struct {
+		name string
+		args []string
+	}{
+		{
+			name: "default",
+			args: []string{"--platform", "linux/amd64", "alpine"},
+		},
+		{
+			name: "format",
+			args: []string{"--platform", "linux/amd64", "--format", "{{.Name}}: {{.PURL}}", "alpine"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cli := test.NewFakeCli(&fakeClient{})
+			cli.SetRegistryClient(sbomRegistryClient(t))
+			cmd := NewSBOMCommand(cli)
+			cmd.SetOut(io.Discard)
+			cmd.SetArgs(tc.args)
+			assert.NilError(t, cmd.Execute())
+			golden.Assert(t, cli.OutBuffer().String(), "sbom-command-registry."+tc.name+".golden")
+		})
+	}
+}
+
+func TestNewSBOMCommandRegistryJSON(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{})
+	cli.SetRegistryClient(sbomRegistryClient(t))
+	cmd := NewSBOMCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{"--platform", "linux/amd64", "--format", "json", "alpine"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), testSPDXDocument+"\n"))
+}
+
+// sbomImageSave returns the archive of an Alpine image, with a second layer
+// installing a package.
+func sbomImageSave(t *testing.T) func([]string) (io.ReadCloser, error) {
+	t.Helper()
+	layerTar := func(files map[string]string) string {
+		b, err := io.ReadAll(tarFiles(t, files))
+		assert.NilError(t, err)
+		return string(b)
+	}
+	return func(images []string) (io.ReadCloser, error) {
+		assert.Check(t, is.DeepEqual(images, []string{"alpine"}))
+		return tarFiles(t, map[string]string{
+			"manifest.json": `[{"Config":"config.json","RepoTags":["alpine:latest"],"Layers":["layer1/layer.tar","layer2/layer.tar"]}]`,
+			"config.json":   `{}`,
+			"layer1/layer.tar": layerTar(map[string]string{
+				"etc/os-release":       "NAME=\"Alpine Linux\"\nID=alpine\nVERSION_ID=3.19.1\n",
+				"lib/apk/db/installed": "C:Q1\nP:musl\nV:1.2.4-r2\nA:x86_64\n\nP:busybox\nV:1.36.1-r15\nA:x86_64\n",
+				"lib/apk/db/lock":      "",
+			}),
+			"layer2/layer.tar": layerTar(map[string]string{
+				"lib/apk/db/installed": "C:Q1\nP:musl\nV:1.2.4-r2\nA:x86_64\n\nP:busybox\nV:1.36.1-r15\nA:x86_64\n\nP:curl\nV:8.5.0-r0\nA:x86_64\n",
+			}),
+		}), nil
+	}
+}
+
+func TestNewSBOMCommandLocal(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		imageInspectFunc: func(string) (image.InspectResponse, []byte, error) {
+			return image.InspectResponse{ID: "sha256:5e0da2bb4a93", RepoTags: []string{"alpine:latest"}}, nil, nil
+		},
+		imageSaveFunc: sbomImageSave(t),
+	})
+	cmd := NewSBOMCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{"--local", "--format", "table {{.Name}}\t{{.Version}}\t{{.Type}}\t{{.PURL}}", "alpine"})
+	assert.NilError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "sbom-command-local.golden")
+}
+
+func TestNewSBOMCommandLocalJSON(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		imageInspectFunc: func(string) (image.InspectResponse, []byte, error) {
+			return image.InspectResponse{ID: "sha256:5e0da2bb4a93", RepoTags: []string{"alpine:latest"}}, nil, nil
+		},
+		imageSaveFunc: sbomImageSave(t),
+	})
+	cmd := NewSBOMCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{"--local", "--format", "json", "alpine"})
+	assert.NilError(t, cmd.Execute())
+
+	var doc spdxDocument
+	assert.NilError(t, json.Unmarshal(cli.OutBuffer().Bytes(), &doc))
+	assert.Check(t, is.Equal(doc.SPDXVersion, "SPDX-2.3"))
+	assert.Check(t, is.Equal(doc.Name, "alpine:latest"))
+	assert.Check(t, is.Equal(doc.DocumentNamespace, "urn:docker:sbom:sha256:5e0da2bb4a93"))
+	assert.Check(t, is.Len(doc.Packages, 3))
+}
+
+func TestNewSBOMCommandFallback(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		imageInspectFunc: func(string) (image.InspectResponse, []byte, error) {
+			return image.InspectResponse{ID: "sha256:5e0da2bb4a93", Os: "linux", Architecture: "amd64"}, nil, nil
+		},
+		imageSaveFunc: sbomImageSave(t),
+	})
+	cli.SetRegistryClient(&fakeRegistryClient{
+		getManifestListFunc: func(context.Context, reference.Named) ([]manifesttypes.ImageManifest, error) {
+			return nil, errors.New("no such manifest: docker.io/library/alpine:latest")
+		},
+	})
+	cmd := NewSBOMCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{"--platform", "linux/amd64", "alpine"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(cli.ErrBuffer().String(), "no SBOM attestation found for alpine:latest: no such manifest: docker.io/library/alpine:latest: generating an SBOM from the contents of the local image\n"))
+	assert.Check(t, is.Contains(cli.OutBuffer().String(), "curl      8.5.0-r0     apk"))
+}
+
+func TestNewSBOMCommandErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		args          []string
+		inspectErr    error
+		expectedError string
+	}{
+		{
+			name:          "invalid-platform",
+			args:          []string{"--platform", "linux/amd64/v1/x", "alpine"},
+			expectedError: "linux/amd64/v1/x",
+		},
+		{
+			name:          "platform-not-available",
+			args:          []string{"--platform", "windows/amd64", "alpine"},
+			inspectErr:    errors.New("no such image"),
+			expectedError: "image alpine:latest is not available for platform windows/amd64",
+		},
+		{
+			name:          "no-attestation",
+			args:          []string{"--platform", "linux/arm64", "alpine"},
+			inspectErr:    errors.New("no such image"),
+			expectedError: "no SBOM attestation found for alpine:latest (linux/arm64)",
+		},
+		{
+			name:          "local-platform-mismatch",
+			args:          []string{"--platform", "linux/arm64", "alpine"},
+			expectedError: "no SBOM attestation found for alpine:latest (linux/arm64)",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cli := test.NewFakeCli(&fakeClient{
+				imageInspectFunc: func(string) (image.InspectResponse, []byte, error) {
+					return image.InspectResponse{ID: "sha256:5e0da2bb4a93", Os: "linux", Architecture: "amd64"}, nil, tc.inspectErr
+				},
+			})
+			cli.SetRegistryClient(sbomRegistryClient(t))
+			cmd := NewSBOMCommand(cli)
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(tc.args)
+			assert.ErrorContains(t, cmd.Execute(), tc.expectedError)
+		})
+	}
+}
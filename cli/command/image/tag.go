@@ -2,16 +2,23 @@
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 
+	"github.com/distribution/reference"
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/docker/api/types/image"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 type tagOptions struct {
-	image string
-	name  string
+	image   string
+	name    string
+	pattern bool
 }
 
 // NewTagCommand creates a new `docker tag` command
@@ -19,12 +26,15 @@ func NewTagCommand(dockerCli command.Cli) *cobra.Command {
 	var opts tagOptions
 
 	cmd := &cobra.Command{
-		Use:   "tag SOURCE_IMAGE[:TAG] TARGET_IMAGE[:TAG]",
+		Use:   "tag [OPTIONS] SOURCE_IMAGE[:TAG] TARGET_IMAGE[:TAG]",
 		Short: "Create a tag TARGET_IMAGE that refers to SOURCE_IMAGE",
 		Args:  cli.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.image = args[0]
 			opts.name = args[1]
+			if opts.pattern {
+				return runTagPattern(cmd.Context(), dockerCli, opts)
+			}
 			return runTag(cmd.Context(), dockerCli, opts)
 		},
 		Annotations: map[string]string{
@@ -35,6 +45,7 @@ func NewTagCommand(dockerCli command.Cli) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.SetInterspersed(false)
+	flags.BoolVar(&opts.pattern, "pattern", false, `Tag all the images matching SOURCE_IMAGE, where "*" matches any part of the name, replacing "*" in TARGET_IMAGE`)
 
 	return cmd
 }
@@ -42,3 +53,64 @@ func NewTagCommand(dockerCli command.Cli) *cobra.Command {
 func runTag(ctx context.Context, dockerCli command.Cli, opts tagOptions) error {
 	return dockerCli.Client().ImageTag(ctx, opts.image, opts.name)
 }
+
+// runTagPattern tags all the images with a tag matching the source pattern.
+// The part of the tag matched by the "*" of the source pattern replaces the
+// "*" of the target pattern to create the new tag.
+func runTagPattern(ctx context.Context, dockerCli command.Cli, opts tagOptions) error {
+	srcPrefix, srcSuffix, ok := cutWildcard(opts.image)
+	if !ok {
+		return errors.Errorf("invalid pattern %q: must contain a single \"*\"", opts.image)
+	}
+	if _, _, ok := cutWildcard(opts.name); !ok {
+		return errors.Errorf("invalid pattern %q: must contain a single \"*\"", opts.name)
+	}
+
+	images, err := dockerCli.Client().ImageList(ctx, image.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	mapping := map[string]string{}
+	for _, img := range images {
+		for _, tag := range img.RepoTags {
+			if tag == "<none>:<none>" {
+				continue
+			}
+			if len(tag) < len(srcPrefix)+len(srcSuffix) || !strings.HasPrefix(tag, srcPrefix) || !strings.HasSuffix(tag, srcSuffix) {
+				continue
+			}
+			match := tag[len(srcPrefix) : len(tag)-len(srcSuffix)]
+			target := strings.Replace(opts.name, "*", match, 1)
+			if _, err := reference.ParseNormalizedNamed(target); err != nil {
+				return errors.Wrapf(err, "invalid target for %s", tag)
+			}
+			mapping[tag] = target
+		}
+	}
+	if len(mapping) == 0 {
+		return errors.Errorf("no image matches %s", opts.image)
+	}
+
+	sources := make([]string, 0, len(mapping))
+	for src := range mapping {
+		sources = append(sources, src)
+	}
+	sort.Strings(sources)
+	for _, src := range sources {
+		if err := dockerCli.Client().ImageTag(ctx, src, mapping[src]); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(dockerCli.Out(), "%s -> %s\n", src, mapping[src])
+	}
+	return nil
+}
+
+// cutWildcard returns the parts of a pattern before and after its "*". It
+// returns false if the pattern doesn't contain exactly one "*".
+func cutWildcard(pattern string) (before, after string, ok bool) {
+	if strings.Count(pattern, "*") != 1 {
+		return "", "", false
+	}
+	return strings.Cut(pattern, "*")
+}
@@ -1,12 +1,15 @@
 package image
 
 import (
+	"errors"
 	"io"
 	"testing"
 
 	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/image"
 	"gotest.tools/v3/assert"
 	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/golden"
 )
 
 func TestCliNewTagCommandErrors(t *testing.T) {
@@ -40,3 +43,76 @@ func TestCliNewTagCommand(t *testing.T) {
 	value, _ := cmd.Flags().GetBool("interspersed")
 	assert.Check(t, !value)
 }
+
+func TestCliNewTagCommandPattern(t *testing.T) {
+	var tagged []string
+	cli := test.NewFakeCli(&fakeClient{
+		imageListFunc: func(options image.ListOptions) ([]image.Summary, error) {
+			return []image.Summary{
+				{ID: "sha256:1", RepoTags: []string{"registry.old/app:1.0", "registry.old/team/web:latest"}},
+				{ID: "sha256:2", RepoTags: []string{"alpine:latest", "registry.old/app:2.0"}},
+				{ID: "sha256:3", RepoTags: []string{"<none>:<none>"}},
+			}, nil
+		},
+		imageTagFunc: func(image string, ref string) error {
+			tagged = append(tagged, image+" "+ref)
+			return nil
+		},
+	})
+	cmd := NewTagCommand(cli)
+	cmd.SetArgs([]string{"--pattern", "registry.old/*", "registry.new/*"})
+	cmd.SetOut(io.Discard)
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.DeepEqual(tagged, []string{
+		"registry.old/app:1.0 registry.new/app:1.0",
+		"registry.old/app:2.0 registry.new/app:2.0",
+		"registry.old/team/web:latest registry.new/team/web:latest",
+	}))
+	golden.Assert(t, cli.OutBuffer().String(), "tag-command-pattern.golden")
+}
+
+func TestCliNewTagCommandPatternErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		args          []string
+		expectedError string
+	}{
+		{
+			name:          "no-wildcard",
+			args:          []string{"--pattern", "registry.old/app", "registry.new/*"},
+			expectedError: `invalid pattern "registry.old/app": must contain a single "*"`,
+		},
+		{
+			name:          "many-wildcards",
+			args:          []string{"--pattern", "registry.old/*", "*/*"},
+			expectedError: `invalid pattern "*/*": must contain a single "*"`,
+		},
+		{
+			name:          "no-match",
+			args:          []string{"--pattern", "registry.other/*", "registry.new/*"},
+			expectedError: "no image matches registry.other/*",
+		},
+		{
+			name:          "invalid-target",
+			args:          []string{"--pattern", "registry.old/*", "registry.new/*:v2"},
+			expectedError: "invalid target for registry.old/app:1.0",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := NewTagCommand(test.NewFakeCli(&fakeClient{
+				imageListFunc: func(options image.ListOptions) ([]image.Summary, error) {
+					return []image.Summary{{ID: "sha256:1", RepoTags: []string{"registry.old/app:1.0"}}}, nil
+				},
+				imageTagFunc: func(string, string) error {
+					return errors.New("shouldn't tag images")
+				},
+			}))
+			cmd.SetArgs(tc.args)
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			assert.ErrorContains(t, cmd.Execute(), tc.expectedError)
+		})
+	}
+}
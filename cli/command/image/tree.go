@@ -18,8 +18,9 @@
 )
 
 type treeOptions struct {
-	all     bool
-	filters filters.Args
+	all           bool
+	filters       filters.Args
+	clientFilters clientFilters
 }
 
 type treeView struct {
@@ -38,6 +39,7 @@ func runTree(ctx context.Context, dockerCLI command.Cli, opts treeOptions) error
 	if err != nil {
 		return err
 	}
+	images = opts.clientFilters.filter(images)
 
 	view := treeView{
 		images: make([]topImage, 0, len(images)),
@@ -181,7 +181,9 @@ func trustedPull(ctx context.Context, cli command.Cli, imgRefAndAuth trust.Image
 		if displayTag != "" {
 			displayTag = ":" + displayTag
 		}
-		fmt.Fprintf(cli.Out(), "Pull (%d of %d): %s%s@%s\n", i+1, len(refs), reference.FamiliarName(ref), displayTag, r.digest)
+		if opts.progress != command.ProgressJSON {
+			fmt.Fprintf(cli.Out(), "Pull (%d of %d): %s%s@%s\n", i+1, len(refs), reference.FamiliarName(ref), displayTag, r.digest)
+		}
 
 		trustedRef, err := reference.WithDigest(reference.TrimNamed(ref), r.digest)
 		if err != nil {
@@ -192,10 +194,13 @@ func trustedPull(ctx context.Context, cli command.Cli, imgRefAndAuth trust.Image
 			return err
 		}
 		if err := imagePullPrivileged(ctx, cli, updatedImgRefAndAuth, PullOptions{
-			all:      false,
-			platform: opts.platform,
-			quiet:    opts.quiet,
-			remote:   opts.remote,
+			all:          false,
+			platform:     opts.platform,
+			quiet:        opts.quiet,
+			progress:     opts.progress,
+			remote:       opts.remote,
+			retries:      opts.retries,
+			retryBackoff: opts.retryBackoff,
 		}); err != nil {
 			return err
 		}
@@ -268,22 +273,27 @@ func imagePullPrivileged(ctx context.Context, cli command.Cli, imgRefAndAuth tru
 		return err
 	}
 	requestPrivilege := command.RegistryAuthenticationPrivilegedFunc(cli, imgRefAndAuth.RepoInfo().Index, "pull")
-	responseBody, err := cli.Client().ImagePull(ctx, reference.FamiliarString(imgRefAndAuth.Reference()), image.PullOptions{
-		RegistryAuth:  encodedAuth,
-		PrivilegeFunc: requestPrivilege,
-		All:           opts.all,
-		Platform:      opts.platform,
-	})
-	if err != nil {
-		return err
-	}
-	defer responseBody.Close()
+	return retryPull(ctx, cli, opts, func() error {
+		responseBody, err := cli.Client().ImagePull(ctx, reference.FamiliarString(imgRefAndAuth.Reference()), image.PullOptions{
+			RegistryAuth:  encodedAuth,
+			PrivilegeFunc: requestPrivilege,
+			All:           opts.all,
+			Platform:      opts.platform,
+		})
+		if err != nil {
+			return err
+		}
+		defer responseBody.Close()
 
-	out := cli.Out()
-	if opts.quiet {
-		out = streams.NewOut(io.Discard)
-	}
-	return jsonmessage.DisplayJSONMessagesToStream(responseBody, out, nil)
+		if opts.progress == command.ProgressJSON {
+			return writePullEvents(responseBody, cli.Out(), imgRefAndAuth.Reference().String(), opts.platform)
+		}
+		out := cli.Out()
+		if opts.quiet {
+			out = streams.NewOut(io.Discard)
+		}
+		return jsonmessage.DisplayJSONMessagesToStream(responseBody, out, nil)
+	})
 }
 
 // TrustedReference returns the canonical trusted reference for an image reference
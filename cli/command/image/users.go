@@ -0,0 +1,77 @@
+package image
+
+import (
+	"context"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/cli/command/formatter"
+	flagsHelper "github.com/docker/cli/cli/flags"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/spf13/cobra"
+)
+
+const defaultUsersTableFormat = "table {{.ID}}\t{{.Names}}\t{{.Image}}\t{{.State}}\t{{.Status}}"
+
+type usersOptions struct {
+	image   string
+	quiet   bool
+	noTrunc bool
+	format  string
+}
+
+// NewUsersCommand creates a new `docker image users` command
+func NewUsersCommand(dockerCli command.Cli) *cobra.Command {
+	var opts usersOptions
+
+	cmd := &cobra.Command{
+		Use:   "users [OPTIONS] IMAGE",
+		Short: "List the containers using an image, or an image built from it",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.image = args[0]
+			return runUsers(cmd.Context(), dockerCli, opts)
+		},
+		ValidArgsFunction: completion.ImageNames(dockerCli),
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only display container IDs")
+	flags.BoolVar(&opts.noTrunc, "no-trunc", false, "Don't truncate output")
+	flags.StringVar(&opts.format, "format", "", flagsHelper.FormatHelp)
+
+	return cmd
+}
+
+func runUsers(ctx context.Context, dockerCli command.Cli, opts usersOptions) error {
+	img, _, err := dockerCli.Client().ImageInspectWithRaw(ctx, opts.image)
+	if err != nil {
+		return err
+	}
+
+	// The ancestor filter matches the containers created from the image, and
+	// from the images that have the image as parent.
+	containers, err := dockerCli.Client().ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("ancestor", img.ID)),
+	})
+	if err != nil {
+		return err
+	}
+
+	format := opts.format
+	if format == "" || format == formatter.TableFormatKey {
+		format = defaultUsersTableFormat
+		if opts.quiet {
+			format = formatter.DefaultQuietFormat
+		}
+	}
+	containerCtx := formatter.Context{
+		Output: dockerCli.Out(),
+		Format: formatter.NewContainerFormat(format, opts.quiet, false),
+		Trunc:  !opts.noTrunc,
+	}
+	return formatter.ContainerWrite(containerCtx, containers)
+}
@@ -0,0 +1,70 @@
+package image
+
+import (
+	"io"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/pkg/errors"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/golden"
+)
+
+func TestNewUsersCommand(t *testing.T) {
+	testCases := []struct {
+		name string
+		args []string
+	}{
+		{
+			name: "default",
+			args: []string{"alpine"},
+		},
+		{
+			name: "quiet",
+			args: []string{"--quiet", "alpine"},
+		},
+		{
+			name: "format",
+			args: []string{"--format", "{{.Names}}: {{.Image}}", "alpine"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cli := test.NewFakeCli(&fakeClient{
+				imageInspectFunc: func(img string) (image.InspectResponse, []byte, error) {
+					return image.InspectResponse{ID: "sha256:5e0da2bb4a93"}, nil, nil
+				},
+				containerListFunc: func(options container.ListOptions) ([]container.Summary, error) {
+					assert.Check(t, options.All)
+					assert.Check(t, is.DeepEqual(options.Filters.Get("ancestor"), []string{"sha256:5e0da2bb4a93"}))
+					return []container.Summary{
+						{ID: "0123456789abcdef", Names: []string{"/web"}, Image: "alpine", State: "running", Status: "Up 2 hours"},
+						{ID: "ba9876543210fedc", Names: []string{"/worker"}, Image: "example.com/app:1.0", State: "exited", Status: "Exited (0) 2 days ago"},
+					}, nil
+				},
+			})
+			cmd := NewUsersCommand(cli)
+			cmd.SetOut(io.Discard)
+			cmd.SetArgs(tc.args)
+			assert.NilError(t, cmd.Execute())
+			golden.Assert(t, cli.OutBuffer().String(), "users-command."+tc.name+".golden")
+		})
+	}
+}
+
+func TestNewUsersCommandImageNotFound(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		imageInspectFunc: func(img string) (image.InspectResponse, []byte, error) {
+			return image.InspectResponse{}, nil, errors.New("No such image: alpine")
+		},
+	})
+	cmd := NewUsersCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"alpine"})
+	assert.Error(t, cmd.Execute(), "No such image: alpine")
+}
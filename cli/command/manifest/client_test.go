@@ -13,6 +13,7 @@
 type fakeRegistryClient struct {
 	getManifestFunc     func(ctx context.Context, ref reference.Named) (manifesttypes.ImageManifest, error)
 	getManifestListFunc func(ctx context.Context, ref reference.Named) ([]manifesttypes.ImageManifest, error)
+	getBlobFunc         func(ctx context.Context, ref reference.Named, dgst digest.Digest) ([]byte, error)
 	mountBlobFunc       func(ctx context.Context, source reference.Canonical, target reference.Named) error
 	putManifestFunc     func(ctx context.Context, source reference.Named, mf distribution.Manifest) (digest.Digest, error)
 }
@@ -31,6 +32,13 @@ func (c *fakeRegistryClient) GetManifestList(ctx context.Context, ref reference.
 	return nil, nil
 }
 
+func (c *fakeRegistryClient) GetBlob(ctx context.Context, ref reference.Named, dgst digest.Digest) ([]byte, error) {
+	if c.getBlobFunc != nil {
+		return c.getBlobFunc(ctx, ref, dgst)
+	}
+	return nil, nil
+}
+
 func (c *fakeRegistryClient) MountBlob(ctx context.Context, source reference.Canonical, target reference.Named) error {
 	if c.mountBlobFunc != nil {
 		return c.mountBlobFunc(ctx, source, target)
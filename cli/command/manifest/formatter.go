@@ -0,0 +1,78 @@
+package manifest
+
+import (
+	"github.com/containerd/platforms"
+	"github.com/docker/cli/cli/command/formatter"
+	"github.com/docker/cli/cli/manifest/types"
+	units "github.com/docker/go-units"
+)
+
+const (
+	defaultManifestTableFormat = "table {{.Platform}}\t{{.Digest}}\t{{.Size}}"
+
+	platformHeader  = "PLATFORM"
+	digestHeader    = "DIGEST"
+	mediaTypeHeader = "MEDIA TYPE"
+)
+
+// newFormat returns a Format for rendering using a manifest Context
+func newFormat(source string) formatter.Format {
+	if source == formatter.TableFormatKey {
+		return defaultManifestTableFormat
+	}
+	return formatter.Format(source)
+}
+
+// formatWrite writes the context
+func formatWrite(ctx formatter.Context, manifests []types.ImageManifest) error {
+	render := func(format func(subContext formatter.SubContext) error) error {
+		for _, m := range manifests {
+			if err := format(&manifestContext{m: m}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	manifestCtx := manifestContext{}
+	manifestCtx.Header = formatter.SubHeaderContext{
+		"Platform":  platformHeader,
+		"Digest":    digestHeader,
+		"Size":      formatter.SizeHeader,
+		"MediaType": mediaTypeHeader,
+	}
+	return ctx.Write(&manifestCtx, render)
+}
+
+type manifestContext struct {
+	formatter.HeaderContext
+	m types.ImageManifest
+}
+
+func (c *manifestContext) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(c)
+}
+
+func (c *manifestContext) Platform() string {
+	if c.m.Descriptor.Platform == nil {
+		return ""
+	}
+	return platforms.Format(*c.m.Descriptor.Platform)
+}
+
+func (c *manifestContext) Digest() string {
+	return c.m.Descriptor.Digest.String()
+}
+
+// Size returns the size of the image, which is the size of its config and
+// of its compressed layers.
+func (c *manifestContext) Size() string {
+	var size int64
+	for _, d := range c.m.References() {
+		size += d.Size
+	}
+	return units.HumanSizeWithPrecision(float64(size), 3)
+}
+
+func (c *manifestContext) MediaType() string {
+	return c.m.Descriptor.MediaType
+}
@@ -5,10 +5,14 @@
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/containerd/platforms"
 	"github.com/distribution/reference"
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/formatter"
+	flagsHelper "github.com/docker/cli/cli/flags"
 	"github.com/docker/cli/cli/manifest/types"
 	"github.com/docker/distribution/manifest/manifestlist"
 	"github.com/docker/docker/registry"
@@ -21,6 +25,8 @@ type inspectOptions struct {
 	list     string
 	verbose  bool
 	insecure bool
+	format   string
+	platform string
 }
 
 // NewInspectCommand creates a new `docker manifest inspect` command
@@ -39,6 +45,9 @@ func newInspectCommand(dockerCli command.Cli) *cobra.Command {
 				opts.list = args[0]
 				opts.ref = args[1]
 			}
+			if opts.verbose && opts.format != "" {
+				return errors.New("conflicting options: --format and --verbose cannot be used together")
+			}
 			return runInspect(cmd.Context(), dockerCli, opts)
 		},
 	}
@@ -46,6 +55,8 @@ func newInspectCommand(dockerCli command.Cli) *cobra.Command {
 	flags := cmd.Flags()
 	flags.BoolVar(&opts.insecure, "insecure", false, "Allow communication with an insecure registry")
 	flags.BoolVarP(&opts.verbose, "verbose", "v", false, "Output additional info including layers and platform")
+	flags.StringVar(&opts.format, "format", "", flagsHelper.FormatHelp)
+	flags.StringVar(&opts.platform, "platform", "", "Only show the manifests for this platform (os/arch[/variant])")
 	return cmd
 }
 
@@ -66,20 +77,20 @@ func runInspect(ctx context.Context, dockerCli command.Cli, opts inspectOptions)
 		if err != nil {
 			return err
 		}
-		return printManifest(dockerCli, imageManifest, opts)
+		return printInspect(dockerCli, namedRef, []types.ImageManifest{imageManifest}, false, opts)
 	}
 
 	// Try a local manifest list first
 	localManifestList, err := dockerCli.ManifestStore().GetList(namedRef)
 	if err == nil {
-		return printManifestList(dockerCli, namedRef, localManifestList, opts)
+		return printInspect(dockerCli, namedRef, localManifestList, true, opts)
 	}
 
 	// Next try a remote manifest
 	registryClient := dockerCli.RegistryClient(opts.insecure)
 	imageManifest, err := registryClient.GetManifest(ctx, namedRef)
 	if err == nil {
-		return printManifest(dockerCli, imageManifest, opts)
+		return printInspect(dockerCli, namedRef, []types.ImageManifest{imageManifest}, false, opts)
 	}
 
 	// Finally try a remote manifest list
@@ -87,7 +98,60 @@ func runInspect(ctx context.Context, dockerCli command.Cli, opts inspectOptions)
 	if err != nil {
 		return err
 	}
-	return printManifestList(dockerCli, namedRef, manifestList, opts)
+	return printInspect(dockerCli, namedRef, manifestList, true, opts)
+}
+
+// printInspect prints the manifests for the platform of the options, if any.
+// isList is true if the manifests are the manifests of a manifest list. When
+// filtering a manifest list leaves a single manifest, that manifest is printed
+// instead of the list.
+func printInspect(dockerCli command.Cli, namedRef reference.Named, manifests []types.ImageManifest, isList bool, opts inspectOptions) error {
+	if opts.platform != "" {
+		var err error
+		if manifests, err = filterPlatform(namedRef, manifests, opts.platform); err != nil {
+			return err
+		}
+		isList = isList && len(manifests) > 1
+	}
+
+	if opts.format != "" {
+		manifestCtx := formatter.Context{
+			Output: dockerCli.Out(),
+			Format: newFormat(opts.format),
+		}
+		return formatWrite(manifestCtx, manifests)
+	}
+	if !isList {
+		return printManifest(dockerCli, manifests[0], opts)
+	}
+	return printManifestList(dockerCli, namedRef, manifests, opts)
+}
+
+// filterPlatform returns the manifests matching the platform.
+func filterPlatform(namedRef reference.Named, manifests []types.ImageManifest, platform string) ([]types.ImageManifest, error) {
+	p, err := platforms.Parse(platform)
+	if err != nil {
+		return nil, err
+	}
+	matcher := platforms.NewMatcher(p)
+
+	var (
+		matching  []types.ImageManifest
+		available []string
+	)
+	for _, m := range manifests {
+		if m.Descriptor.Platform == nil {
+			continue
+		}
+		if matcher.Match(*m.Descriptor.Platform) {
+			matching = append(matching, m)
+		}
+		available = append(available, platforms.Format(*m.Descriptor.Platform))
+	}
+	if len(matching) == 0 {
+		return nil, errors.Errorf("no manifest found for platform %s in %s (available: %s)", platforms.Format(p), reference.FamiliarString(namedRef), strings.Join(available, ", "))
+	}
+	return matching, nil
 }
 
 func printManifest(dockerCli command.Cli, manifest types.ImageManifest, opts inspectOptions) error {
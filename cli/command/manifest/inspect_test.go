@@ -135,3 +135,102 @@ func TestInspectcommandRemoteManifest(t *testing.T) {
 	expected := golden.Get(t, "inspect-manifest.golden")
 	assert.Check(t, is.Equal(string(expected), actual.String()))
 }
+
+func multiPlatformManifests(t *testing.T, ref reference.Named) []types.ImageManifest {
+	t.Helper()
+	amd64 := fullImageManifest(t, ref)
+	arm64 := fullImageManifest(t, ref)
+	arm64.Descriptor.Digest = "sha256:3ee1a5a2a56e8a5b1fbbdc5ab0c6cd2ef6ae1a4d5e6a4a9e1c4c1de6f1a9b5a7"
+	arm64.Descriptor.Platform = &ocispec.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}
+	return []types.ImageManifest{amd64, arm64}
+}
+
+func TestInspectCommandFormat(t *testing.T) {
+	testCases := []struct {
+		name   string
+		args   []string
+		golden string
+	}{
+		{
+			name:   "table",
+			args:   []string{"--format", "table", "example.com/alpine:3.0"},
+			golden: "inspect-manifest-list-table.golden",
+		},
+		{
+			name:   "template",
+			args:   []string{"--format", "{{.Platform}} {{.MediaType}}", "example.com/alpine:3.0"},
+			golden: "inspect-manifest-list-template.golden",
+		},
+		{
+			name:   "platform",
+			args:   []string{"--platform", "linux/amd64", "example.com/alpine:3.0"},
+			golden: "inspect-manifest.golden",
+		},
+		{
+			name:   "platform-table",
+			args:   []string{"--platform", "linux/arm64", "--format", "table", "example.com/alpine:3.0"},
+			golden: "inspect-manifest-list-platform-table.golden",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cli := test.NewFakeCli(nil)
+			cli.SetManifestStore(store.NewStore(t.TempDir()))
+			cli.SetRegistryClient(&fakeRegistryClient{
+				getManifestFunc: func(_ context.Context, ref reference.Named) (types.ImageManifest, error) {
+					return types.ImageManifest{}, errors.New("not a manifest")
+				},
+				getManifestListFunc: func(_ context.Context, ref reference.Named) ([]types.ImageManifest, error) {
+					return multiPlatformManifests(t, ref), nil
+				},
+			})
+
+			cmd := newInspectCommand(cli)
+			cmd.SetOut(io.Discard)
+			cmd.SetArgs(tc.args)
+			assert.NilError(t, cmd.Execute())
+			golden.Assert(t, cli.OutBuffer().String(), tc.golden)
+		})
+	}
+}
+
+func TestInspectCommandFormatErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		args          []string
+		expectedError string
+	}{
+		{
+			name:          "format-and-verbose",
+			args:          []string{"--format", "table", "--verbose", "example.com/alpine:3.0"},
+			expectedError: "conflicting options: --format and --verbose cannot be used together",
+		},
+		{
+			name:          "platform-not-found",
+			args:          []string{"--platform", "windows/amd64", "example.com/alpine:3.0"},
+			expectedError: "no manifest found for platform windows/amd64 in example.com/alpine:3.0 (available: linux/amd64, linux/arm64/v8)",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cli := test.NewFakeCli(nil)
+			cli.SetManifestStore(store.NewStore(t.TempDir()))
+			cli.SetRegistryClient(&fakeRegistryClient{
+				getManifestFunc: func(_ context.Context, ref reference.Named) (types.ImageManifest, error) {
+					return types.ImageManifest{}, errors.New("not a manifest")
+				},
+				getManifestListFunc: func(_ context.Context, ref reference.Named) ([]types.ImageManifest, error) {
+					return multiPlatformManifests(t, ref), nil
+				},
+			})
+
+			cmd := newInspectCommand(cli)
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(tc.args)
+			assert.Error(t, cmd.Execute(), tc.expectedError)
+		})
+	}
+}
@@ -2,10 +2,16 @@
 
 import (
 	"context"
+	"io"
+	"strings"
 
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/system"
 	"github.com/docker/docker/client"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 type fakeClient struct {
@@ -17,6 +23,14 @@ type fakeClient struct {
 	networkListFunc       func(ctx context.Context, options network.ListOptions) ([]network.Summary, error)
 	networkPruneFunc      func(ctx context.Context, pruneFilters filters.Args) (network.PruneReport, error)
 	networkInspectFunc    func(ctx context.Context, networkID string, options network.InspectOptions) (network.Inspect, []byte, error)
+	containerListFunc     func(ctx context.Context, options container.ListOptions) ([]container.Summary, error)
+	infoFunc              func(ctx context.Context) (system.Info, error)
+	containerCreateFunc   func(ctx context.Context, config *container.Config, hostConfig *container.HostConfig) (container.CreateResponse, error)
+	containerStartFunc    func(ctx context.Context, containerID string) error
+	containerWaitFunc     func(ctx context.Context, containerID string) (<-chan container.WaitResponse, <-chan error)
+	containerLogsFunc     func(ctx context.Context, containerID string) (io.ReadCloser, error)
+	containerRemoveFunc   func(ctx context.Context, containerID string, options container.RemoveOptions) error
+	imagePullFunc         func(ctx context.Context, ref string) (io.ReadCloser, error)
 }
 
 func (c *fakeClient) NetworkCreate(ctx context.Context, name string, options network.CreateOptions) (network.CreateResponse, error) {
@@ -67,3 +81,61 @@ func (c *fakeClient) NetworksPrune(ctx context.Context, pruneFilter filters.Args
 	}
 	return network.PruneReport{}, nil
 }
+
+func (c *fakeClient) ContainerList(ctx context.Context, options container.ListOptions) ([]container.Summary, error) {
+	if c.containerListFunc != nil {
+		return c.containerListFunc(ctx, options)
+	}
+	return []container.Summary{}, nil
+}
+
+func (c *fakeClient) Info(ctx context.Context) (system.Info, error) {
+	if c.infoFunc != nil {
+		return c.infoFunc(ctx)
+	}
+	return system.Info{}, nil
+}
+
+func (c *fakeClient) ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, _ *network.NetworkingConfig, _ *ocispec.Platform, _ string) (container.CreateResponse, error) {
+	if c.containerCreateFunc != nil {
+		return c.containerCreateFunc(ctx, config, hostConfig)
+	}
+	return container.CreateResponse{}, nil
+}
+
+func (c *fakeClient) ContainerStart(ctx context.Context, containerID string, _ container.StartOptions) error {
+	if c.containerStartFunc != nil {
+		return c.containerStartFunc(ctx, containerID)
+	}
+	return nil
+}
+
+func (c *fakeClient) ContainerWait(ctx context.Context, containerID string, _ container.WaitCondition) (<-chan container.WaitResponse, <-chan error) {
+	if c.containerWaitFunc != nil {
+		return c.containerWaitFunc(ctx, containerID)
+	}
+	waitCh := make(chan container.WaitResponse, 1)
+	waitCh <- container.WaitResponse{}
+	return waitCh, make(chan error)
+}
+
+func (c *fakeClient) ContainerLogs(ctx context.Context, containerID string, _ container.LogsOptions) (io.ReadCloser, error) {
+	if c.containerLogsFunc != nil {
+		return c.containerLogsFunc(ctx, containerID)
+	}
+	return io.NopCloser(strings.NewReader("")), nil
+}
+
+func (c *fakeClient) ContainerRemove(ctx context.Context, containerID string, options container.RemoveOptions) error {
+	if c.containerRemoveFunc != nil {
+		return c.containerRemoveFunc(ctx, containerID, options)
+	}
+	return nil
+}
+
+func (c *fakeClient) ImagePull(ctx context.Context, ref string, _ image.PullOptions) (io.ReadCloser, error) {
+	if c.imagePullFunc != nil {
+		return c.imagePullFunc(ctx, ref)
+	}
+	return io.NopCloser(strings.NewReader("")), nil
+}
@@ -19,9 +19,11 @@ func NewNetworkCommand(dockerCli command.Cli) *cobra.Command {
 		newConnectCommand(dockerCli),
 		newCreateCommand(dockerCli),
 		newDisconnectCommand(dockerCli),
+		newDoctorCommand(dockerCli),
 		newInspectCommand(dockerCli),
 		newListCommand(dockerCli),
 		newRemoveCommand(dockerCli),
+		newTopologyCommand(dockerCli),
 		NewPruneCommand(dockerCli),
 	)
 	return cmd
@@ -3,12 +3,15 @@
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
 	"github.com/docker/cli/opts"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
 	"github.com/spf13/cobra"
@@ -23,21 +26,25 @@ type connectOptions struct {
 	aliases      []string
 	linklocalips []string
 	driverOpts   []string
+	filter       opts.FilterOpt
 }
 
 func newConnectCommand(dockerCLI command.Cli) *cobra.Command {
 	options := connectOptions{
-		links: opts.NewListOpts(opts.ValidateLink),
+		links:  opts.NewListOpts(opts.ValidateLink),
+		filter: opts.NewFilterOpt(),
 	}
 
 	cmd := &cobra.Command{
-		Use:   "connect [OPTIONS] NETWORK CONTAINER",
+		Use:   "connect [OPTIONS] NETWORK [CONTAINER]",
 		Short: "Connect a container to a network",
-		Args:  cli.ExactArgs(2),
+		Args:  containerArgs(&options.filter),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			options.network = args[0]
-			options.container = args[1]
-			return runConnect(cmd.Context(), dockerCLI.Client(), options)
+			if len(args) > 1 {
+				options.container = args[1]
+			}
+			return runConnect(cmd.Context(), dockerCLI, options)
 		},
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			if len(args) == 0 {
@@ -55,25 +62,117 @@ func newConnectCommand(dockerCLI command.Cli) *cobra.Command {
 	flags.StringSliceVar(&options.aliases, "alias", []string{}, "Add network-scoped alias for the container")
 	flags.StringSliceVar(&options.linklocalips, "link-local-ip", []string{}, "Add a link-local address for the container")
 	flags.StringSliceVar(&options.driverOpts, "driver-opt", []string{}, "driver options for the network")
+	flags.Var(&options.filter, "filter", `Connect the containers matching the filter (e.g. "label=app=web")`)
 	return cmd
 }
 
-func runConnect(ctx context.Context, apiClient client.NetworkAPIClient, options connectOptions) error {
+func runConnect(ctx context.Context, dockerCLI command.Cli, options connectOptions) error {
 	driverOpts, err := convertDriverOpt(options.driverOpts)
 	if err != nil {
 		return err
 	}
+	if options.container == "" && (options.ipaddress != "" || options.ipv6address != "") {
+		return errors.New("conflicting options: --ip and --ip6 cannot be used with --filter")
+	}
 
-	return apiClient.NetworkConnect(ctx, options.network, options.container, &network.EndpointSettings{
-		IPAMConfig: &network.EndpointIPAMConfig{
-			IPv4Address:  options.ipaddress,
-			IPv6Address:  options.ipv6address,
-			LinkLocalIPs: options.linklocalips,
-		},
-		Links:      options.links.GetAll(),
-		Aliases:    options.aliases,
-		DriverOpts: driverOpts,
-	})
+	apiClient := dockerCLI.Client()
+	connect := func(ctr string) error {
+		return apiClient.NetworkConnect(ctx, options.network, ctr, &network.EndpointSettings{
+			IPAMConfig: &network.EndpointIPAMConfig{
+				IPv4Address:  options.ipaddress,
+				IPv6Address:  options.ipv6address,
+				LinkLocalIPs: options.linklocalips,
+			},
+			Links:      options.links.GetAll(),
+			Aliases:    options.aliases,
+			DriverOpts: driverOpts,
+		})
+	}
+	if options.container != "" {
+		return connect(options.container)
+	}
+
+	containers, err := filteredContainers(ctx, apiClient, options.filter.Value())
+	if err != nil {
+		return err
+	}
+
+	// Skip the containers that are already connected to the network.
+	attachedFilter := options.filter.Value().Clone()
+	attachedFilter.Add("network", options.network)
+	attached, err := apiClient.ContainerList(ctx, container.ListOptions{All: true, Filters: attachedFilter})
+	if err != nil {
+		return err
+	}
+	skip := make(map[string]bool, len(attached))
+	for _, c := range attached {
+		skip[containerName(c)] = true
+	}
+	toConnect := make([]string, 0, len(containers))
+	for _, ctr := range containers {
+		if !skip[ctr] {
+			toConnect = append(toConnect, ctr)
+		}
+	}
+	return forEachContainer(dockerCLI, toConnect, connect)
+}
+
+// containerArgs validates the arguments of commands that take a NETWORK and
+// a CONTAINER, or a NETWORK and a filter to select the containers.
+func containerArgs(filter *opts.FilterOpt) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if filter.Value().Len() == 0 {
+			return cli.ExactArgs(2)(cmd, args)
+		}
+		if len(args) > 1 {
+			return errors.New("conflicting options: cannot specify both --filter and a container")
+		}
+		return cli.ExactArgs(1)(cmd, args)
+	}
+}
+
+// filteredContainers returns the names of the containers, running or not,
+// that match the filter.
+func filteredContainers(ctx context.Context, apiClient client.ContainerAPIClient, filter filters.Args) ([]string, error) {
+	containers, err := apiClient.ContainerList(ctx, container.ListOptions{All: true, Filters: filter})
+	if err != nil {
+		return nil, err
+	}
+	if len(containers) == 0 {
+		return nil, errors.New("no containers match the filter")
+	}
+	names := make([]string, 0, len(containers))
+	for _, c := range containers {
+		names = append(names, containerName(c))
+	}
+	return names, nil
+}
+
+// containerName returns the name of the container, or its ID if it has no
+// name.
+func containerName(c container.Summary) string {
+	if len(c.Names) > 0 {
+		return strings.TrimPrefix(c.Names[0], "/")
+	}
+	return c.ID
+}
+
+// forEachContainer calls fn for each container, and prints the name of the
+// containers for which it succeeded, and the errors of the others.
+func forEachContainer(dockerCLI command.Cli, containers []string, fn func(ctr string) error) error {
+	status := 0
+	for _, ctr := range containers {
+		if err := fn(ctr); err != nil {
+			_, _ = fmt.Fprintf(dockerCLI.Err(), "%s\n", err)
+			status = 1
+			continue
+		}
+		_, _ = fmt.Fprintf(dockerCLI.Out(), "%s\n", ctr)
+	}
+	if status != 0 {
+		return cli.StatusError{StatusCode: status}
+	}
+	return nil
 }
 
 func convertDriverOpt(options []string) (map[string]string, error) {
@@ -5,7 +5,9 @@
 	"io"
 	"testing"
 
+	"github.com/docker/cli/cli"
 	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
 	"github.com/pkg/errors"
 	"gotest.tools/v3/assert"
@@ -28,6 +30,18 @@ func TestNetworkConnectErrors(t *testing.T) {
 			},
 			expectedError: "error connecting network",
 		},
+		{
+			args:          []string{"--filter", "label=app=web", "toto", "titi"},
+			expectedError: "conflicting options: cannot specify both --filter and a container",
+		},
+		{
+			args:          []string{"--filter", "label=app=web", "--ip", "192.168.4.1", "toto"},
+			expectedError: "conflicting options: --ip and --ip6 cannot be used with --filter",
+		},
+		{
+			args:          []string{"--filter", "label=app=web", "toto"},
+			expectedError: "no containers match the filter",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -82,3 +96,40 @@ func TestNetworkConnectWithFlags(t *testing.T) {
 	}
 	assert.NilError(t, cmd.Execute())
 }
+
+func TestNetworkConnectFilter(t *testing.T) {
+	var connected []string
+	fakeCli := test.NewFakeCli(&fakeClient{
+		containerListFunc: func(_ context.Context, options container.ListOptions) ([]container.Summary, error) {
+			assert.Check(t, options.All)
+			assert.Check(t, options.Filters.ExactMatch("label", "app=web"))
+			if options.Filters.Contains("network") {
+				assert.Check(t, options.Filters.ExactMatch("network", "mynet"))
+				return []container.Summary{{ID: "c2", Names: []string{"/web-2"}}}, nil
+			}
+			return []container.Summary{
+				{ID: "c1", Names: []string{"/web-1"}},
+				{ID: "c2", Names: []string{"/web-2"}},
+				{ID: "c3", Names: []string{"/web-3"}},
+				{ID: "c4", Names: []string{"/web-4"}},
+			}, nil
+		},
+		networkConnectFunc: func(_ context.Context, networkID, container string, config *network.EndpointSettings) error {
+			assert.Check(t, is.Equal(networkID, "mynet"))
+			assert.Check(t, is.DeepEqual(config.Aliases, []string{"web"}))
+			if container == "web-3" {
+				return errors.Errorf("container %s is not running", container)
+			}
+			connected = append(connected, container)
+			return nil
+		},
+	})
+	cmd := newConnectCommand(fakeCli)
+	cmd.SetArgs([]string{"--filter", "label=app=web", "--alias", "web", "mynet"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	assert.Check(t, is.DeepEqual(cmd.Execute(), cli.StatusError{StatusCode: 1}))
+	assert.Check(t, is.DeepEqual(connected, []string{"web-1", "web-4"}))
+	assert.Check(t, is.Equal(fakeCli.OutBuffer().String(), "web-1\nweb-4\n"))
+	assert.Check(t, is.Equal(fakeCli.ErrBuffer().String(), "container web-3 is not running\n"))
+}
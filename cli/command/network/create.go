@@ -3,16 +3,16 @@
 import (
 	"context"
 	"fmt"
-	"io"
 	"net"
+	"sort"
 	"strings"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/cli/command/formatter/tabwriter"
 	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types/network"
-	"github.com/docker/docker/client"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -30,6 +30,8 @@ type createOptions struct {
 	configOnly bool
 	configFrom string
 	ipam       ipamOptions
+	strict     bool
+	dryRun     bool
 }
 
 type ipamOptions struct {
@@ -63,7 +65,7 @@ func newCreateCommand(dockerCLI command.Cli) *cobra.Command {
 				options.ipv6 = &ipv6
 			}
 
-			return runCreate(cmd.Context(), dockerCLI.Client(), dockerCLI.Out(), options)
+			return runCreate(cmd.Context(), dockerCLI, options)
 		},
 		ValidArgsFunction: completion.NoComplete,
 	}
@@ -93,14 +95,31 @@ func newCreateCommand(dockerCLI command.Cli) *cobra.Command {
 	flags.Var(&options.ipam.auxAddresses, "aux-address", "Auxiliary IPv4 or IPv6 addresses used by Network driver")
 	flags.Var(&options.ipam.driverOpts, "ipam-opt", "Set IPAM driver specific options")
 
+	flags.BoolVar(&options.strict, "strict", false, "Fail if a subnet overlaps with an existing network or a route of the host")
+	flags.BoolVar(&options.dryRun, "dry-run", false, "Show the IPAM configuration of the network without creating it")
+
 	return cmd
 }
 
-func runCreate(ctx context.Context, apiClient client.NetworkAPIClient, output io.Writer, options createOptions) error {
+func runCreate(ctx context.Context, dockerCLI command.Cli, options createOptions) error {
 	ipamCfg, err := createIPAMConfig(options.ipam)
 	if err != nil {
 		return err
 	}
+	sortIPAMConfig(ipamCfg.Config, options.ipam.subnets)
+
+	if len(ipamCfg.Config) > 0 || options.dryRun {
+		used, err := usedSubnets(ctx, dockerCLI)
+		if err != nil {
+			return err
+		}
+		if err := checkOverlaps(dockerCLI, ipamCfg.Config, used, options.strict); err != nil {
+			return err
+		}
+		if options.dryRun {
+			return printIPAMConfig(ctx, dockerCLI, options, ipamCfg, used)
+		}
+	}
 
 	var configFrom *network.ConfigReference
 	if options.configFrom != "" {
@@ -108,7 +127,7 @@ func runCreate(ctx context.Context, apiClient client.NetworkAPIClient, output io
 			Network: options.configFrom,
 		}
 	}
-	resp, err := apiClient.NetworkCreate(ctx, options.name, network.CreateOptions{
+	resp, err := dockerCLI.Client().NetworkCreate(ctx, options.name, network.CreateOptions{
 		Driver:     options.driver,
 		Options:    options.driverOpts.GetAll(),
 		IPAM:       ipamCfg,
@@ -124,10 +143,75 @@ func runCreate(ctx context.Context, apiClient client.NetworkAPIClient, output io
 	if err != nil {
 		return err
 	}
-	_, _ = fmt.Fprintf(output, "%s\n", resp.ID)
+	_, _ = fmt.Fprintf(dockerCLI.Out(), "%s\n", resp.ID)
+	return nil
+}
+
+// checkOverlaps prints a warning for each subnet that overlaps with a used
+// subnet, or fails if strict is set. Overlapping subnets make the containers
+// of the network, or the host, unreachable.
+func checkOverlaps(dockerCLI command.Cli, configs []network.IPAMConfig, used []usedSubnet, strict bool) error {
+	var overlaps []string
+	for _, cfg := range configs {
+		_, subnet, err := net.ParseCIDR(cfg.Subnet)
+		if err != nil {
+			return errors.Wrap(err, "invalid subnet")
+		}
+		for _, u := range overlappingSubnets(subnet, used) {
+			overlaps = append(overlaps, fmt.Sprintf("subnet %s overlaps with subnet %s of %s", cfg.Subnet, u.subnet, u.owner))
+		}
+	}
+	if len(overlaps) == 0 {
+		return nil
+	}
+	if strict {
+		return errors.New(strings.Join(overlaps, "\n"))
+	}
+	for _, o := range overlaps {
+		_, _ = fmt.Fprintln(dockerCLI.Err(), "WARNING:", o)
+	}
 	return nil
 }
 
+// printIPAMConfig prints the IPAM configuration that the network would be
+// created with. If no subnet is specified, the subnet of local networks is
+// predicted from the address pools of the daemon.
+func printIPAMConfig(ctx context.Context, dockerCLI command.Cli, options createOptions, ipam *network.IPAM, used []usedSubnet) error {
+	configs := ipam.Config
+	if len(configs) == 0 {
+		if options.driver != "bridge" || ipam.Driver != "default" || options.configFrom != "" || options.scope == "swarm" {
+			_, _ = fmt.Fprintln(dockerCLI.Err(), "The subnet of the network is allocated by the daemon when the network is created")
+			return nil
+		}
+		info, err := dockerCLI.Client().Info(ctx)
+		if err != nil {
+			return err
+		}
+		subnet := predictSubnet(info.DefaultAddressPools, used)
+		if subnet == nil {
+			return errors.New("no subnet is available in the default address pools of the daemon")
+		}
+		configs = []network.IPAMConfig{{Subnet: subnet.String()}}
+		_, _ = fmt.Fprintln(dockerCLI.Err(), "The subnet is the first available subnet of the default address pools of the daemon")
+	}
+
+	w := tabwriter.NewWriter(dockerCLI.Out(), 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "SUBNET\tGATEWAY\tIP RANGE\tAUX ADDRESSES")
+	for _, cfg := range configs {
+		gateway := cfg.Gateway
+		if gateway == "" {
+			gateway = defaultGateway(cfg)
+		}
+		aux := make([]string, 0, len(cfg.AuxAddress))
+		for k, v := range cfg.AuxAddress {
+			aux = append(aux, k+"="+v)
+		}
+		sort.Strings(aux)
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", cfg.Subnet, gateway, cfg.IPRange, strings.Join(aux, ","))
+	}
+	return w.Flush()
+}
+
 // Consolidates the ipam configuration as a group from different related configurations
 // user can configure network with multiple non-overlapping subnets and hence it is
 // possible to correlate the various related parameters and consolidate them.
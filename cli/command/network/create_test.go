@@ -8,6 +8,7 @@
 
 	"github.com/docker/cli/internal/test"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/system"
 	"github.com/pkg/errors"
 	"gotest.tools/v3/assert"
 	is "gotest.tools/v3/assert/cmp"
@@ -227,3 +228,93 @@ func TestNetworkCreateIPv6(t *testing.T) {
 		})
 	}
 }
+
+func overlapClient(t *testing.T, created *bool) *fakeClient {
+	t.Helper()
+	return &fakeClient{
+		networkListFunc: func(context.Context, network.ListOptions) ([]network.Summary, error) {
+			return []network.Summary{
+				{Name: "bridge", IPAM: network.IPAM{Config: []network.IPAMConfig{{Subnet: "172.17.0.0/16", Gateway: "172.17.0.1"}}}},
+				{Name: "frontend", IPAM: network.IPAM{Config: []network.IPAMConfig{{Subnet: "172.18.0.0/16"}}}},
+				{Name: "host"},
+			}, nil
+		},
+		networkCreateFunc: func(_ context.Context, name string, _ network.CreateOptions) (network.CreateResponse, error) {
+			*created = true
+			return network.CreateResponse{ID: name}, nil
+		},
+	}
+}
+
+func TestNetworkCreateOverlap(t *testing.T) {
+	var created bool
+	cli := test.NewFakeCli(overlapClient(t, &created))
+	cmd := newCreateCommand(cli)
+	cmd.SetArgs([]string{"--subnet", "172.18.128.0/20", "mynet"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, created)
+	assert.Check(t, is.Equal(cli.ErrBuffer().String(), "WARNING: subnet 172.18.128.0/20 overlaps with subnet 172.18.0.0/16 of network frontend\n"))
+}
+
+func TestNetworkCreateOverlapStrict(t *testing.T) {
+	var created bool
+	cli := test.NewFakeCli(overlapClient(t, &created))
+	cmd := newCreateCommand(cli)
+	cmd.SetArgs([]string{"--strict", "--subnet", "172.16.0.0/12", "mynet"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	assert.Error(t, cmd.Execute(), "subnet 172.16.0.0/12 overlaps with subnet 172.17.0.0/16 of network bridge\n"+
+		"subnet 172.16.0.0/12 overlaps with subnet 172.18.0.0/16 of network frontend")
+	assert.Check(t, !created)
+}
+
+func TestNetworkCreateDryRun(t *testing.T) {
+	testCases := []struct {
+		doc            string
+		args           []string
+		pools          []system.NetworkAddressPool
+		expectedOut    string
+		expectedErrOut string
+	}{
+		{
+			doc:  "subnets",
+			args: []string{"--subnet", "10.10.0.0/16", "--ip-range", "10.10.240.0/20", "--aux-address", "router=10.10.0.254", "--subnet", "fd00:10::/64", "--gateway", "fd00:10::1"},
+			expectedOut: "SUBNET         GATEWAY       IP RANGE         AUX ADDRESSES\n" +
+				"10.10.0.0/16   10.10.240.1   10.10.240.0/20   router=10.10.0.254\n" +
+				"fd00:10::/64   fd00:10::1                     \n",
+		},
+		{
+			doc:            "default pools",
+			expectedOut:    "SUBNET          GATEWAY      IP RANGE   AUX ADDRESSES\n172.19.0.0/16   172.19.0.1              \n",
+			expectedErrOut: "The subnet is the first available subnet of the default address pools of the daemon\n",
+		},
+		{
+			doc:            "custom pools",
+			pools:          []system.NetworkAddressPool{{Base: "172.18.0.0/15", Size: 24}},
+			expectedOut:    "SUBNET          GATEWAY      IP RANGE   AUX ADDRESSES\n172.19.0.0/24   172.19.0.1              \n",
+			expectedErrOut: "The subnet is the first available subnet of the default address pools of the daemon\n",
+		},
+		{
+			doc:            "overlay",
+			args:           []string{"--driver", "overlay"},
+			expectedErrOut: "The subnet of the network is allocated by the daemon when the network is created\n",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.doc, func(t *testing.T) {
+			var created bool
+			client := overlapClient(t, &created)
+			client.infoFunc = func(context.Context) (system.Info, error) {
+				return system.Info{DefaultAddressPools: tc.pools}, nil
+			}
+			cli := test.NewFakeCli(client)
+			cmd := newCreateCommand(cli)
+			cmd.SetArgs(append(append([]string{"--dry-run"}, tc.args...), "mynet"))
+			assert.NilError(t, cmd.Execute())
+			assert.Check(t, !created)
+			assert.Check(t, is.Equal(cli.OutBuffer().String(), tc.expectedOut))
+			assert.Check(t, is.Equal(cli.ErrBuffer().String(), tc.expectedErrOut))
+		})
+	}
+}
@@ -3,11 +3,10 @@
 import (
 	"context"
 
-	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/opts"
 	"github.com/docker/docker/api/types/container"
-	"github.com/docker/docker/client"
 	"github.com/spf13/cobra"
 )
 
@@ -15,19 +14,22 @@ type disconnectOptions struct {
 	network   string
 	container string
 	force     bool
+	filter    opts.FilterOpt
 }
 
 func newDisconnectCommand(dockerCli command.Cli) *cobra.Command {
-	opts := disconnectOptions{}
+	opts := disconnectOptions{filter: opts.NewFilterOpt()}
 
 	cmd := &cobra.Command{
-		Use:   "disconnect [OPTIONS] NETWORK CONTAINER",
+		Use:   "disconnect [OPTIONS] NETWORK [CONTAINER]",
 		Short: "Disconnect a container from a network",
-		Args:  cli.ExactArgs(2),
+		Args:  containerArgs(&opts.filter),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.network = args[0]
-			opts.container = args[1]
-			return runDisconnect(cmd.Context(), dockerCli.Client(), opts)
+			if len(args) > 1 {
+				opts.container = args[1]
+			}
+			return runDisconnect(cmd.Context(), dockerCli, opts)
 		},
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			if len(args) == 0 {
@@ -40,12 +42,28 @@ func newDisconnectCommand(dockerCli command.Cli) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.BoolVarP(&opts.force, "force", "f", false, "Force the container to disconnect from a network")
+	flags.Var(&opts.filter, "filter", `Disconnect the containers matching the filter (e.g. "label=app=web")`)
 
 	return cmd
 }
 
-func runDisconnect(ctx context.Context, apiClient client.NetworkAPIClient, opts disconnectOptions) error {
-	return apiClient.NetworkDisconnect(ctx, opts.network, opts.container, opts.force)
+func runDisconnect(ctx context.Context, dockerCli command.Cli, opts disconnectOptions) error {
+	apiClient := dockerCli.Client()
+	disconnect := func(ctr string) error {
+		return apiClient.NetworkDisconnect(ctx, opts.network, ctr, opts.force)
+	}
+	if opts.container != "" {
+		return disconnect(opts.container)
+	}
+
+	// Only select the containers that are connected to the network.
+	filter := opts.filter.Value().Clone()
+	filter.Add("network", opts.network)
+	containers, err := filteredContainers(ctx, apiClient, filter)
+	if err != nil {
+		return err
+	}
+	return forEachContainer(dockerCli, containers, disconnect)
 }
 
 func isConnected(network string) func(container.Summary) bool {
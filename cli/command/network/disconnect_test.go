@@ -6,8 +6,10 @@
 	"testing"
 
 	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/container"
 	"github.com/pkg/errors"
 	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
 )
 
 func TestNetworkDisconnectErrors(t *testing.T) {
@@ -40,3 +42,28 @@ func TestNetworkDisconnectErrors(t *testing.T) {
 		assert.ErrorContains(t, cmd.Execute(), tc.expectedError)
 	}
 }
+
+func TestNetworkDisconnectFilter(t *testing.T) {
+	var disconnected []string
+	cli := test.NewFakeCli(&fakeClient{
+		containerListFunc: func(_ context.Context, options container.ListOptions) ([]container.Summary, error) {
+			assert.Check(t, options.Filters.ExactMatch("label", "app=web"))
+			assert.Check(t, options.Filters.ExactMatch("network", "mynet"))
+			return []container.Summary{
+				{ID: "c1", Names: []string{"/web-1"}},
+				{ID: "c2", Names: []string{"/web-2"}},
+			}, nil
+		},
+		networkDisconnectFunc: func(_ context.Context, networkID, container string, force bool) error {
+			assert.Check(t, is.Equal(networkID, "mynet"))
+			assert.Check(t, force)
+			disconnected = append(disconnected, container)
+			return nil
+		},
+	})
+	cmd := newDisconnectCommand(cli)
+	cmd.SetArgs([]string{"--filter", "label=app=web", "--force", "mynet"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.DeepEqual(disconnected, []string{"web-1", "web-2"}))
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "web-1\nweb-2\n"))
+}
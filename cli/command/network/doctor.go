@@ -0,0 +1,392 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/system"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// dnsTestName is the name that is resolved to check DNS resolution.
+	dnsTestName = "docker.com"
+	// dnsTimeout is the time after which the DNS check is aborted.
+	dnsTimeout = 30 * time.Second
+	// addressUsageThreshold is the percentage of the addresses of a subnet
+	// in use from which the subnet is reported as almost exhausted.
+	addressUsageThreshold = 90
+)
+
+type doctorOptions struct {
+	networks []string
+	image    string
+	skipDNS  bool
+}
+
+// doctorCheck is the result of a check of "docker network doctor".
+type doctorCheck struct {
+	name     string
+	skipped  string
+	problems []doctorProblem
+}
+
+// doctorProblem is a problem found by a check, and how to fix it.
+type doctorProblem struct {
+	message    string
+	suggestion string
+}
+
+func (c *doctorCheck) add(suggestion, format string, args ...any) {
+	c.problems = append(c.problems, doctorProblem{message: fmt.Sprintf(format, args...), suggestion: suggestion})
+}
+
+func newDoctorCommand(dockerCLI command.Cli) *cobra.Command {
+	var opts doctorOptions
+
+	cmd := &cobra.Command{
+		Use:   "doctor [OPTIONS] [NETWORK...]",
+		Short: "Check networks for common problems",
+		Args:  cli.RequiresMinArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.networks = args
+			return runDoctor(cmd.Context(), dockerCLI, opts)
+		},
+		ValidArgsFunction: completion.NetworkNames(dockerCLI),
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.image, "image", "busybox", "Image of the container used to check DNS resolution")
+	flags.BoolVar(&opts.skipDNS, "skip-dns", false, "Do not check DNS resolution inside a container")
+
+	return cmd
+}
+
+func runDoctor(ctx context.Context, dockerCLI command.Cli, opts doctorOptions) error {
+	networks, err := inspectNetworks(ctx, dockerCLI.Client(), opts.networks)
+	if err != nil {
+		return err
+	}
+	info, err := dockerCLI.Client().Info(ctx)
+	if err != nil {
+		return err
+	}
+	used, err := usedSubnets(ctx, dockerCLI)
+	if err != nil {
+		return err
+	}
+
+	checks := []doctorCheck{
+		checkSubnetOverlaps(networks, used),
+		checkAddressPools(info.DefaultAddressPools, networks, used),
+	}
+	stale, err := checkStaleEndpoints(ctx, dockerCLI, networks)
+	if err != nil {
+		return err
+	}
+	checks = append(checks, stale, checkFirewall(info.OSType, info.IPv4Forwarding, info.BridgeNfIptables, info.BridgeNfIP6tables, networks))
+
+	dnsNetworks := opts.networks
+	if len(dnsNetworks) == 0 {
+		dnsNetworks = []string{network.NetworkBridge}
+	}
+	for _, nw := range dnsNetworks {
+		checks = append(checks, checkDNS(ctx, dockerCLI, opts, nw))
+	}
+
+	return printDoctorChecks(dockerCLI.Out(), checks)
+}
+
+// checkSubnetOverlaps reports the subnets of the networks that overlap with
+// the subnet of another network, or with a route of the host.
+func checkSubnetOverlaps(networks []network.Inspect, used []usedSubnet) doctorCheck {
+	check := doctorCheck{name: "Overlapping subnets"}
+	reported := map[string]bool{}
+	for _, nw := range networks {
+		for _, cfg := range nw.IPAM.Config {
+			_, subnet, err := net.ParseCIDR(cfg.Subnet)
+			if err != nil {
+				continue
+			}
+			for _, u := range overlappingSubnets(subnet, used) {
+				if u.networkID != "" && u.networkID == nw.ID {
+					continue
+				}
+				// Overlaps between two networks are only reported once.
+				pair := []string{nw.ID + subnet.String(), u.networkID + u.subnet.String()}
+				sort.Strings(pair)
+				key := strings.Join(pair, "|")
+				if reported[key] {
+					continue
+				}
+				reported[key] = true
+				check.add(
+					fmt.Sprintf("Remove one of the networks, or re-create network %s with a subnet that doesn't overlap (see \"docker network create --dry-run\")", nw.Name),
+					"subnet %s of network %s overlaps with subnet %s of %s", subnet, nw.Name, u.subnet, u.owner,
+				)
+			}
+		}
+	}
+	return check
+}
+
+// checkAddressPools reports whether the default address pools of the daemon
+// have no subnet left for new networks, and the subnets of the networks of
+// which almost all the addresses are in use.
+func checkAddressPools(pools []system.NetworkAddressPool, networks []network.Inspect, used []usedSubnet) doctorCheck {
+	check := doctorCheck{name: "Address pools"}
+	if predictSubnet(pools, used) == nil {
+		check.add(
+			`Remove unused networks with "docker network prune", or add address pools with the "default-address-pools" option of the daemon`,
+			"no subnet is left in the default address pools of the daemon for new networks",
+		)
+	}
+	for _, nw := range networks {
+		for _, cfg := range nw.IPAM.Config {
+			capacity := addressCapacity(cfg)
+			if capacity == 0 {
+				continue
+			}
+			var inUse uint64
+			for _, ep := range nw.Containers {
+				ip, _, err := net.ParseCIDR(ep.IPv4Address)
+				if err == nil && subnetContains(cfg.Subnet, ip) {
+					inUse++
+				}
+			}
+			if inUse*100 >= capacity*addressUsageThreshold {
+				check.add(
+					fmt.Sprintf("Re-create network %s with a larger subnet, or split its containers across networks", nw.Name),
+					"%d of the %d addresses of subnet %s of network %s are in use", inUse, capacity, cfg.Subnet, nw.Name,
+				)
+			}
+		}
+	}
+	return check
+}
+
+// addressCapacity returns the number of addresses that can be assigned to
+// containers in an IPv4 subnet, or in its IP range, which excludes the
+// network and broadcast addresses, and the gateway. It returns 0 for IPv6
+// subnets, which can't realistically be exhausted.
+func addressCapacity(cfg network.IPAMConfig) uint64 {
+	r := cfg.IPRange
+	if r == "" {
+		r = cfg.Subnet
+	}
+	_, ipNet, err := net.ParseCIDR(r)
+	if err != nil || ipNet.IP.To4() == nil {
+		return 0
+	}
+	ones, bits := ipNet.Mask.Size()
+	size := uint64(1) << uint(bits-ones)
+	if size <= 3 {
+		return 0
+	}
+	return size - 3
+}
+
+func subnetContains(subnet string, ip net.IP) bool {
+	_, ipNet, err := net.ParseCIDR(subnet)
+	return err == nil && ipNet.Contains(ip)
+}
+
+// checkStaleEndpoints reports the endpoints of containers that don't exist
+// anymore, or that are not running, which keep their addresses allocated.
+func checkStaleEndpoints(ctx context.Context, dockerCLI command.Cli, networks []network.Inspect) (doctorCheck, error) {
+	check := doctorCheck{name: "Stale endpoints"}
+	containers, err := dockerCLI.Client().ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return check, err
+	}
+	states := make(map[string]string, len(containers))
+	for _, c := range containers {
+		states[c.ID] = c.State
+	}
+	for _, nw := range networks {
+		ids := make([]string, 0, len(nw.Containers))
+		for id := range nw.Containers {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			// The endpoints of load-balancers, and of containers of other
+			// nodes, are not endpoints of local containers.
+			if strings.HasPrefix(id, "lb-") || strings.HasPrefix(id, "ep-") {
+				continue
+			}
+			name := nw.Containers[id].Name
+			if name == "" {
+				name = id
+			}
+			suggestion := fmt.Sprintf("docker network disconnect --force %s %s", nw.Name, name)
+			state, ok := states[id]
+			switch {
+			case !ok:
+				check.add(suggestion, "network %s has an endpoint of container %s (%s), which doesn't exist", nw.Name, name, stringid.TruncateID(id))
+			case state != "running" && state != "paused" && state != "restarting":
+				check.add(suggestion, "network %s has an endpoint of container %s, which is %s", nw.Name, name, state)
+			}
+		}
+	}
+	return check, nil
+}
+
+// checkFirewall reports the kernel settings that prevent containers from
+// reaching other networks, or from being reached through published ports,
+// and that prevent the isolation of the containers of bridge networks with
+// inter-container communication disabled. Only the settings reported by the
+// daemon are checked, not the iptables rules themselves.
+func checkFirewall(osType string, ipv4Forwarding, bridgeNfIptables, bridgeNfIP6tables bool, networks []network.Inspect) doctorCheck {
+	check := doctorCheck{name: "IP forwarding and iptables"}
+	if osType != "linux" {
+		check.skipped = "only checked on Linux daemons"
+		return check
+	}
+	if !ipv4Forwarding {
+		check.add(
+			`Enable IP forwarding with "sysctl net.ipv4.ip_forward=1" on the host of the daemon, and restart the daemon`,
+			"IPv4 forwarding is disabled: containers can't reach external networks, and published ports are unreachable",
+		)
+	}
+	// Traffic between the containers of a bridge network only goes through
+	// iptables with br_netfilter, which is only needed to block it if
+	// inter-container communication is disabled.
+	for _, nw := range networks {
+		if nw.Driver != "bridge" || nw.Options["com.docker.network.bridge.enable_icc"] != "false" {
+			continue
+		}
+		if !bridgeNfIptables {
+			check.add(
+				`Load the "br_netfilter" kernel module with "modprobe br_netfilter" on the host of the daemon`,
+				"bridge-nf-call-iptables is disabled: traffic between the containers of network %s isn't blocked, although inter-container communication is disabled", nw.Name,
+			)
+		}
+		if nw.EnableIPv6 && !bridgeNfIP6tables {
+			check.add(
+				`Load the "br_netfilter" kernel module with "modprobe br_netfilter" on the host of the daemon`,
+				"bridge-nf-call-ip6tables is disabled: IPv6 traffic between the containers of network %s isn't blocked, although inter-container communication is disabled", nw.Name,
+			)
+		}
+	}
+	return check
+}
+
+// checkDNS resolves a name inside a short-lived container attached to the
+// network.
+func checkDNS(ctx context.Context, dockerCLI command.Cli, opts doctorOptions, nw string) doctorCheck {
+	check := doctorCheck{name: "DNS resolution in network " + nw}
+	if opts.skipDNS {
+		check.skipped = "skipped with --skip-dns"
+		return check
+	}
+	if nw == network.NetworkNone || nw == network.NetworkHost {
+		check.skipped = "not checked for network " + nw
+		return check
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dnsTimeout)
+	defer cancel()
+	output, err := runDNSContainer(ctx, dockerCLI, opts.image, nw)
+	if err != nil {
+		check.add(
+			"Check the DNS servers of the host of the daemon, or set them with the \"dns\" option of the daemon",
+			"failed to resolve %s in network %s: %s", dnsTestName, nw, strings.TrimSpace(err.Error()+"\n"+output),
+		)
+	}
+	return check
+}
+
+// runDNSContainer runs nslookup in a container, and returns its output.
+func runDNSContainer(ctx context.Context, dockerCLI command.Cli, img, nw string) (string, error) {
+	apiClient := dockerCLI.Client()
+	config := &container.Config{Image: img, Cmd: []string{"nslookup", dnsTestName}}
+	hostConfig := &container.HostConfig{NetworkMode: container.NetworkMode(nw)}
+
+	created, err := apiClient.ContainerCreate(ctx, config, hostConfig, nil, nil, "")
+	if errdefs.IsNotFound(err) {
+		_, _ = fmt.Fprintf(dockerCLI.Err(), "Unable to find image '%s' locally, pulling it to check DNS resolution\n", img)
+		responseBody, pullErr := apiClient.ImagePull(ctx, img, image.PullOptions{})
+		if pullErr != nil {
+			return "", pullErr
+		}
+		_, _ = io.Copy(io.Discard, responseBody)
+		responseBody.Close()
+		created, err = apiClient.ContainerCreate(ctx, config, hostConfig, nil, nil, "")
+	}
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = apiClient.ContainerRemove(context.WithoutCancel(ctx), created.ID, container.RemoveOptions{Force: true})
+	}()
+
+	waitCh, errCh := apiClient.ContainerWait(ctx, created.ID, container.WaitConditionNextExit)
+	if err := apiClient.ContainerStart(ctx, created.ID, container.StartOptions{}); err != nil {
+		return "", err
+	}
+	var exitCode int64
+	select {
+	case res := <-waitCh:
+		exitCode = res.StatusCode
+	case err := <-errCh:
+		return "", err
+	}
+	if exitCode == 0 {
+		return "", nil
+	}
+
+	var output bytes.Buffer
+	if logs, err := apiClient.ContainerLogs(ctx, created.ID, container.LogsOptions{ShowStdout: true, ShowStderr: true}); err == nil {
+		_, _ = stdcopy.StdCopy(&output, &output, logs)
+		logs.Close()
+	}
+	return output.String(), fmt.Errorf("nslookup exited with status %d", exitCode)
+}
+
+// printDoctorChecks prints the result of the checks, and returns an error
+// if a problem was found.
+func printDoctorChecks(out io.Writer, checks []doctorCheck) error {
+	var b strings.Builder
+	var problems int
+	for _, check := range checks {
+		switch {
+		case check.skipped != "":
+			fmt.Fprintf(&b, "[SKIP] %s: %s\n", check.name, check.skipped)
+		case len(check.problems) == 0:
+			fmt.Fprintf(&b, "[OK]   %s\n", check.name)
+		default:
+			fmt.Fprintf(&b, "[WARN] %s\n", check.name)
+			for _, p := range check.problems {
+				fmt.Fprintf(&b, "       - %s\n", strings.ReplaceAll(p.message, "\n", "\n         "))
+				fmt.Fprintf(&b, "         Suggestion: %s\n", p.suggestion)
+			}
+			problems += len(check.problems)
+		}
+	}
+	if problems > 0 {
+		fmt.Fprintf(&b, "\n%d problem(s) found\n", problems)
+	}
+	if _, err := io.WriteString(out, b.String()); err != nil {
+		return err
+	}
+	if problems > 0 {
+		return cli.StatusError{StatusCode: 1}
+	}
+	return nil
+}
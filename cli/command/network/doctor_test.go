@@ -0,0 +1,173 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/system"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/golden"
+)
+
+func doctorClient(networks []network.Inspect, containers []container.Summary, info system.Info) *fakeClient {
+	return &fakeClient{
+		networkListFunc: func(context.Context, network.ListOptions) ([]network.Summary, error) {
+			return networks, nil
+		},
+		networkInspectFunc: func(_ context.Context, networkID string, _ network.InspectOptions) (network.Inspect, []byte, error) {
+			for _, nw := range networks {
+				if nw.ID == networkID || nw.Name == networkID {
+					return nw, nil, nil
+				}
+			}
+			return network.Inspect{}, nil, errdefs.NotFound(errors.Errorf("network %s not found", networkID))
+		},
+		containerListFunc: func(context.Context, container.ListOptions) ([]container.Summary, error) {
+			return containers, nil
+		},
+		infoFunc: func(context.Context) (system.Info, error) {
+			return info, nil
+		},
+	}
+}
+
+func TestNetworkDoctorHealthy(t *testing.T) {
+	fakeCli := test.NewFakeCli(doctorClient(
+		[]network.Inspect{
+			{ID: "n1", Name: "bridge", Driver: "bridge", IPAM: network.IPAM{Config: []network.IPAMConfig{{Subnet: "172.17.0.0/16"}}}, Containers: map[string]network.EndpointResource{
+				"c1": {Name: "web", IPv4Address: "172.17.0.2/16"},
+			}},
+			{ID: "n2", Name: "host", Driver: "host"},
+		},
+		[]container.Summary{{ID: "c1", State: "running"}},
+		system.Info{OSType: "linux", IPv4Forwarding: true, BridgeNfIptables: true, BridgeNfIP6tables: true},
+	))
+	cmd := newDoctorCommand(fakeCli)
+	cmd.SetArgs([]string{"--skip-dns"})
+	assert.NilError(t, cmd.Execute())
+	golden.Assert(t, fakeCli.OutBuffer().String(), "network-doctor-healthy.golden")
+}
+
+func TestNetworkDoctorProblems(t *testing.T) {
+	fakeCli := test.NewFakeCli(doctorClient(
+		[]network.Inspect{
+			{ID: "n1", Name: "bridge", Driver: "bridge", IPAM: network.IPAM{Config: []network.IPAMConfig{{Subnet: "172.17.0.0/16"}}}},
+			{ID: "n2", Name: "frontend", Driver: "bridge", IPAM: network.IPAM{Config: []network.IPAMConfig{{Subnet: "172.16.0.0/12"}}}},
+			{ID: "n3", Name: "small", Driver: "bridge", EnableIPv6: true, Options: map[string]string{"com.docker.network.bridge.enable_icc": "false"}, IPAM: network.IPAM{Config: []network.IPAMConfig{{Subnet: "10.10.0.0/29"}}}, Containers: map[string]network.EndpointResource{
+				"c1":               {Name: "web-1", IPv4Address: "10.10.0.2/29"},
+				"c2":               {Name: "web-2", IPv4Address: "10.10.0.3/29"},
+				"c3":               {Name: "web-3", IPv4Address: "10.10.0.4/29"},
+				"c4":               {Name: "web-4", IPv4Address: "10.10.0.5/29"},
+				"5f3c1b9a2d7e4c6f": {Name: "gone", IPv4Address: "10.10.0.6/29"},
+				"lb-small":         {Name: "small-endpoint"},
+			}},
+		},
+		[]container.Summary{
+			{ID: "c1", State: "running"},
+			{ID: "c2", State: "running"},
+			{ID: "c3", State: "running"},
+			{ID: "c4", State: "exited"},
+		},
+		system.Info{
+			OSType:              "linux",
+			DefaultAddressPools: []system.NetworkAddressPool{{Base: "172.17.0.0/16", Size: 16}},
+		},
+	))
+	cmd := newDoctorCommand(fakeCli)
+	cmd.SetArgs([]string{"--skip-dns"})
+	assert.Check(t, is.DeepEqual(cmd.Execute(), cli.StatusError{StatusCode: 1}))
+	golden.Assert(t, fakeCli.OutBuffer().String(), "network-doctor-problems.golden")
+}
+
+func TestNetworkDoctorDNS(t *testing.T) {
+	testCases := []struct {
+		doc        string
+		exitCode   int64
+		pull       bool
+		expected   string
+		expectedOK bool
+	}{
+		{
+			doc:        "resolved",
+			expected:   "[OK]   DNS resolution in network mynet\n",
+			expectedOK: true,
+		},
+		{
+			doc:        "pull",
+			pull:       true,
+			expected:   "[OK]   DNS resolution in network mynet\n",
+			expectedOK: true,
+		},
+		{
+			doc:      "failed",
+			exitCode: 1,
+			expected: "[WARN] DNS resolution in network mynet\n" +
+				"       - failed to resolve docker.com in network mynet: nslookup exited with status 1\n" +
+				"         ;; connection timed out; no servers could be reached\n" +
+				"         Suggestion: Check the DNS servers of the host of the daemon, or set them with the \"dns\" option of the daemon\n",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.doc, func(t *testing.T) {
+			var pulled, removed bool
+			client := doctorClient([]network.Inspect{{ID: "n1", Name: "mynet", Driver: "bridge"}}, nil, system.Info{})
+			client.containerCreateFunc = func(_ context.Context, config *container.Config, hostConfig *container.HostConfig) (container.CreateResponse, error) {
+				assert.Check(t, is.Equal(config.Image, "busybox"))
+				assert.Check(t, is.DeepEqual([]string(config.Cmd), []string{"nslookup", "docker.com"}))
+				assert.Check(t, is.Equal(hostConfig.NetworkMode, container.NetworkMode("mynet")))
+				if tc.pull && !pulled {
+					return container.CreateResponse{}, errdefs.NotFound(errors.New("no such image: busybox"))
+				}
+				return container.CreateResponse{ID: "dns-check"}, nil
+			}
+			client.imagePullFunc = func(_ context.Context, ref string) (io.ReadCloser, error) {
+				assert.Check(t, is.Equal(ref, "busybox"))
+				pulled = true
+				return io.NopCloser(bytes.NewReader(nil)), nil
+			}
+			client.containerWaitFunc = func(context.Context, string) (<-chan container.WaitResponse, <-chan error) {
+				waitCh := make(chan container.WaitResponse, 1)
+				waitCh <- container.WaitResponse{StatusCode: tc.exitCode}
+				return waitCh, make(chan error)
+			}
+			client.containerLogsFunc = func(context.Context, string) (io.ReadCloser, error) {
+				var logs bytes.Buffer
+				_, _ = stdcopy.NewStdWriter(&logs, stdcopy.Stdout).Write([]byte(";; connection timed out; no servers could be reached\n"))
+				return io.NopCloser(&logs), nil
+			}
+			client.containerRemoveFunc = func(_ context.Context, containerID string, options container.RemoveOptions) error {
+				assert.Check(t, is.Equal(containerID, "dns-check"))
+				assert.Check(t, options.Force)
+				removed = true
+				return nil
+			}
+
+			fakeCli := test.NewFakeCli(client)
+			cmd := newDoctorCommand(fakeCli)
+			cmd.SetArgs([]string{"mynet"})
+			err := cmd.Execute()
+			if tc.expectedOK {
+				assert.NilError(t, err)
+			} else {
+				assert.Check(t, is.DeepEqual(err, cli.StatusError{StatusCode: 1}))
+			}
+			assert.Check(t, is.Contains(fakeCli.OutBuffer().String(), tc.expected))
+			assert.Check(t, is.Equal(pulled, tc.pull))
+			if tc.pull {
+				assert.Check(t, is.Equal(fakeCli.ErrBuffer().String(), "Unable to find image 'busybox' locally, pulling it to check DNS resolution\n"))
+			}
+			assert.Check(t, removed)
+		})
+	}
+}
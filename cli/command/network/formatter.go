@@ -12,9 +12,12 @@
 const (
 	defaultNetworkTableFormat = "table {{.ID}}\t{{.Name}}\t{{.Driver}}\t{{.Scope}}"
 
-	networkIDHeader = "NETWORK ID"
-	ipv6Header      = "IPV6"
-	internalHeader  = "INTERNAL"
+	networkIDHeader  = "NETWORK ID"
+	ipv6Header       = "IPV6"
+	internalHeader   = "INTERNAL"
+	containersHeader = "CONTAINERS"
+	subnetHeader     = "SUBNET"
+	gatewayHeader    = "GATEWAY"
 )
 
 // NewFormat returns a Format for rendering using a network Context
@@ -34,6 +37,12 @@ func NewFormat(source string, quiet bool) formatter.Format {
 	return formatter.Format(source)
 }
 
+// needsContainers returns whether the format shows the containers attached
+// to the networks, which are not included when listing networks.
+func needsContainers(format formatter.Format) bool {
+	return format.IsJSON() || format.Contains("{{json .}}") || format.Contains(".Containers")
+}
+
 // FormatWrite writes the context
 func FormatWrite(ctx formatter.Context, networks []network.Summary) error {
 	render := func(format func(subContext formatter.SubContext) error) error {
@@ -47,14 +56,17 @@ func FormatWrite(ctx formatter.Context, networks []network.Summary) error {
 	}
 	networkCtx := networkContext{}
 	networkCtx.Header = formatter.SubHeaderContext{
-		"ID":        networkIDHeader,
-		"Name":      formatter.NameHeader,
-		"Driver":    formatter.DriverHeader,
-		"Scope":     formatter.ScopeHeader,
-		"IPv6":      ipv6Header,
-		"Internal":  internalHeader,
-		"Labels":    formatter.LabelsHeader,
-		"CreatedAt": formatter.CreatedAtHeader,
+		"ID":         networkIDHeader,
+		"Name":       formatter.NameHeader,
+		"Driver":     formatter.DriverHeader,
+		"Scope":      formatter.ScopeHeader,
+		"IPv6":       ipv6Header,
+		"Internal":   internalHeader,
+		"Labels":     formatter.LabelsHeader,
+		"CreatedAt":  formatter.CreatedAtHeader,
+		"Containers": containersHeader,
+		"Subnet":     subnetHeader,
+		"Gateway":    gatewayHeader,
 	}
 	return ctx.Write(&networkCtx, render)
 }
@@ -118,3 +130,30 @@ func (c *networkContext) Label(name string) string {
 func (c *networkContext) CreatedAt() string {
 	return c.n.Created.String()
 }
+
+// Containers returns the number of containers attached to the network.
+func (c *networkContext) Containers() string {
+	return strconv.Itoa(len(c.n.Containers))
+}
+
+// Subnet returns the subnets of the network, separated by commas.
+func (c *networkContext) Subnet() string {
+	subnets := make([]string, 0, len(c.n.IPAM.Config))
+	for _, cfg := range c.n.IPAM.Config {
+		if cfg.Subnet != "" {
+			subnets = append(subnets, cfg.Subnet)
+		}
+	}
+	return strings.Join(subnets, ",")
+}
+
+// Gateway returns the gateways of the network, separated by commas.
+func (c *networkContext) Gateway() string {
+	gateways := make([]string, 0, len(c.n.IPAM.Config))
+	for _, cfg := range c.n.IPAM.Config {
+		if cfg.Gateway != "" {
+			gateways = append(gateways, cfg.Gateway)
+		}
+	}
+	return strings.Join(gateways, ",")
+}
@@ -60,6 +60,24 @@ func TestNetworkContext(t *testing.T) {
 		{networkContext{
 			n: network.Summary{Labels: map[string]string{"label1": "value1", "label2": "value2"}},
 		}, "label1=value1,label2=value2", ctx.Labels},
+		{networkContext{
+			n: network.Summary{},
+		}, "0", ctx.Containers},
+		{networkContext{
+			n: network.Summary{Containers: map[string]network.EndpointResource{"c1": {}, "c2": {}}},
+		}, "2", ctx.Containers},
+		{networkContext{
+			n: network.Summary{IPAM: network.IPAM{Config: []network.IPAMConfig{
+				{Subnet: "172.18.0.0/16", Gateway: "172.18.0.1"},
+				{Subnet: "fd00::/64"},
+			}}},
+		}, "172.18.0.0/16,fd00::/64", ctx.Subnet},
+		{networkContext{
+			n: network.Summary{IPAM: network.IPAM{Config: []network.IPAMConfig{
+				{Subnet: "172.18.0.0/16", Gateway: "172.18.0.1"},
+				{Subnet: "fd00::/64"},
+			}}},
+		}, "172.18.0.1", ctx.Gateway},
 	}
 
 	for _, c := range cases {
@@ -181,8 +199,8 @@ func TestNetworkContextWriteJSON(t *testing.T) {
 		{ID: "networkID2", Name: "foobar_bar"},
 	}
 	expectedJSONs := []map[string]any{
-		{"Driver": "", "ID": "networkID1", "IPv6": "false", "Internal": "false", "Labels": "", "Name": "foobar_baz", "Scope": "", "CreatedAt": "0001-01-01 00:00:00 +0000 UTC"},
-		{"Driver": "", "ID": "networkID2", "IPv6": "false", "Internal": "false", "Labels": "", "Name": "foobar_bar", "Scope": "", "CreatedAt": "0001-01-01 00:00:00 +0000 UTC"},
+		{"Driver": "", "ID": "networkID1", "IPv6": "false", "Internal": "false", "Labels": "", "Name": "foobar_baz", "Scope": "", "CreatedAt": "0001-01-01 00:00:00 +0000 UTC", "Containers": "0", "Subnet": "", "Gateway": ""},
+		{"Driver": "", "ID": "networkID2", "IPv6": "false", "Internal": "false", "Labels": "", "Name": "foobar_bar", "Scope": "", "CreatedAt": "0001-01-01 00:00:00 +0000 UTC", "Containers": "0", "Subnet": "", "Gateway": ""},
 	}
 
 	out := bytes.NewBufferString("")
@@ -0,0 +1,255 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+)
+
+// Formats of "docker network inspect" that render the networks as a graph.
+const (
+	graphFormatDot   = "dot"
+	graphFormatASCII = "ascii"
+)
+
+// graphNetwork is a network, and the containers that are attached to it.
+type graphNetwork struct {
+	network   network.Inspect
+	endpoints []graphEndpoint
+}
+
+// graphEndpoint is a container attached to a network.
+type graphEndpoint struct {
+	containerID string
+	name        string
+	ipv4        string
+	ipv6        string
+	aliases     []string
+	ports       []container.Port
+}
+
+// inspectNetworks inspects the networks, or all the networks if none is
+// specified, sorted by name. Networks are inspected, as the containers
+// attached to them are not listed otherwise.
+func inspectNetworks(ctx context.Context, apiClient client.NetworkAPIClient, names []string) ([]network.Inspect, error) {
+	if len(names) == 0 {
+		summaries, err := apiClient.NetworkList(ctx, network.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, nw := range summaries {
+			names = append(names, nw.ID)
+		}
+	}
+	networks := make([]network.Inspect, 0, len(names))
+	for _, name := range names {
+		nw, _, err := apiClient.NetworkInspectWithRaw(ctx, name, network.InspectOptions{})
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, nw)
+	}
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i].Name < networks[j].Name
+	})
+	return networks, nil
+}
+
+// collectGraph returns the containers attached to the networks, with their
+// aliases and published ports, sorted by name.
+func collectGraph(ctx context.Context, apiClient client.APIClient, networks []network.Inspect) ([]graphNetwork, error) {
+	graph := make([]graphNetwork, 0, len(networks))
+	for _, nw := range networks {
+		// The network only knows the addresses of its containers; the aliases
+		// and published ports are part of the containers.
+		containers, err := apiClient.ContainerList(ctx, container.ListOptions{
+			Filters: filters.NewArgs(filters.Arg("network", nw.ID)),
+		})
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[string]container.Summary, len(containers))
+		for _, c := range containers {
+			byID[c.ID] = c
+		}
+
+		gn := graphNetwork{network: nw}
+		for id, ep := range nw.Containers {
+			e := graphEndpoint{
+				containerID: id,
+				name:        ep.Name,
+				ipv4:        ep.IPv4Address,
+				ipv6:        ep.IPv6Address,
+			}
+			if c, ok := byID[id]; ok {
+				e.ports = publishedPorts(c.Ports)
+				if c.NetworkSettings != nil {
+					if es := c.NetworkSettings.Networks[nw.Name]; es != nil {
+						e.aliases = es.Aliases
+					}
+				}
+			}
+			if e.name == "" {
+				e.name = id
+			}
+			gn.endpoints = append(gn.endpoints, e)
+		}
+		sort.Slice(gn.endpoints, func(i, j int) bool {
+			return gn.endpoints[i].name < gn.endpoints[j].name
+		})
+		graph = append(graph, gn)
+	}
+	return graph, nil
+}
+
+// publishedPorts returns the ports that are published on the host, sorted by
+// host address and port.
+func publishedPorts(ports []container.Port) []container.Port {
+	var published []container.Port
+	for _, p := range ports {
+		if p.PublicPort != 0 {
+			published = append(published, p)
+		}
+	}
+	sort.Slice(published, func(i, j int) bool {
+		a, b := published[i], published[j]
+		if a.PublicPort != b.PublicPort {
+			return a.PublicPort < b.PublicPort
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.IP < b.IP
+	})
+	return published
+}
+
+// hostPort returns the host address and port of a published port.
+func hostPort(p container.Port) string {
+	return net.JoinHostPort(p.IP, strconv.Itoa(int(p.PublicPort)))
+}
+
+// formatPort formats a published port as in "docker ps".
+func formatPort(p container.Port) string {
+	return fmt.Sprintf("%s->%d/%s", hostPort(p), p.PrivatePort, p.Type)
+}
+
+func subnets(nw network.Inspect) string {
+	var s []string
+	for _, cfg := range nw.IPAM.Config {
+		if cfg.Subnet != "" {
+			s = append(s, cfg.Subnet)
+		}
+	}
+	return strings.Join(s, ", ")
+}
+
+func networkID(nw network.Inspect) string {
+	if nw.ID != "" {
+		return nw.ID
+	}
+	return nw.Name
+}
+
+// dotQuote returns s as a quoted Graphviz ID. Newlines are kept as line
+// breaks in labels.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// writeDot writes the networks as an undirected Graphviz graph. Networks,
+// containers, and published ports are nodes; a container attached to more
+// than one network is a single node, linked to each of its networks.
+func writeDot(out io.Writer, graph []graphNetwork) error {
+	var b strings.Builder
+	b.WriteString("graph networks {\n")
+	b.WriteString("\tnode [fontname=\"Helvetica\"];\n")
+
+	seen := map[string]bool{}
+	for _, gn := range graph {
+		nw := gn.network
+		label := nw.Name + "\n" + nw.Driver
+		if s := subnets(nw); s != "" {
+			label += " " + s
+		}
+		nwNode := dotQuote("network:" + networkID(nw))
+		fmt.Fprintf(&b, "\t%s [shape=box, style=rounded, label=%s];\n", nwNode, dotQuote(label))
+
+		for _, e := range gn.endpoints {
+			ctrNode := dotQuote("container:" + e.containerID)
+			if !seen[e.containerID] {
+				seen[e.containerID] = true
+				fmt.Fprintf(&b, "\t%s [shape=ellipse, label=%s];\n", ctrNode, dotQuote(e.name))
+				for _, p := range e.ports {
+					portNode := dotQuote("port:" + hostPort(p) + "/" + p.Type)
+					fmt.Fprintf(&b, "\t%s [shape=plaintext, label=%s];\n", portNode, dotQuote(hostPort(p)+"/"+p.Type))
+					fmt.Fprintf(&b, "\t%s -- %s [label=%s];\n", portNode, ctrNode, dotQuote(strconv.Itoa(int(p.PrivatePort))+"/"+p.Type))
+				}
+			}
+			var addrs []string
+			for _, a := range []string{e.ipv4, e.ipv6} {
+				if a != "" {
+					addrs = append(addrs, a)
+				}
+			}
+			fmt.Fprintf(&b, "\t%s -- %s [label=%s];\n", nwNode, ctrNode, dotQuote(strings.Join(addrs, "\n")))
+		}
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// writeASCII writes the networks as trees of their containers, with their
+// addresses and published ports, and their aliases if withAliases is set.
+func writeASCII(out io.Writer, graph []graphNetwork, withAliases bool) error {
+	var b strings.Builder
+	for i, gn := range graph {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		nw := gn.network
+		b.WriteString(nw.Name + " (" + nw.Driver)
+		if s := subnets(nw); s != "" {
+			b.WriteString(", " + s)
+		}
+		b.WriteString(")\n")
+
+		if len(gn.endpoints) == 0 {
+			b.WriteString("└─ (no containers)\n")
+			continue
+		}
+		for j, e := range gn.endpoints {
+			branch := "├─ "
+			if j == len(gn.endpoints)-1 {
+				branch = "└─ "
+			}
+			fields := []string{e.name}
+			for _, a := range []string{e.ipv4, e.ipv6} {
+				if a != "" {
+					fields = append(fields, a)
+				}
+			}
+			if withAliases && len(e.aliases) > 0 {
+				fields = append(fields, "aliases="+strings.Join(e.aliases, ","))
+			}
+			for _, p := range e.ports {
+				fields = append(fields, formatPort(p))
+			}
+			b.WriteString(branch + strings.Join(fields, " ") + "\n")
+		}
+	}
+
+	_, err := io.WriteString(out, b.String())
+	return err
+}
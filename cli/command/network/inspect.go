@@ -11,12 +11,18 @@
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/command/completion"
 	"github.com/docker/cli/cli/command/inspect"
-	flagsHelper "github.com/docker/cli/cli/flags"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
 	"github.com/spf13/cobra"
 )
 
+const inspectFormatHelp = `Format output using a custom template:
+'json':             Print in JSON format
+'dot':              Print the networks, their containers, and published ports as a Graphviz graph
+'ascii':            Print the networks and their containers as a tree
+'TEMPLATE':         Print output using the given Go template.
+Refer to https://docs.docker.com/go/formatting/ for more information about formatting output with templates`
+
 type inspectOptions struct {
 	format  string
 	names   []string
@@ -37,14 +43,37 @@ func newInspectCommand(dockerCLI command.Cli) *cobra.Command {
 		ValidArgsFunction: completion.NetworkNames(dockerCLI),
 	}
 
-	cmd.Flags().StringVarP(&opts.format, "format", "f", "", flagsHelper.InspectFormatHelp)
+	cmd.Flags().StringVarP(&opts.format, "format", "f", "", inspectFormatHelp)
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", false, "Verbose output for diagnostics")
 
 	return cmd
 }
 
-func runInspect(ctx context.Context, apiClient client.NetworkAPIClient, output io.Writer, opts inspectOptions) error {
+func runInspect(ctx context.Context, apiClient client.APIClient, output io.Writer, opts inspectOptions) error {
+	switch opts.format {
+	case graphFormatDot, graphFormatASCII:
+		return runInspectGraph(ctx, apiClient, output, opts)
+	}
 	return inspect.Inspect(output, opts.names, opts.format, func(name string) (any, []byte, error) {
 		return apiClient.NetworkInspectWithRaw(ctx, name, network.InspectOptions{Verbose: opts.verbose})
 	})
 }
+
+func runInspectGraph(ctx context.Context, apiClient client.APIClient, output io.Writer, opts inspectOptions) error {
+	networks := make([]network.Inspect, 0, len(opts.names))
+	for _, name := range opts.names {
+		nw, _, err := apiClient.NetworkInspectWithRaw(ctx, name, network.InspectOptions{Verbose: opts.verbose})
+		if err != nil {
+			return err
+		}
+		networks = append(networks, nw)
+	}
+	graph, err := collectGraph(ctx, apiClient, networks)
+	if err != nil {
+		return err
+	}
+	if opts.format == graphFormatDot {
+		return writeDot(output, graph)
+	}
+	return writeASCII(output, graph, false)
+}
@@ -0,0 +1,122 @@
+package network
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/pkg/errors"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/golden"
+)
+
+func graphClient(t *testing.T) *fakeClient {
+	t.Helper()
+	networks := map[string]network.Inspect{
+		"f1e2d3c4b5a6": {
+			Name:   "frontend",
+			ID:     "f1e2d3c4b5a6",
+			Driver: "bridge",
+			IPAM:   network.IPAM{Config: []network.IPAMConfig{{Subnet: "172.18.0.0/16"}, {Subnet: "fd00:18::/64"}}},
+			Containers: map[string]network.EndpointResource{
+				"c0ffee": {Name: "web", IPv4Address: "172.18.0.2/16", IPv6Address: "fd00:18::2/64"},
+				"beef01": {Name: "api", IPv4Address: "172.18.0.3/16"},
+			},
+		},
+		"0a1b2c3d4e5f": {
+			Name:   "backend",
+			ID:     "0a1b2c3d4e5f",
+			Driver: "bridge",
+			IPAM:   network.IPAM{Config: []network.IPAMConfig{{Subnet: "172.19.0.0/16"}}},
+			Containers: map[string]network.EndpointResource{
+				"beef01": {Name: "api", IPv4Address: "172.19.0.2/16"},
+				"d00d02": {Name: "db", IPv4Address: "172.19.0.3/16"},
+			},
+		},
+		"9e8d7c6b5a4f": {
+			Name:   "empty",
+			ID:     "9e8d7c6b5a4f",
+			Driver: "macvlan",
+		},
+	}
+	containers := map[string]container.Summary{
+		"c0ffee": {
+			ID: "c0ffee",
+			Ports: []container.Port{
+				{IP: "::", PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+				{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+				{PrivatePort: 443, Type: "tcp"},
+			},
+			NetworkSettings: &container.NetworkSettingsSummary{Networks: map[string]*network.EndpointSettings{
+				"frontend": {Aliases: []string{"www", "web.local"}},
+			}},
+		},
+		"beef01": {
+			ID: "beef01",
+			Ports: []container.Port{
+				{IP: "127.0.0.1", PrivatePort: 3000, PublicPort: 3000, Type: "tcp"},
+			},
+		},
+		"d00d02": {ID: "d00d02"},
+	}
+	return &fakeClient{
+		networkListFunc: func(context.Context, network.ListOptions) ([]network.Summary, error) {
+			summaries := make([]network.Summary, 0, len(networks))
+			for _, nw := range networks {
+				summaries = append(summaries, network.Summary{ID: nw.ID, Name: nw.Name})
+			}
+			return summaries, nil
+		},
+		networkInspectFunc: func(_ context.Context, networkID string, _ network.InspectOptions) (network.Inspect, []byte, error) {
+			if nw, ok := networks[networkID]; ok {
+				return nw, nil, nil
+			}
+			for _, nw := range networks {
+				if nw.Name == networkID {
+					return nw, nil, nil
+				}
+			}
+			return network.Inspect{}, nil, errors.Errorf("network %s not found", networkID)
+		},
+		containerListFunc: func(_ context.Context, options container.ListOptions) ([]container.Summary, error) {
+			var result []container.Summary
+			for _, nw := range networks {
+				if !options.Filters.ExactMatch("network", nw.ID) {
+					continue
+				}
+				for id := range nw.Containers {
+					result = append(result, containers[id])
+				}
+			}
+			return result, nil
+		},
+	}
+}
+
+func TestNetworkInspectGraph(t *testing.T) {
+	for _, format := range []string{"dot", "ascii"} {
+		format := format
+		t.Run(format, func(t *testing.T) {
+			cli := test.NewFakeCli(graphClient(t))
+			cmd := newInspectCommand(cli)
+			cmd.SetOut(io.Discard)
+			cmd.SetArgs([]string{"--format", format, "frontend", "backend", "empty"})
+			assert.NilError(t, cmd.Execute())
+			golden.Assert(t, cli.OutBuffer().String(), "network-inspect-graph."+format+".golden")
+		})
+	}
+}
+
+func TestNetworkInspectGraphErrors(t *testing.T) {
+	cli := test.NewFakeCli(graphClient(t))
+	cmd := newInspectCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--format", "dot", "frontend", "missing"})
+	assert.ErrorContains(t, cmd.Execute(), "network missing not found")
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), ""))
+}
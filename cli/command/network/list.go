@@ -3,6 +3,8 @@
 import (
 	"context"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
@@ -10,7 +12,9 @@
 	"github.com/docker/cli/cli/command/formatter"
 	flagsHelper "github.com/docker/cli/cli/flags"
 	"github.com/docker/cli/opts"
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
 	"github.com/fvbommel/sortorder"
 	"github.com/spf13/cobra"
 )
@@ -61,14 +65,65 @@ func runList(ctx context.Context, dockerCli command.Cli, options listOptions) er
 		}
 	}
 
+	networkFormat := NewFormat(format, options.quiet)
+	if needsContainers(networkFormat) {
+		if err := addContainers(ctx, client, networkResources); err != nil {
+			return err
+		}
+	}
+
 	sort.Slice(networkResources, func(i, j int) bool {
 		return sortorder.NaturalLess(networkResources[i].Name, networkResources[j].Name)
 	})
 
 	networksCtx := formatter.Context{
 		Output: dockerCli.Out(),
-		Format: NewFormat(format, options.quiet),
+		Format: networkFormat,
 		Trunc:  !options.noTrunc,
 	}
 	return FormatWrite(networksCtx, networkResources)
 }
+
+// addContainers sets the containers attached to the networks, which are only
+// returned when inspecting a network, from a single list of the running
+// containers.
+func addContainers(ctx context.Context, apiClient client.APIClient, networks []network.Summary) error {
+	containers, err := apiClient.ContainerList(ctx, container.ListOptions{})
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]map[string]network.EndpointResource, len(networks))
+	for _, c := range containers {
+		if c.NetworkSettings == nil {
+			continue
+		}
+		var name string
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		for _, es := range c.NetworkSettings.Networks {
+			if es == nil || es.NetworkID == "" {
+				continue
+			}
+			if byID[es.NetworkID] == nil {
+				byID[es.NetworkID] = map[string]network.EndpointResource{}
+			}
+			ep := network.EndpointResource{
+				Name:       name,
+				EndpointID: es.EndpointID,
+				MacAddress: es.MacAddress,
+			}
+			if es.IPAddress != "" {
+				ep.IPv4Address = es.IPAddress + "/" + strconv.Itoa(es.IPPrefixLen)
+			}
+			if es.GlobalIPv6Address != "" {
+				ep.IPv6Address = es.GlobalIPv6Address + "/" + strconv.Itoa(es.GlobalIPv6PrefixLen)
+			}
+			byID[es.NetworkID][c.ID] = ep
+		}
+	}
+	for i := range networks {
+		networks[i].Containers = byID[networks[i].ID]
+	}
+	return nil
+}
@@ -7,6 +7,7 @@
 
 	"github.com/docker/cli/internal/test"
 	"github.com/docker/cli/internal/test/builders"
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/network"
 	"github.com/google/go-cmp/cmp"
@@ -95,3 +96,45 @@ func TestNetworkList(t *testing.T) {
 		})
 	}
 }
+
+func TestNetworkListContainers(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		networkListFunc: func(context.Context, network.ListOptions) ([]network.Summary, error) {
+			return []network.Summary{
+				{ID: "net1", Name: "frontend", IPAM: network.IPAM{Config: []network.IPAMConfig{{Subnet: "172.18.0.0/16", Gateway: "172.18.0.1"}}}},
+				{ID: "net2", Name: "backend", IPAM: network.IPAM{Config: []network.IPAMConfig{{Subnet: "172.19.0.0/16", Gateway: "172.19.0.1"}, {Subnet: "fd00:19::/64"}}}},
+				{ID: "net3", Name: "none"},
+			}, nil
+		},
+		containerListFunc: func(context.Context, container.ListOptions) ([]container.Summary, error) {
+			return []container.Summary{
+				{ID: "c1", Names: []string{"/web"}, NetworkSettings: &container.NetworkSettingsSummary{Networks: map[string]*network.EndpointSettings{
+					"frontend": {NetworkID: "net1", IPAddress: "172.18.0.2", IPPrefixLen: 16},
+				}}},
+				{ID: "c2", Names: []string{"/api"}, NetworkSettings: &container.NetworkSettingsSummary{Networks: map[string]*network.EndpointSettings{
+					"frontend": {NetworkID: "net1", IPAddress: "172.18.0.3", IPPrefixLen: 16},
+					"backend":  {NetworkID: "net2", IPAddress: "172.19.0.2", IPPrefixLen: 16},
+				}}},
+			}, nil
+		},
+	})
+	cmd := newListCommand(cli)
+	cmd.SetArgs([]string{"--format", "table {{.Name}}\t{{.Containers}}\t{{.Subnet}}\t{{.Gateway}}"})
+	assert.NilError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "network-list-containers.golden")
+}
+
+func TestNetworkListWithoutContainers(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		networkListFunc: func(context.Context, network.ListOptions) ([]network.Summary, error) {
+			return []network.Summary{{ID: "net1", Name: "frontend"}}, nil
+		},
+		containerListFunc: func(context.Context, container.ListOptions) ([]container.Summary, error) {
+			t.Error("unexpected container list")
+			return nil, nil
+		},
+	})
+	cmd := newListCommand(cli)
+	cmd.SetArgs([]string{"--format", "{{.Name}} {{.Subnet}}"})
+	assert.NilError(t, cmd.Execute())
+}
@@ -0,0 +1,155 @@
+package network
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/system"
+)
+
+// defaultAddressPools are the address pools of local networks of daemons
+// that don't report their pools.
+var defaultAddressPools = []system.NetworkAddressPool{
+	{Base: "172.17.0.0/16", Size: 16},
+	{Base: "172.18.0.0/16", Size: 16},
+	{Base: "172.19.0.0/16", Size: 16},
+	{Base: "172.20.0.0/14", Size: 16},
+	{Base: "172.24.0.0/14", Size: 16},
+	{Base: "172.28.0.0/14", Size: 16},
+	{Base: "192.168.0.0/16", Size: 20},
+}
+
+// usedSubnet is a subnet that is used by a network, or by a route of the
+// host.
+type usedSubnet struct {
+	subnet    *net.IPNet
+	owner     string
+	networkID string
+}
+
+// usedSubnets returns the subnets of the existing networks and, if the daemon
+// runs on this host, the routes of the host.
+func usedSubnets(ctx context.Context, dockerCLI command.Cli) ([]usedSubnet, error) {
+	networks, err := dockerCLI.Client().NetworkList(ctx, network.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var used []usedSubnet
+	known := map[string]bool{}
+	for _, nw := range networks {
+		for _, cfg := range nw.IPAM.Config {
+			_, subnet, err := net.ParseCIDR(cfg.Subnet)
+			if err != nil {
+				continue
+			}
+			known[subnet.String()] = true
+			used = append(used, usedSubnet{subnet: subnet, owner: "network " + nw.Name, networkID: nw.ID})
+		}
+	}
+
+	// The routes of the host are only those of the daemon's host if the daemon
+	// is reached through a local socket.
+	if !strings.HasPrefix(dockerCLI.DockerEndpoint().Host, "unix://") {
+		return used, nil
+	}
+	routes, err := hostRoutes()
+	if err != nil {
+		return used, nil //nolint:nilerr // routes are only checked on a best-effort basis
+	}
+	for _, r := range routes {
+		// The default route overlaps with every subnet, and the routes of the
+		// networks' bridges are already listed with their networks.
+		if ones, _ := r.subnet.Mask.Size(); ones == 0 || known[r.subnet.String()] {
+			continue
+		}
+		used = append(used, r)
+	}
+	return used, nil
+}
+
+// overlappingSubnets returns the used subnets that overlap with the subnet.
+func overlappingSubnets(subnet *net.IPNet, used []usedSubnet) []usedSubnet {
+	var overlaps []usedSubnet
+	for _, u := range used {
+		if subnet.Contains(u.subnet.IP) || u.subnet.Contains(subnet.IP) {
+			overlaps = append(overlaps, u)
+		}
+	}
+	return overlaps
+}
+
+// predictSubnet returns the first subnet of the address pools that doesn't
+// overlap with a used subnet, which is the subnet the daemon allocates to a
+// new local network, unless another network is created in the meantime.
+func predictSubnet(pools []system.NetworkAddressPool, used []usedSubnet) *net.IPNet {
+	if len(pools) == 0 {
+		pools = defaultAddressPools
+	}
+	for _, pool := range pools {
+		_, base, err := net.ParseCIDR(pool.Base)
+		if err != nil || base.IP.To4() == nil {
+			continue
+		}
+		ones, bits := base.Mask.Size()
+		if pool.Size < ones || pool.Size > bits {
+			continue
+		}
+		subnet := &net.IPNet{IP: base.IP.To4(), Mask: net.CIDRMask(pool.Size, bits)}
+		for base.Contains(subnet.IP) {
+			if len(overlappingSubnets(subnet, used)) == 0 {
+				return subnet
+			}
+			subnet = &net.IPNet{IP: nextSubnet(subnet), Mask: subnet.Mask}
+		}
+	}
+	return nil
+}
+
+// nextSubnet returns the first address of the subnet that follows the given
+// IPv4 subnet, which wraps around to 0.0.0.0 after the last subnet.
+func nextSubnet(subnet *net.IPNet) net.IP {
+	ones, bits := subnet.Mask.Size()
+	ip := subnet.IP.To4()
+	n := uint64(ip[0])<<24 | uint64(ip[1])<<16 | uint64(ip[2])<<8 | uint64(ip[3])
+	n = (n + 1<<uint(bits-ones)) & 0xffffffff
+	return net.IPv4(byte(n>>24), byte(n>>16), byte(n>>8), byte(n)).To4()
+}
+
+// defaultGateway returns the address the daemon assigns to the gateway of a
+// subnet if none is specified, which is the first address of the IP range, or
+// of the subnet.
+func defaultGateway(cfg network.IPAMConfig) string {
+	r := cfg.IPRange
+	if r == "" {
+		r = cfg.Subnet
+	}
+	_, ipNet, err := net.ParseCIDR(r)
+	if err != nil {
+		return ""
+	}
+	ip := make(net.IP, len(ipNet.IP))
+	copy(ip, ipNet.IP)
+	for i := len(ip) - 1; i >= 0; i-- {
+		ip[i]++
+		if ip[i] != 0 {
+			break
+		}
+	}
+	return ip.String()
+}
+
+// sortIPAMConfig sorts the IPAM configuration in the order of the subnets.
+func sortIPAMConfig(configs []network.IPAMConfig, subnets []string) {
+	order := make(map[string]int, len(subnets))
+	for i, s := range subnets {
+		order[s] = i
+	}
+	sort.SliceStable(configs, func(i, j int) bool {
+		return order[configs[i].Subnet] < order[configs[j].Subnet]
+	})
+}
@@ -0,0 +1,98 @@
+package network
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// hostRoutes returns the IPv4 and IPv6 routes of the host.
+func hostRoutes() ([]usedSubnet, error) {
+	f, err := os.Open("/proc/net/route")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	routes, err := parseIPv4Routes(f)
+	if err != nil {
+		return nil, err
+	}
+
+	f6, err := os.Open("/proc/net/ipv6_route")
+	if err != nil {
+		// IPv6 may be disabled.
+		return routes, nil //nolint:nilerr // IPv6 routes are optional
+	}
+	defer f6.Close()
+	routes6, err := parseIPv6Routes(f6)
+	if err != nil {
+		return nil, err
+	}
+	return append(routes, routes6...), nil
+}
+
+// routeFlagUp is the RTF_UP flag of the routes that are in use.
+const routeFlagUp = 0x1
+
+// parseIPv4Routes parses the format of /proc/net/route, in which addresses
+// are in the byte order of the host.
+func parseIPv4Routes(r io.Reader) ([]usedSubnet, error) {
+	var routes []usedSubnet
+	scanner := bufio.NewScanner(r)
+	for first := true; scanner.Scan(); first = false {
+		fields := strings.Fields(scanner.Text())
+		if first || len(fields) < 8 {
+			continue
+		}
+		iface := fields[0]
+		dst, err1 := strconv.ParseUint(fields[1], 16, 32)
+		flags, err2 := strconv.ParseUint(fields[3], 16, 32)
+		mask, err3 := strconv.ParseUint(fields[7], 16, 32)
+		if err1 != nil || err2 != nil || err3 != nil || flags&routeFlagUp == 0 || iface == "lo" {
+			continue
+		}
+		ip, m := make(net.IP, net.IPv4len), make(net.IPMask, net.IPv4len)
+		binary.NativeEndian.PutUint32(ip, uint32(dst))
+		binary.NativeEndian.PutUint32(m, uint32(mask))
+		routes = append(routes, usedSubnet{
+			subnet: &net.IPNet{IP: ip.Mask(m), Mask: m},
+			owner:  "the host route on interface " + iface,
+		})
+	}
+	return routes, scanner.Err()
+}
+
+// parseIPv6Routes parses the format of /proc/net/ipv6_route. The routes to a
+// single address, such as the addresses of the host, are ignored.
+func parseIPv6Routes(r io.Reader) ([]usedSubnet, error) {
+	var routes []usedSubnet
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+		iface := fields[9]
+		dst, err1 := hex.DecodeString(fields[0])
+		prefix, err2 := strconv.ParseUint(fields[1], 16, 8)
+		flags, err3 := strconv.ParseUint(fields[8], 16, 32)
+		if err1 != nil || err2 != nil || err3 != nil || len(dst) != net.IPv6len || prefix >= 8*net.IPv6len || flags&routeFlagUp == 0 || iface == "lo" {
+			continue
+		}
+		ip := net.IP(dst)
+		if ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+			continue
+		}
+		m := net.CIDRMask(int(prefix), 8*net.IPv6len)
+		routes = append(routes, usedSubnet{
+			subnet: &net.IPNet{IP: ip.Mask(m), Mask: m},
+			owner:  "the host route on interface " + iface,
+		})
+	}
+	return routes, scanner.Err()
+}
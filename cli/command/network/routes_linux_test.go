@@ -0,0 +1,51 @@
+package network
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestParseIPv4Routes(t *testing.T) {
+	// Addresses are in the byte order of the host.
+	hostOrder := func(ip string) string {
+		return fmt.Sprintf("%08X", binary.NativeEndian.Uint32(net.ParseIP(ip).To4()))
+	}
+	routes := "Iface\tDestination\tGateway \tFlags\tRefCnt\tUse\tMetric\tMask\t\tMTU\tWindow\tIRTT\n" +
+		"eth0\t" + hostOrder("0.0.0.0") + "\t" + hostOrder("192.168.1.1") + "\t0003\t0\t0\t100\t" + hostOrder("0.0.0.0") + "\t0\t0\t0\n" +
+		"eth0\t" + hostOrder("192.168.1.0") + "\t00000000\t0001\t0\t0\t100\t" + hostOrder("255.255.255.0") + "\t0\t0\t0\n" +
+		"wg0\t" + hostOrder("10.0.0.0") + "\t00000000\t0001\t0\t0\t0\t" + hostOrder("255.0.0.0") + "\t0\t0\t0\n" +
+		"wg1\t" + hostOrder("10.8.0.0") + "\t00000000\t0000\t0\t0\t0\t" + hostOrder("255.255.0.0") + "\t0\t0\t0\n"
+
+	parsed, err := parseIPv4Routes(strings.NewReader(routes))
+	assert.NilError(t, err)
+	var actual []string
+	for _, r := range parsed {
+		actual = append(actual, r.subnet.String()+" "+r.owner)
+	}
+	assert.Check(t, is.DeepEqual(actual, []string{
+		"0.0.0.0/0 the host route on interface eth0",
+		"192.168.1.0/24 the host route on interface eth0",
+		"10.0.0.0/8 the host route on interface wg0",
+	}))
+}
+
+func TestParseIPv6Routes(t *testing.T) {
+	routes := "fd000000000000000000000000000000 40 00000000000000000000000000000000 00 00000000000000000000000000000000 00000100 00000001 00000000 00000001 eth0\n" +
+		"fe800000000000000000000000000000 40 00000000000000000000000000000000 00 00000000000000000000000000000000 00000100 00000001 00000000 00000001 eth0\n" +
+		"fd000000000000000000000000000002 80 00000000000000000000000000000000 00 00000000000000000000000000000000 00000000 00000002 00000000 80200001 eth0\n" +
+		"00000000000000000000000000000001 80 00000000000000000000000000000000 00 00000000000000000000000000000000 00000000 00000002 00000000 80200001 lo\n"
+
+	parsed, err := parseIPv6Routes(strings.NewReader(routes))
+	assert.NilError(t, err)
+	var actual []string
+	for _, r := range parsed {
+		actual = append(actual, r.subnet.String()+" "+r.owner)
+	}
+	assert.Check(t, is.DeepEqual(actual, []string{"fd00::/64 the host route on interface eth0"}))
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package network
+
+// hostRoutes returns the routes of the host, which are only read on Linux.
+func hostRoutes() ([]usedSubnet, error) {
+	return nil, nil
+}
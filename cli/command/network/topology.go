@@ -0,0 +1,145 @@
+package network
+
+import (
+	"context"
+	"strings"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/completion"
+	"github.com/docker/cli/cli/command/formatter"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultTopologyTableFormat = "table {{.Network}}\t{{.Container}}\t{{.IPv4}}\t{{.IPv6}}\t{{.Aliases}}\t{{.Ports}}"
+	topologyTreeFormat         = "tree"
+
+	topologyNetworkHeader   = "NETWORK"
+	topologyContainerHeader = "CONTAINER"
+	topologyIPv4Header      = "IPV4 ADDRESS"
+	topologyIPv6Header      = "IPV6 ADDRESS"
+	topologyAliasesHeader   = "ALIASES"
+)
+
+const topologyFormatHelp = `Format output using a custom template:
+'table':            Print the containers of the networks in table format with column headers (default)
+'table TEMPLATE':   Print the containers of the networks in table format using the given Go template
+'tree':             Print the networks as trees of their containers
+'json':             Print in JSON format
+'TEMPLATE':         Print output using the given Go template.
+Refer to https://docs.docker.com/go/formatting/ for more information about formatting output with templates`
+
+type topologyOptions struct {
+	networks []string
+	format   string
+}
+
+func newTopologyCommand(dockerCLI command.Cli) *cobra.Command {
+	var opts topologyOptions
+
+	cmd := &cobra.Command{
+		Use:   "topology [OPTIONS] [NETWORK...]",
+		Short: "Show the containers attached to networks, with their addresses and published ports",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.networks = args
+			return runTopology(cmd.Context(), dockerCLI, opts)
+		},
+		ValidArgsFunction: completion.NetworkNames(dockerCLI),
+	}
+
+	cmd.Flags().StringVar(&opts.format, "format", "", topologyFormatHelp)
+
+	return cmd
+}
+
+func runTopology(ctx context.Context, dockerCLI command.Cli, opts topologyOptions) error {
+	apiClient := dockerCLI.Client()
+	networks, err := inspectNetworks(ctx, apiClient, opts.networks)
+	if err != nil {
+		return err
+	}
+	graph, err := collectGraph(ctx, apiClient, networks)
+	if err != nil {
+		return err
+	}
+
+	format := opts.format
+	switch format {
+	case topologyTreeFormat:
+		return writeASCII(dockerCLI.Out(), graph, true)
+	case "", formatter.TableFormatKey:
+		format = defaultTopologyTableFormat
+	}
+	topologyCtx := formatter.Context{
+		Output: dockerCLI.Out(),
+		Format: formatter.Format(format),
+	}
+	return topologyWrite(topologyCtx, graph)
+}
+
+// topologyWrite writes a row for each container attached to each network.
+func topologyWrite(ctx formatter.Context, graph []graphNetwork) error {
+	render := func(format func(subContext formatter.SubContext) error) error {
+		for _, gn := range graph {
+			for _, e := range gn.endpoints {
+				if err := format(&topologyContext{network: gn.network.Name, e: e}); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	topologyCtx := &topologyContext{}
+	topologyCtx.Header = formatter.SubHeaderContext{
+		"Network":   topologyNetworkHeader,
+		"Container": topologyContainerHeader,
+		"IPv4":      topologyIPv4Header,
+		"IPv6":      topologyIPv6Header,
+		"Aliases":   topologyAliasesHeader,
+		"Ports":     formatter.PortsHeader,
+	}
+	return ctx.Write(topologyCtx, render)
+}
+
+// topologyContext is the context of a container attached to a network.
+type topologyContext struct {
+	formatter.HeaderContext
+	network string
+	e       graphEndpoint
+}
+
+func (c *topologyContext) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(c)
+}
+
+func (c *topologyContext) Network() string {
+	return c.network
+}
+
+func (c *topologyContext) Container() string {
+	return c.e.name
+}
+
+func (c *topologyContext) IPv4() string {
+	return c.e.ipv4
+}
+
+func (c *topologyContext) IPv6() string {
+	return c.e.ipv6
+}
+
+// Aliases returns the network-scoped aliases of the container, separated by
+// commas.
+func (c *topologyContext) Aliases() string {
+	return strings.Join(c.e.aliases, ",")
+}
+
+// Ports returns the ports that the container publishes on the host,
+// separated by commas.
+func (c *topologyContext) Ports() string {
+	ports := make([]string, 0, len(c.e.ports))
+	for _, p := range c.e.ports {
+		ports = append(ports, formatPort(p))
+	}
+	return strings.Join(ports, ", ")
+}
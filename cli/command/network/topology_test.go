@@ -0,0 +1,57 @@
+package network
+
+import (
+	"io"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+)
+
+func TestNetworkTopology(t *testing.T) {
+	testCases := []struct {
+		name string
+		args []string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name: "networks",
+			args: []string{"frontend", "empty"},
+		},
+		{
+			name: "tree",
+			args: []string{"--format", "tree"},
+		},
+		{
+			name: "template",
+			args: []string{"--format", "{{.Network}}/{{.Container}}: {{.Aliases}}", "frontend"},
+		},
+		{
+			name: "json",
+			args: []string{"--format", "json", "backend"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cli := test.NewFakeCli(graphClient(t))
+			cmd := newTopologyCommand(cli)
+			cmd.SetOut(io.Discard)
+			cmd.SetArgs(append([]string{}, tc.args...))
+			assert.NilError(t, cmd.Execute())
+			golden.Assert(t, cli.OutBuffer().String(), "network-topology-"+tc.name+".golden")
+		})
+	}
+}
+
+func TestNetworkTopologyNotFound(t *testing.T) {
+	cli := test.NewFakeCli(graphClient(t))
+	cmd := newTopologyCommand(cli)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"missing"})
+	assert.ErrorContains(t, cmd.Execute(), "network missing not found")
+}
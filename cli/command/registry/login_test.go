@@ -31,12 +31,20 @@
 
 type fakeClient struct {
 	client.Client
+	imageSearchFunc func(term string, options registrytypes.SearchOptions) ([]registrytypes.SearchResult, error)
 }
 
 func (c *fakeClient) Info(context.Context) (system.Info, error) {
 	return system.Info{}, nil
 }
 
+func (c *fakeClient) ImageSearch(_ context.Context, term string, options registrytypes.SearchOptions) ([]registrytypes.SearchResult, error) {
+	if c.imageSearchFunc != nil {
+		return c.imageSearchFunc(term, options)
+	}
+	return nil, nil
+}
+
 func (c *fakeClient) RegistryLogin(_ context.Context, auth registrytypes.AuthConfig) (registrytypes.AuthenticateOKBody, error) {
 	if auth.Password == expiredPassword {
 		return registrytypes.AuthenticateOKBody{}, errors.New("Invalid Username or Password")
@@ -3,13 +3,17 @@
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/completion"
 	"github.com/docker/cli/cli/command/formatter"
+	flagsHelper "github.com/docker/cli/cli/flags"
 	"github.com/docker/cli/opts"
 	registrytypes "github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/registry"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -18,9 +22,25 @@ type searchOptions struct {
 	term    string
 	noTrunc bool
 	limit   int
+	sort    string
 	filter  opts.FilterOpt
 }
 
+// maxSearchLimit is the maximum number of results returned by a search, as
+// the search API doesn't support paging.
+const maxSearchLimit = 100
+
+// searchSortKeys are the keys the results of a search can be sorted by, and
+// the functions comparing two results by that key.
+var searchSortKeys = map[string]func(a, b registrytypes.SearchResult) bool{
+	"stars": func(a, b registrytypes.SearchResult) bool {
+		return a.StarCount > b.StarCount
+	},
+	"name": func(a, b registrytypes.SearchResult) bool {
+		return a.Name < b.Name
+	},
+}
+
 // NewSearchCommand creates a new `docker search` command
 func NewSearchCommand(dockerCli command.Cli) *cobra.Command {
 	options := searchOptions{filter: opts.NewFilterOpt()}
@@ -42,13 +62,26 @@ func NewSearchCommand(dockerCli command.Cli) *cobra.Command {
 
 	flags.BoolVar(&options.noTrunc, "no-trunc", false, "Don't truncate output")
 	flags.VarP(&options.filter, "filter", "f", "Filter output based on conditions provided")
-	flags.IntVar(&options.limit, "limit", 0, "Max number of search results")
-	flags.StringVar(&options.format, "format", "", "Pretty-print search using a Go template")
+	flags.IntVar(&options.limit, "limit", 0, "Max number of search results (up to 100)")
+	flags.StringVar(&options.sort, "sort", "", "Sort the results by stars or name, instead of by relevance")
+	flags.StringVar(&options.format, "format", "", flagsHelper.FormatHelp)
+
+	_ = cmd.RegisterFlagCompletionFunc("sort", completion.FromList("stars", "name"))
 
 	return cmd
 }
 
 func runSearch(ctx context.Context, dockerCli command.Cli, options searchOptions) error {
+	if options.limit < 0 || options.limit > maxSearchLimit {
+		return errors.Errorf("invalid limit %d: must be between 1 and %d, or 0 for the default of the daemon, as search results can't be paged", options.limit, maxSearchLimit)
+	}
+	var less func(a, b registrytypes.SearchResult) bool
+	if options.sort != "" {
+		var ok bool
+		if less, ok = searchSortKeys[options.sort]; !ok {
+			return errors.Errorf("invalid sort key: %s (must be stars or name)", options.sort)
+		}
+	}
 	if options.filter.Value().Contains("is-automated") {
 		_, _ = fmt.Fprintln(dockerCli.Err(), `WARNING: the "is-automated" filter is deprecated, and searching for "is-automated=true" will not yield any results in future.`)
 	}
@@ -73,11 +106,17 @@ func runSearch(ctx context.Context, dockerCli command.Cli, options searchOptions
 	if err != nil {
 		return err
 	}
+	if less != nil {
+		sort.SliceStable(results, func(i, j int) bool {
+			return less(results[i], results[j])
+		})
+	}
 
 	searchCtx := formatter.Context{
 		Output: dockerCli.Out(),
 		Format: NewSearchFormat(options.format),
-		Trunc:  !options.noTrunc,
+		// Descriptions are never truncated in the JSON output.
+		Trunc: !options.noTrunc && !formatter.Format(options.format).IsJSON(),
 	}
 	return SearchWrite(searchCtx, results)
 }
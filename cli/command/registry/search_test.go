@@ -0,0 +1,88 @@
+package registry
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	registrytypes "github.com/docker/docker/api/types/registry"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/golden"
+)
+
+func TestSearchCommand(t *testing.T) {
+	results := []registrytypes.SearchResult{
+		{Name: "ubuntu", Description: "Ubuntu is a Debian-based Linux operating system based on free software.", StarCount: 17000, IsOfficial: true},
+		{Name: "bitnami/nginx", Description: "Bitnami container image for NGINX", StarCount: 190},
+		{Name: "nginx", Description: "Official build of Nginx.", StarCount: 20000, IsOfficial: true},
+	}
+	testCases := []struct {
+		name string
+		args []string
+	}{
+		{
+			name: "default",
+			args: []string{"nginx"},
+		},
+		{
+			name: "sort-stars",
+			args: []string{"--sort", "stars", "nginx"},
+		},
+		{
+			name: "sort-name-json",
+			args: []string{"--sort", "name", "--format", "json", "nginx"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cli := test.NewFakeCli(&fakeClient{
+				imageSearchFunc: func(term string, options registrytypes.SearchOptions) ([]registrytypes.SearchResult, error) {
+					assert.Check(t, is.Equal(term, "nginx"))
+					return append([]registrytypes.SearchResult{}, results...), nil
+				},
+			})
+			cmd := NewSearchCommand(cli)
+			cmd.SetOut(io.Discard)
+			cmd.SetArgs(tc.args)
+			assert.NilError(t, cmd.Execute())
+			golden.Assert(t, cli.OutBuffer().String(), fmt.Sprintf("search-command.%s.golden", tc.name))
+		})
+	}
+}
+
+func TestSearchCommandErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		args          []string
+		expectedError string
+	}{
+		{
+			name:          "limit-too-high",
+			args:          []string{"--limit", "150", "nginx"},
+			expectedError: "invalid limit 150: must be between 1 and 100, or 0 for the default of the daemon, as search results can't be paged",
+		},
+		{
+			name:          "negative-limit",
+			args:          []string{"--limit", "-1", "nginx"},
+			expectedError: "invalid limit -1: must be between 1 and 100, or 0 for the default of the daemon, as search results can't be paged",
+		},
+		{
+			name:          "invalid-sort",
+			args:          []string{"--sort", "updated", "nginx"},
+			expectedError: "invalid sort key: updated (must be stars or name)",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := NewSearchCommand(test.NewFakeCli(&fakeClient{}))
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(tc.args)
+			assert.Error(t, cmd.Execute(), tc.expectedError)
+		})
+	}
+}
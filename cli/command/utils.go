@@ -8,16 +8,20 @@
 	"context"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/docker/cli/cli/streams"
 	"github.com/docker/docker/api/types/filters"
 	mounttypes "github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/moby/sys/sequential"
 	"github.com/moby/term"
 	"github.com/pkg/errors"
@@ -228,6 +232,24 @@ func ValidateOutputPath(path string) error {
 	return nil
 }
 
+// Types of progress output of the commands that have a "--progress" option
+// to print their progress as JSON.
+const (
+	ProgressAuto = "auto"
+	ProgressJSON = "json"
+)
+
+// ValidateProgressOpt validates the type of progress output set with the
+// "--progress" option. An empty type is the same as [ProgressAuto].
+func ValidateProgressOpt(progress string) error {
+	switch progress {
+	case "", ProgressAuto, ProgressJSON:
+		return nil
+	default:
+		return errors.Errorf("invalid progress type %q: must be %q or %q", progress, ProgressAuto, ProgressJSON)
+	}
+}
+
 // ValidateOutputPathFileMode validates the output paths of the `cp` command and serves as a
 // helper to `ValidateOutputPath`
 func ValidateOutputPathFileMode(fileMode os.FileMode) error {
@@ -284,3 +306,55 @@ func ValidateMountWithAPIVersion(m mounttypes.Mount, serverAPIVersion string) er
 	}
 	return nil
 }
+
+// transientErrorMessages are the messages of transient errors. Errors of the
+// registry are only returned by the daemon as text, for example in the
+// progress stream of a pull.
+var transientErrorMessages = []string{
+	"connection reset by peer",
+	"i/o timeout",
+	"tls handshake timeout",
+	"unexpected eof",
+	"502 bad gateway",
+	"503 service unavailable",
+	"504 gateway timeout",
+}
+
+// PrintRetry reports to out that operation failed with err, and that it is
+// retried after delay. attempt is the number of the failed attempt, and
+// retries the maximum number of retries.
+func PrintRetry(out io.Writer, operation string, err error, delay time.Duration, attempt, retries int) {
+	_, _ = fmt.Fprintf(out, "%s failed: %v\nRetrying in %s (%d of %d)\n", operation, err, delay, attempt, retries)
+}
+
+// IsTransientError returns whether an operation that failed with err may
+// succeed if it's retried: the connection to the daemon or to the registry
+// timed out or was reset, or the daemon or the registry is temporarily
+// unavailable. Any other error, including errors that are not known, is not
+// considered to be transient.
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errdefs.IsUnavailable(err) || errdefs.IsDeadline(err) {
+		return true
+	}
+	var jsonErr *jsonmessage.JSONError
+	if errors.As(err, &jsonErr) && jsonErr.Code >= 500 {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, m := range transientErrorMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
@@ -17,8 +17,11 @@
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/streams"
 	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/pkg/errors"
 	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
 )
 
 func TestStringSliceReplaceAt(t *testing.T) {
@@ -248,3 +251,33 @@ func drainChannel(ctx context.Context, ch <-chan struct{}) {
 		}
 	}()
 }
+
+func TestIsTransientError(t *testing.T) {
+	testCases := []struct {
+		err       error
+		transient bool
+	}{
+		{err: nil},
+		{err: errors.New("manifest unknown")},
+		{err: &jsonmessage.JSONError{Message: "no matching manifest for linux/arm64 in the manifest list entries"}},
+		{err: errdefs.NotFound(errors.New("no such image"))},
+		{err: errors.Wrap(context.DeadlineExceeded, "pull")},
+		{err: context.Canceled},
+		{err: errdefs.Unavailable(errors.New("daemon is shutting down")), transient: true},
+		{err: &jsonmessage.JSONError{Code: 503, Message: "service unavailable"}, transient: true},
+		{err: errors.New("read tcp 10.0.0.1:443: read: connection reset by peer"), transient: true},
+		{err: errors.New("net/http: TLS handshake timeout"), transient: true},
+		{err: errors.Wrap(syscall.ECONNRESET, "read"), transient: true},
+		{err: errors.New("received unexpected HTTP status: 503 Service Unavailable"), transient: true},
+	}
+	for _, tc := range testCases {
+		assert.Check(t, is.Equal(command.IsTransientError(tc.err), tc.transient), "%v", tc.err)
+	}
+}
+
+func TestValidateProgressOpt(t *testing.T) {
+	for _, progress := range []string{"", command.ProgressAuto, command.ProgressJSON} {
+		assert.Check(t, command.ValidateProgressOpt(progress))
+	}
+	assert.Check(t, is.Error(command.ValidateProgressOpt("plain"), `invalid progress type "plain": must be "auto" or "json"`))
+}
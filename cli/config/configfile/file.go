@@ -35,6 +35,8 @@ type ConfigFile struct {
 	ConfigFormat         string                       `json:"configFormat,omitempty"`
 	NodesFormat          string                       `json:"nodesFormat,omitempty"`
 	PruneFilters         []string                     `json:"pruneFilters,omitempty"`
+	PullRetries          int                          `json:"pullRetries,omitempty"`
+	PullRetryBackoff     string                       `json:"pullRetryBackoff,omitempty"`
 	Proxies              map[string]ProxyConfig       `json:"proxies,omitempty"`
 	Experimental         string                       `json:"experimental,omitempty"`
 	CurrentContext       string                       `json:"currentContext,omitempty"`
@@ -0,0 +1,84 @@
+package transfer
+
+import (
+	"compress/gzip"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+// Compressor returns a stream of the data read from src, compressed.
+type Compressor func(src io.Reader) io.ReadCloser
+
+// Gzip compresses using gzip, with the default compression level.
+var Gzip = newCompressor(func(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriter(w), nil
+})
+
+// Zstd compresses using zstd, with the default compression level.
+var Zstd = newCompressor(func(w io.Writer) (io.WriteCloser, error) {
+	return zstd.NewWriter(w)
+})
+
+// ParseCompression parses a compression in the "<algorithm>[:<level>]"
+// format, and returns the Compressor to use, or nil if value is empty.
+func ParseCompression(value string) (Compressor, error) {
+	if value == "" {
+		return nil, nil
+	}
+	algorithm, lvl, hasLevel := strings.Cut(value, ":")
+	level := 0
+	if hasLevel {
+		var err error
+		if level, err = strconv.Atoi(lvl); err != nil {
+			return nil, errors.Errorf("invalid compression level: %s", lvl)
+		}
+	}
+
+	switch algorithm {
+	case "gzip":
+		if !hasLevel {
+			return Gzip, nil
+		}
+		if level < gzip.BestSpeed || level > gzip.BestCompression {
+			return nil, errors.Errorf("invalid compression level for gzip: %d (must be between %d and %d)", level, gzip.BestSpeed, gzip.BestCompression)
+		}
+		return newCompressor(func(w io.Writer) (io.WriteCloser, error) {
+			return gzip.NewWriterLevel(w, level)
+		}), nil
+	case "zstd":
+		if !hasLevel {
+			return Zstd, nil
+		}
+		if level < 1 || level > 22 {
+			return nil, errors.Errorf("invalid compression level for zstd: %d (must be between 1 and 22)", level)
+		}
+		return newCompressor(func(w io.Writer) (io.WriteCloser, error) {
+			return zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+		}), nil
+	default:
+		return nil, errors.Errorf("invalid compression: %s (must be gzip or zstd)", algorithm)
+	}
+}
+
+func newCompressor(newWriter func(io.Writer) (io.WriteCloser, error)) Compressor {
+	return func(src io.Reader) io.ReadCloser {
+		pr, pw := io.Pipe()
+		go func() {
+			w, err := newWriter(pw)
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			if _, err := io.Copy(w, src); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			pw.CloseWithError(w.Close())
+		}()
+		return pr
+	}
+}
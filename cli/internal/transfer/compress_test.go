@@ -0,0 +1,58 @@
+package transfer
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/pkg/archive"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestParseCompression(t *testing.T) {
+	testCases := []struct {
+		value       string
+		compression archive.Compression
+		expectedErr string
+	}{
+		{value: "gzip", compression: archive.Gzip},
+		{value: "gzip:1", compression: archive.Gzip},
+		{value: "zstd", compression: archive.Zstd},
+		{value: "zstd:19", compression: archive.Zstd},
+		{value: "xz", expectedErr: "invalid compression: xz (must be gzip or zstd)"},
+		{value: "gzip:fast", expectedErr: "invalid compression level: fast"},
+		{value: "gzip:10", expectedErr: "invalid compression level for gzip: 10 (must be between 1 and 9)"},
+		{value: "zstd:0", expectedErr: "invalid compression level for zstd: 0 (must be between 1 and 22)"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.value, func(t *testing.T) {
+			compress, err := ParseCompression(tc.value)
+			if tc.expectedErr != "" {
+				assert.Check(t, is.Error(err, tc.expectedErr))
+				return
+			}
+			assert.NilError(t, err)
+
+			r := compress(strings.NewReader("hello"))
+			defer r.Close()
+			compressed, err := io.ReadAll(r)
+			assert.NilError(t, err)
+			assert.Check(t, is.Equal(archive.DetectCompression(compressed), tc.compression))
+
+			d, err := archive.DecompressStream(bytes.NewReader(compressed))
+			assert.NilError(t, err)
+			content, err := io.ReadAll(d)
+			assert.NilError(t, err)
+			assert.Check(t, is.Equal(string(content), "hello"))
+		})
+	}
+}
+
+func TestParseCompressionEmpty(t *testing.T) {
+	compress, err := ParseCompression("")
+	assert.NilError(t, err)
+	assert.Check(t, compress == nil)
+}
@@ -0,0 +1,87 @@
+// Package transfer provides helpers to report the progress of transferring
+// archives between the CLI and the daemon, and to compress them.
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/docker/cli/cli/streams"
+	units "github.com/docker/go-units"
+	"github.com/morikuni/aec"
+)
+
+const progressUpdateInterval = 100 * time.Millisecond
+
+// CountingReader counts the bytes read from the underlying reader in N.
+type CountingReader struct {
+	io.Reader
+	N *int64
+}
+
+func (r *CountingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	atomic.AddInt64(r.N, int64(n))
+	return n, err
+}
+
+// Close closes the underlying reader if it's an io.Closer.
+func (r *CountingReader) Close() error {
+	if c, ok := r.Reader.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// ShowProgress prints header followed by the progress returned by the
+// progress function to dst, updating it on the same line until ctx is
+// cancelled. Nothing is printed if dst is not a terminal. The returned
+// function must be called after the done channel is closed to clear the
+// progress line.
+func ShowProgress(ctx context.Context, dst io.Writer, header string, progress func(elapsed time.Duration) string) (clear func(), done <-chan struct{}) {
+	ch := make(chan struct{})
+	if !streams.NewOut(dst).IsTerminal() {
+		close(ch)
+		return func() {}, ch
+	}
+
+	go func() {
+		defer close(ch)
+		_, _ = fmt.Fprint(dst, aec.Hide)
+		defer fmt.Fprint(dst, aec.Show)
+
+		start := time.Now()
+		last := ""
+		ticker := time.NewTicker(progressUpdateInterval)
+		defer ticker.Stop()
+		for {
+			if p := progress(time.Since(start)); p != last {
+				_, _ = fmt.Fprint(dst, "\r", aec.EraseLine(aec.EraseModes.All), header, p)
+				last = p
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return func() { _, _ = fmt.Fprint(dst, "\r", aec.EraseLine(aec.EraseModes.All)) }, ch
+}
+
+// HumanSize formats a number of bytes for progress output.
+func HumanSize(n int64) string {
+	return units.HumanSizeWithPrecision(float64(n), 3)
+}
+
+// Rate formats the number of bytes transferred, and the transfer rate.
+func Rate(n int64, elapsed time.Duration) string {
+	s := HumanSize(n)
+	if secs := elapsed.Seconds(); secs >= 1 {
+		s += fmt.Sprintf(" (%s/s)", units.HumanSizeWithPrecision(float64(n)/secs, 3))
+	}
+	return s
+}
@@ -23,6 +23,10 @@ type ImageManifest struct {
 	SchemaV2Manifest *schema2.DeserializedManifest `json:",omitempty"`
 	// OCIManifest is used for inspection
 	OCIManifest *ocischema.DeserializedManifest `json:",omitempty"`
+
+	// Config is the raw image configuration, if it was fetched from the
+	// registry. It's not stored in the local manifest store.
+	Config []byte `json:"-"`
 }
 
 // OCIPlatform creates an OCI platform from a manifest list platform spec
@@ -22,6 +22,7 @@
 type RegistryClient interface {
 	GetManifest(ctx context.Context, ref reference.Named) (manifesttypes.ImageManifest, error)
 	GetManifestList(ctx context.Context, ref reference.Named) ([]manifesttypes.ImageManifest, error)
+	GetBlob(ctx context.Context, ref reference.Named, dgst digest.Digest) ([]byte, error)
 	MountBlob(ctx context.Context, source reference.Canonical, target reference.Named) error
 	PutManifest(ctx context.Context, ref reference.Named, manifest distribution.Manifest) (digest.Digest, error)
 }
@@ -186,6 +187,19 @@ func (c *client) GetManifestList(ctx context.Context, ref reference.Named) ([]ma
 	return result, err
 }
 
+// GetBlob returns the content of a blob of the repository of the reference
+func (c *client) GetBlob(ctx context.Context, ref reference.Named, dgst digest.Digest) ([]byte, error) {
+	var result []byte
+	fetch := func(ctx context.Context, repo distribution.Repository, ref reference.Named) (bool, error) {
+		var err error
+		result, err = fetchBlob(ctx, repo, dgst)
+		return err == nil, err
+	}
+
+	err := c.iterateEndpoints(ctx, ref, fetch)
+	return result, err
+}
+
 func getManifestOptionsFromReference(ref reference.Named) (digest.Digest, []distribution.ManifestServiceOption, error) {
 	if tagged, isTagged := ref.(reference.NamedTagged); isTagged {
 		tag := tagged.Tag()
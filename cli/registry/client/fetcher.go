@@ -86,7 +86,9 @@ func pullManifestSchemaV2(ctx context.Context, ref reference.Named, repo distrib
 		return types.ImageManifest{}, err
 	}
 
-	return types.NewImageManifest(ref, manifestDesc, &mfst), nil
+	imageManifest := types.NewImageManifest(ref, manifestDesc, &mfst)
+	imageManifest.Config = configJSON
+	return imageManifest, nil
 }
 
 func pullManifestOCISchema(ctx context.Context, ref reference.Named, repo distribution.Repository, mfst ocischema.DeserializedManifest) (types.ImageManifest, error) {
@@ -108,7 +110,9 @@ func pullManifestOCISchema(ctx context.Context, ref reference.Named, repo distri
 		return types.ImageManifest{}, err
 	}
 
-	return types.NewOCIImageManifest(ref, manifestDesc, &mfst), nil
+	imageManifest := types.NewOCIImageManifest(ref, manifestDesc, &mfst)
+	imageManifest.Config = configJSON
+	return imageManifest, nil
 }
 
 func pullManifestSchemaV2ImageConfig(ctx context.Context, dgst digest.Digest, repo distribution.Repository) ([]byte, error) {
@@ -128,6 +132,23 @@ func pullManifestSchemaV2ImageConfig(ctx context.Context, dgst digest.Digest, re
 	return configJSON, nil
 }
 
+// fetchBlob pulls a blob from a registry, and verifies its digest.
+func fetchBlob(ctx context.Context, repo distribution.Repository, dgst digest.Digest) ([]byte, error) {
+	content, err := repo.Blobs(ctx).Get(ctx, dgst)
+	if err != nil {
+		return nil, err
+	}
+
+	verifier := dgst.Verifier()
+	if _, err := verifier.Write(content); err != nil {
+		return nil, err
+	}
+	if !verifier.Verified() {
+		return nil, errors.Errorf("blob verification failed for digest %s", dgst)
+	}
+	return content, nil
+}
+
 // validateManifestDigest computes the manifest digest, and, if pulling by
 // digest, ensures that it matches the requested digest.
 func validateManifestDigest(ref reference.Named, mfst distribution.Manifest) (ocispec.Descriptor, error) {
@@ -188,6 +209,9 @@ func pullManifestList(ctx context.Context, ref reference.Named, repo distributio
 		// Replace platform from config
 		p := manifestDescriptor.Platform
 		imageManifest.Descriptor.Platform = types.OCIPlatform(&p)
+		// Keep the annotations of the index, such as the ones of attestation
+		// manifests referring to the manifest they are attached to.
+		imageManifest.Descriptor.Annotations = manifestDescriptor.Annotations
 
 		infos = append(infos, imageManifest)
 	}
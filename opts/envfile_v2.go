@@ -0,0 +1,339 @@
+package opts
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// Env-file formats accepted by [ReadKVEnvStringsWithFormat].
+const (
+	// EnvFileFormatV1 is the original env-file format, see [ParseEnvFile].
+	EnvFileFormatV1 = "v1"
+	// EnvFileFormatV2 is the env-file format compatible with compose, see
+	// [ParseEnvFileV2].
+	EnvFileFormatV2 = "v2"
+)
+
+// ParseEnvFileV2 reads a file with environment variables, using a format
+// compatible with the env-files of compose:
+//
+//   - lines can start with "export ", which is ignored;
+//   - empty lines and lines starting with "#" are ignored;
+//   - unquoted values are trimmed, and a "#" preceded by a whitespace starts
+//     a comment;
+//   - values in single quotes are used as-is, and can span multiple lines;
+//   - values in double quotes can span multiple lines, and support the \n,
+//     \r, \t, \\, \", and \$ escape sequences;
+//   - in unquoted and double-quoted values, variables ($VAR, ${VAR},
+//     ${VAR:-default}, ${VAR-default}, ${VAR:?error}, ${VAR?error}) are
+//     replaced by the value of a variable defined earlier in the file, or
+//     of the environment. "$$" is replaced by a literal "$".
+//
+// As with [ParseEnvFile], a variable without "=" takes its value from the
+// environment, and is omitted if not set.
+func ParseEnvFileV2(filename string) ([]string, error) {
+	return parseEnvFileV2(filename, os.LookupEnv)
+}
+
+func parseEnvFileV2(filename string, lookupEnv func(string) (string, bool)) ([]string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return []string{}, err
+	}
+	data = bytes.TrimPrefix(data, []byte{0xEF, 0xBB, 0xBF})
+	if !utf8.Valid(data) {
+		return []string{}, fmt.Errorf("env file %s contains invalid utf8 bytes", filename)
+	}
+	p := &envFileParser{
+		src:       strings.ReplaceAll(string(data), "\r\n", "\n"),
+		line:      1,
+		vars:      map[string]string{},
+		lookupEnv: lookupEnv,
+	}
+	lines, err := p.parse()
+	if err != nil {
+		return []string{}, fmt.Errorf("env file %s: line %d: %w", filename, p.line, err)
+	}
+	return lines, nil
+}
+
+type envFileParser struct {
+	src  string
+	pos  int
+	line int
+
+	// vars are the variables defined so far, which are used for interpolation.
+	vars      map[string]string
+	lookupEnv func(string) (string, bool)
+}
+
+func (p *envFileParser) parse() ([]string, error) {
+	lines := []string{}
+	for {
+		p.skip(" \t\n")
+		if p.pos >= len(p.src) {
+			return lines, nil
+		}
+		if p.src[p.pos] == '#' {
+			p.skipLine()
+			continue
+		}
+
+		key, hasValue, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+		if !hasValue {
+			if value, ok := p.lookupEnv(key); ok {
+				p.vars[key] = value
+				lines = append(lines, key+"="+value)
+			}
+			continue
+		}
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		p.vars[key] = value
+		lines = append(lines, key+"="+value)
+	}
+}
+
+// parseKey parses the variable name, and the "=" following it if any.
+func (p *envFileParser) parseKey() (key string, hasValue bool, _ error) {
+	end := strings.IndexAny(p.src[p.pos:], "=\n")
+	if end < 0 {
+		end = len(p.src) - p.pos
+	}
+	key = strings.TrimSpace(p.src[p.pos : p.pos+end])
+	if k, ok := strings.CutPrefix(key, "export"); ok && k != "" && strings.ContainsAny(k[:1], whiteSpaces) {
+		key = strings.TrimLeft(k, whiteSpaces)
+	}
+	p.pos += end
+	hasValue = p.pos < len(p.src) && p.src[p.pos] == '='
+	if hasValue {
+		p.pos++
+	}
+
+	if key == "" {
+		return "", false, ErrBadKey{"no variable name"}
+	}
+	if strings.ContainsAny(key, whiteSpaces) {
+		return "", false, ErrBadKey{fmt.Sprintf("variable '%s' contains whitespaces", key)}
+	}
+	return key, hasValue, nil
+}
+
+func (p *envFileParser) parseValue() (string, error) {
+	p.skip(whiteSpaces)
+	if p.pos >= len(p.src) {
+		return "", nil
+	}
+
+	switch quote := p.src[p.pos]; quote {
+	case '\'', '"':
+		p.pos++
+		var value strings.Builder
+		for {
+			if p.pos >= len(p.src) {
+				return "", errors.New("unterminated quoted value")
+			}
+			c := p.src[p.pos]
+			p.pos++
+			switch {
+			case c == quote:
+				if err := p.endOfValue(); err != nil {
+					return "", err
+				}
+				if quote == '\'' {
+					return value.String(), nil
+				}
+				return p.expand(value.String())
+			case c == '\n':
+				p.line++
+				value.WriteByte(c)
+			case c == '\\' && quote == '"' && p.pos < len(p.src):
+				value.WriteString(unescapeEnvValue(p.src[p.pos]))
+				p.pos++
+			default:
+				value.WriteByte(c)
+			}
+		}
+	default:
+		end := strings.IndexByte(p.src[p.pos:], '\n')
+		if end < 0 {
+			end = len(p.src) - p.pos
+		}
+		value := p.src[p.pos : p.pos+end]
+		p.pos += end
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = value[:i]
+		}
+		if i := strings.Index(value, "\t#"); i >= 0 {
+			value = value[:i]
+		}
+		return p.expand(strings.TrimSpace(value))
+	}
+}
+
+// unescapeEnvValue returns the value of an escape sequence in a double-quoted
+// value. "\$" is kept as "$$", which is replaced by a literal "$" once the
+// variables are expanded.
+func unescapeEnvValue(c byte) string {
+	switch c {
+	case 'n':
+		return "\n"
+	case 'r':
+		return "\r"
+	case 't':
+		return "\t"
+	case '\\', '"':
+		return string(c)
+	case '$':
+		return "$$"
+	default:
+		return "\\" + string(c)
+	}
+}
+
+// endOfValue checks that the rest of the line after a quoted value is empty,
+// or a comment.
+func (p *envFileParser) endOfValue() error {
+	p.skip(whiteSpaces)
+	if p.pos < len(p.src) && p.src[p.pos] != '\n' {
+		if p.src[p.pos] != '#' {
+			return fmt.Errorf("unexpected character %q after quoted value", p.src[p.pos])
+		}
+		p.skipLine()
+	}
+	return nil
+}
+
+func (p *envFileParser) skip(chars string) {
+	for p.pos < len(p.src) && strings.IndexByte(chars, p.src[p.pos]) >= 0 {
+		if p.src[p.pos] == '\n' {
+			p.line++
+		}
+		p.pos++
+	}
+}
+
+func (p *envFileParser) skipLine() {
+	if end := strings.IndexByte(p.src[p.pos:], '\n'); end >= 0 {
+		p.pos += end
+	} else {
+		p.pos = len(p.src)
+	}
+}
+
+func (p *envFileParser) lookup(name string) (string, bool) {
+	if v, ok := p.vars[name]; ok {
+		return v, true
+	}
+	return p.lookupEnv(name)
+}
+
+// expand replaces the variables in s.
+func (p *envFileParser) expand(s string) (string, error) {
+	var out strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 >= len(s) {
+			out.WriteByte(s[i])
+			continue
+		}
+		switch next := s[i+1]; {
+		case next == '$':
+			out.WriteByte('$')
+			i++
+		case next == '{':
+			end := matchingBrace(s, i+2)
+			if end < 0 {
+				return "", fmt.Errorf("unterminated variable %q", s[i:])
+			}
+			v, err := p.expandExpression(s[i+2 : end])
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(v)
+			i = end
+		case isEnvNameChar(next, true):
+			end := i + 2
+			for end < len(s) && isEnvNameChar(s[end], false) {
+				end++
+			}
+			v, _ := p.lookup(s[i+1 : end])
+			out.WriteString(v)
+			i = end - 1
+		default:
+			out.WriteByte('$')
+		}
+	}
+	return out.String(), nil
+}
+
+// expandExpression returns the value of a "${...}" expression.
+func (p *envFileParser) expandExpression(expr string) (string, error) {
+	end := 0
+	for end < len(expr) && isEnvNameChar(expr[end], end == 0) {
+		end++
+	}
+	name, op := expr[:end], expr[end:]
+	if name == "" {
+		return "", fmt.Errorf("invalid variable name in \"${%s}\"", expr)
+	}
+	value, ok := p.lookup(name)
+
+	var arg string
+	switch {
+	case op == "":
+		return value, nil
+	case strings.HasPrefix(op, ":-"), strings.HasPrefix(op, ":?"):
+		arg, op = op[2:], op[:2]
+		ok = ok && value != ""
+	case strings.HasPrefix(op, "-"), strings.HasPrefix(op, "?"):
+		arg, op = op[1:], op[:1]
+	default:
+		return "", fmt.Errorf("invalid variable expression \"${%s}\"", expr)
+	}
+	if ok {
+		return value, nil
+	}
+	arg, err := p.expand(arg)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasSuffix(op, "?") {
+		if arg == "" {
+			arg = "not set"
+		}
+		return "", fmt.Errorf("variable %s: %s", name, arg)
+	}
+	return arg, nil
+}
+
+// matchingBrace returns the index of the "}" closing the expression starting
+// at start, taking nested expressions into account.
+func matchingBrace(s string, start int) int {
+	depth := 1
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isEnvNameChar(c byte, first bool) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (!first && c >= '0' && c <= '9')
+}
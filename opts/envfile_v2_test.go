@@ -0,0 +1,108 @@
+package opts
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestParseEnvFileV2(t *testing.T) {
+	content := `# comment
+FOO=bar
+export EXPORTED=yes
+  INDENTED = trimmed value   # a comment
+HASH=value#not-a-comment
+SINGLE='literal $FOO \n' # comment
+DOUBLE="tab\tnewline\n\"quoted\" \$FOO $FOO"
+MULTI="first line
+second line"
+MULTI_SINGLE='first
+second'
+INTERPOLATED=${FOO}-$FOO-${HOST_VAR}
+DEFAULT=${UNSET:-default $FOO}
+EMPTY=
+DEFAULT_EMPTY=${EMPTY-default}
+DEFAULT_EMPTY_COLON=${EMPTY:-default}
+DOLLAR=$$FOO
+HOST_VAR
+UNSET_HOST_VAR
+`
+	lookupEnv := func(name string) (string, bool) {
+		if name == "HOST_VAR" {
+			return "from-host", true
+		}
+		return "", false
+	}
+
+	lines, err := parseEnvFileV2(tmpFileWithContent(t, content), lookupEnv)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(lines, []string{
+		"FOO=bar",
+		"EXPORTED=yes",
+		"INDENTED=trimmed value",
+		"HASH=value#not-a-comment",
+		`SINGLE=literal $FOO \n`,
+		"DOUBLE=tab\tnewline\n\"quoted\" $FOO bar",
+		"MULTI=first line\nsecond line",
+		"MULTI_SINGLE=first\nsecond",
+		"INTERPOLATED=bar-bar-from-host",
+		"DEFAULT=default bar",
+		"EMPTY=",
+		"DEFAULT_EMPTY=",
+		"DEFAULT_EMPTY_COLON=default",
+		"DOLLAR=$FOO",
+		"HOST_VAR=from-host",
+	}))
+}
+
+func TestParseEnvFileV2Errors(t *testing.T) {
+	testCases := []struct {
+		content  string
+		expected string
+	}{
+		{
+			content:  "FOO=bar\nBAR=\"unterminated\n",
+			expected: "line 3: unterminated quoted value",
+		},
+		{
+			content:  "FOO='value' trailing\n",
+			expected: `line 1: unexpected character 't' after quoted value`,
+		},
+		{
+			content:  "\nFOO BAR=value\n",
+			expected: "line 2: poorly formatted environment: variable 'FOO BAR' contains whitespaces",
+		},
+		{
+			content:  "=value\n",
+			expected: "line 1: poorly formatted environment: no variable name",
+		},
+		{
+			content:  "FOO=${REQUIRED:?must be set}\n",
+			expected: "line 1: variable REQUIRED: must be set",
+		},
+		{
+			content:  "FOO=${BAR\n",
+			expected: `line 1: unterminated variable "${BAR"`,
+		},
+	}
+	for _, tc := range testCases {
+		_, err := parseEnvFileV2(tmpFileWithContent(t, tc.content), func(string) (string, bool) { return "", false })
+		assert.Check(t, is.ErrorContains(err, tc.expected), tc.content)
+	}
+}
+
+func TestReadKVEnvStringsWithFormat(t *testing.T) {
+	envFile := tmpFileWithContent(t, "FOO=\"quoted\"\nBAR=bar\n")
+
+	v1, err := ReadKVEnvStringsWithFormat(EnvFileFormatV1, []string{envFile}, []string{"BAR=override"})
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(v1, []string{`FOO="quoted"`, "BAR=bar", "BAR=override"}))
+
+	v2, err := ReadKVEnvStringsWithFormat(EnvFileFormatV2, []string{envFile}, []string{"BAR=override"})
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(v2, []string{"FOO=quoted", "BAR=bar", "BAR=override"}))
+
+	_, err = ReadKVEnvStringsWithFormat("v3", []string{envFile}, nil)
+	assert.Check(t, is.Error(err, `invalid env-file format "v3": must be "v1" or "v2"`))
+}
@@ -289,6 +289,20 @@ func ValidateLabel(value string) (string, error) {
 	return value, nil
 }
 
+// ValidateAnnotation validates that the specified string is a valid annotation,
+// in the "key=value" or "key" format, where "key" sets an annotation with an
+// empty value. Unlike labels, the key of an annotation can't contain whitespace.
+func ValidateAnnotation(value string) (string, error) {
+	key, _, _ := strings.Cut(value, "=")
+	if key == "" {
+		return "", fmt.Errorf("invalid annotation '%s': empty name", value)
+	}
+	if strings.ContainsAny(key, whiteSpaces) {
+		return "", fmt.Errorf("invalid annotation '%s': name contains whitespaces", value)
+	}
+	return value, nil
+}
+
 // ValidateSysctl validates a sysctl and returns it.
 func ValidateSysctl(val string) (string, error) {
 	validSysctlMap := map[string]bool{
@@ -249,6 +249,32 @@ func TestValidateDNSSearch(t *testing.T) {
 	}
 }
 
+func TestValidateAnnotation(t *testing.T) {
+	tests := []struct {
+		value       string
+		expectedErr string
+	}{
+		{value: "com.example.key=value"},
+		{value: "com.example.key="},
+		{value: "com.example.key=value with=equal-signs"},
+		{value: "com.example.key"},
+		{value: "", expectedErr: `invalid annotation '': empty name`},
+		{value: "=value", expectedErr: `invalid annotation '=value': empty name`},
+		{value: " key=value", expectedErr: `invalid annotation ' key=value': name contains whitespaces`},
+		{value: "com.example key=value", expectedErr: `invalid annotation 'com.example key=value': name contains whitespaces`},
+	}
+
+	for _, tc := range tests {
+		val, err := ValidateAnnotation(tc.value)
+		if tc.expectedErr != "" {
+			assert.Check(t, is.Error(err, tc.expectedErr), tc.value)
+			continue
+		}
+		assert.Check(t, is.Nil(err), tc.value)
+		assert.Check(t, is.Equal(val, tc.value))
+	}
+}
+
 func TestValidateLabel(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -2,6 +2,7 @@
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -22,6 +23,27 @@ func ReadKVEnvStrings(files []string, override []string) ([]string, error) {
 	return readKVStrings(files, override, os.LookupEnv)
 }
 
+// ReadKVEnvStringsWithFormat is like [ReadKVEnvStrings], but parses the files
+// using the given env-file format ([EnvFileFormatV1] or [EnvFileFormatV2]).
+func ReadKVEnvStringsWithFormat(format string, files []string, override []string) ([]string, error) {
+	switch format {
+	case "", EnvFileFormatV1:
+		return ReadKVEnvStrings(files, override)
+	case EnvFileFormatV2:
+		var variables []string
+		for _, ef := range files {
+			parsedVars, err := ParseEnvFileV2(ef)
+			if err != nil {
+				return nil, err
+			}
+			variables = append(variables, parsedVars...)
+		}
+		return append(variables, override...), nil
+	default:
+		return nil, fmt.Errorf("invalid env-file format %q: must be %q or %q", format, EnvFileFormatV1, EnvFileFormatV2)
+	}
+}
+
 func readKVStrings(files []string, override []string, emptyFn func(string) (string, bool)) ([]string, error) {
 	var variables []string
 	for _, ef := range files {
@@ -6,8 +6,11 @@
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"text/template"
+
+	units "github.com/docker/go-units"
 )
 
 // basicFunctions are the set of initial
@@ -25,13 +28,14 @@
 		// Remove the trailing new line added by the encoder
 		return strings.TrimSpace(buf.String())
 	},
-	"split":    strings.Split,
-	"join":     strings.Join,
-	"title":    strings.Title, //nolint:nolintlint,staticcheck // strings.Title is deprecated, but we only use it for ASCII, so replacing with golang.org/x/text is out of scope
-	"lower":    strings.ToLower,
-	"upper":    strings.ToUpper,
-	"pad":      padWithSpace,
-	"truncate": truncateWithLength,
+	"split":     strings.Split,
+	"join":      strings.Join,
+	"title":     strings.Title, //nolint:nolintlint,staticcheck // strings.Title is deprecated, but we only use it for ASCII, so replacing with golang.org/x/text is out of scope
+	"lower":     strings.ToLower,
+	"upper":     strings.ToUpper,
+	"pad":       padWithSpace,
+	"truncate":  truncateWithLength,
+	"humanSize": humanSize,
 }
 
 // HeaderFunctions are used to created headers of a table.
@@ -66,6 +70,9 @@
 	"truncate": func(v string, _ int) string {
 		return v
 	},
+	"humanSize": func(v string) string {
+		return v
+	},
 }
 
 // Parse creates a new anonymous template with the basic functions
@@ -101,3 +108,27 @@ func truncateWithLength(source string, length int) string {
 	}
 	return source[:length]
 }
+
+// humanSize formats a size in bytes in a human-readable form (e.g. "21.5MB").
+// A nil pointer results in an empty string.
+func humanSize(size any) (string, error) {
+	var f float64
+	switch v := size.(type) {
+	case int:
+		f = float64(v)
+	case int64:
+		f = float64(v)
+	case uint64:
+		f = float64(v)
+	case float64:
+		f = v
+	case *int64:
+		if v == nil {
+			return "", nil
+		}
+		f = float64(*v)
+	default:
+		return "", fmt.Errorf("humanSize: unsupported type %T", size)
+	}
+	return units.HumanSizeWithPrecision(f, 3), nil
+}
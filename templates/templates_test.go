@@ -89,3 +89,27 @@ func TestParseTruncateFunction(t *testing.T) {
 		})
 	}
 }
+
+func TestParseHumanSizeFunction(t *testing.T) {
+	size := int64(21500000)
+	testCases := []struct {
+		data     any
+		expected string
+	}{
+		{data: int64(2), expected: "2B"},
+		{data: 21500000, expected: "21.5MB"},
+		{data: float64(1e9), expected: "1GB"},
+		{data: &size, expected: "21.5MB"},
+		{data: (*int64)(nil), expected: ""},
+	}
+	tm, err := Parse(`{{humanSize .}}`)
+	assert.NilError(t, err)
+	for _, tc := range testCases {
+		var b bytes.Buffer
+		assert.NilError(t, tm.Execute(&b, tc.data))
+		assert.Check(t, is.Equal(tc.expected, b.String()))
+	}
+
+	var b bytes.Buffer
+	assert.Check(t, is.ErrorContains(tm.Execute(&b, "foo"), "humanSize: unsupported type string"))
+}